@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// linkTargets guarda la key creada para el titulo de cada fila del archivo, para resolver los
+// enlaces que refieren a otra fila. Un titulo repetido en filas creadas queda ambiguo ("")
+type linkTargets map[string]string
+
+func normalizeLinkTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+func (t linkTargets) add(title, issueKey string) {
+	key := normalizeLinkTitle(title)
+	if key == "" || issueKey == "" {
+		return
+	}
+	if existing, ok := t[key]; ok && existing != issueKey {
+		t[key] = ""
+		return
+	}
+	t[key] = issueKey
+}
+
+// resolve devuelve la key del destino: el destino mismo si es una key o la creada para la fila
+// con ese titulo
+func (t linkTargets) resolve(link entities.Link) (string, error) {
+	if link.TargetIsIssueKey() {
+		return link.Target, nil
+	}
+	issueKey, ok := t[normalizeLinkTitle(link.Target)]
+	if !ok {
+		return "", fmt.Errorf("no story titled %q was created from this file", link.Target)
+	}
+	if issueKey == "" {
+		return "", fmt.Errorf("more than one story titled %q was created from this file", link.Target)
+	}
+	return issueKey, nil
+}
+
+// createLinks crea los links de la columna enlaces de las historias creadas en jobs, despues de
+// crear todas, asi un enlace puede referir a una fila anterior o posterior del grupo. Un link que
+// falla queda como aviso en el resultado sin marcar la fila fallida
+func (uc *ProcessFilesUseCase) createLinks(ctx context.Context, jobs []storyJob, results []*entities.ProcessResult, targets linkTargets) {
+	for i, result := range results {
+		if result != nil && result.IssueKey != "" {
+			targets.add(jobs[i].story.Titulo, result.IssueKey)
+		}
+	}
+
+	for i, result := range results {
+		if result == nil || result.IssueKey == "" {
+			continue
+		}
+		for _, link := range jobs[i].story.Links {
+			uc.createLink(ctx, result, link, targets)
+		}
+	}
+}
+
+// createLink resuelve el destino del enlace y lo crea con la historia en el extremo que indica
+// su tipo. Jira lee inwardIssue=A, outwardIssue=B como "A blocks B": "blocks:PROJ-10" deja a la
+// historia como inwardIssue (bloquea a PROJ-10) y "is blocked by:PROJ-10" deja a PROJ-10 como
+// inwardIssue (PROJ-10 bloquea a la historia)
+func (uc *ProcessFilesUseCase) createLink(ctx context.Context, result *entities.ProcessResult, link entities.Link, targets linkTargets) {
+	targetKey, err := targets.resolve(link)
+	if err != nil {
+		result.AddWarning(fmt.Sprintf("could not link %s (%s): %v", result.IssueKey, link.Type, err))
+		return
+	}
+
+	inwardKey, outwardKey := result.IssueKey, targetKey
+	if link.Inward {
+		inwardKey, outwardKey = targetKey, result.IssueKey
+	}
+
+	if err := uc.jiraRepo.CreateIssueLink(ctx, inwardKey, outwardKey, link.Type); err != nil {
+		result.AddWarning(fmt.Sprintf("could not link %s to %s (%s): %v", result.IssueKey, targetKey, link.Type, err))
+	}
+}
@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"historiadorgo/internal/domain/repositories"
+)
+
+// ConfigCheck valida la configuracion cargada para el proyecto indicado y devuelve los problemas encontrados
+type ConfigCheck func(projectKey string) []string
+
+// PreflightSection es el resultado de una de las verificaciones del preflight
+type PreflightSection struct {
+	Name    string
+	Passed  bool
+	Details []string
+}
+
+// PreflightReport consolida configuracion, conexion y validacion de archivos pendientes
+type PreflightReport struct {
+	Sections []*PreflightSection
+	Files    *DirectoryValidationResult
+}
+
+// Passed indica si todas las secciones pasaron; sirve para condicionar una importacion programada
+func (r *PreflightReport) Passed() bool {
+	for _, section := range r.Sections {
+		if !section.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// PreflightUseCase compone las verificaciones existentes en un unico reporte apto / no apto
+type PreflightUseCase struct {
+	configCheck     ConfigCheck
+	jiraRepo        repositories.JiraRepository
+	validateUseCase *ValidateFileUseCase
+}
+
+func NewPreflightUseCase(configCheck ConfigCheck, jiraRepo repositories.JiraRepository, validateUseCase *ValidateFileUseCase) *PreflightUseCase {
+	return &PreflightUseCase{
+		configCheck:     configCheck,
+		jiraRepo:        jiraRepo,
+		validateUseCase: validateUseCase,
+	}
+}
+
+// Execute corre todas las secciones aunque alguna falle, para mostrar el panorama completo
+func (uc *PreflightUseCase) Execute(ctx context.Context, inputDir, projectKey string) *PreflightReport {
+	report := &PreflightReport{}
+
+	report.Sections = append(report.Sections, uc.checkConfig(projectKey))
+	report.Sections = append(report.Sections, uc.checkConnection(ctx, projectKey))
+
+	files, section := uc.checkFiles(ctx, inputDir)
+	report.Files = files
+	report.Sections = append(report.Sections, section)
+
+	return report
+}
+
+func (uc *PreflightUseCase) checkConfig(projectKey string) *PreflightSection {
+	section := &PreflightSection{Name: "Configuracion", Passed: true}
+	if uc.configCheck == nil {
+		return section
+	}
+
+	if problems := uc.configCheck(projectKey); len(problems) > 0 {
+		section.Passed = false
+		section.Details = problems
+	}
+	return section
+}
+
+// checkConnection prueba la autenticacion y, con proyecto, el proyecto y los tipos de issue que se usaran
+func (uc *PreflightUseCase) checkConnection(ctx context.Context, projectKey string) *PreflightSection {
+	section := &PreflightSection{Name: "Conexion con Jira", Passed: true}
+
+	if err := uc.jiraRepo.TestConnection(ctx); err != nil {
+		section.Passed = false
+		section.Details = append(section.Details, fmt.Sprintf("autenticacion: %v", err))
+		return section
+	}
+	section.Details = append(section.Details, "autenticacion correcta")
+
+	if projectKey == "" {
+		section.Details = append(section.Details, "sin proyecto: no se validan proyecto ni tipos de issue")
+		return section
+	}
+
+	checks := []struct {
+		name string
+		run  func() error
+	}{
+		{fmt.Sprintf("proyecto %s", projectKey), func() error { return uc.jiraRepo.ValidateProject(ctx, projectKey) }},
+		{"tipo de subtarea", func() error { return uc.jiraRepo.ValidateSubtaskIssueType(ctx, projectKey) }},
+		{"tipo Feature", func() error { return uc.jiraRepo.ValidateFeatureIssueType(ctx) }},
+	}
+
+	for _, check := range checks {
+		if err := check.run(); err != nil {
+			section.Passed = false
+			section.Details = append(section.Details, fmt.Sprintf("%s: %v", check.name, err))
+			continue
+		}
+		section.Details = append(section.Details, fmt.Sprintf("%s: OK", check.name))
+	}
+
+	return section
+}
+
+func (uc *PreflightUseCase) checkFiles(ctx context.Context, inputDir string) (*DirectoryValidationResult, *PreflightSection) {
+	section := &PreflightSection{Name: "Archivos pendientes", Passed: true}
+
+	// El proyecto ya se valida en la seccion de conexion
+	files, err := uc.validateUseCase.ExecuteDirectory(ctx, inputDir, "")
+	if err != nil {
+		section.Passed = false
+		section.Details = append(section.Details, err.Error())
+		return files, section
+	}
+
+	for _, file := range files.Files {
+		if file.Err != nil {
+			section.Passed = false
+			section.Details = append(section.Details, fmt.Sprintf("%s: %v", file.FilePath, file.Err))
+			continue
+		}
+		section.Details = append(section.Details, fmt.Sprintf("%s: %d historias", file.FilePath, file.Result.TotalStories))
+	}
+	for _, warning := range files.Totals.Warnings {
+		section.Details = append(section.Details, "aviso: "+warning)
+	}
+
+	return files, section
+}
@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/tests/fixtures"
+	"historiadorgo/tests/mocks"
+)
+
+func TestPreflightUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	fileRepo := &mocks.MockFileRepository{
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return []string{"entrada/ok.csv", "entrada/roto.csv"}, nil
+		},
+		ValidateFileFunc: func(ctx context.Context, filePath string) error {
+			if strings.Contains(filePath, "roto") {
+				return errors.New("missing required columns")
+			}
+			return nil
+		},
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{fixtures.ValidUserStory1(), fixtures.ValidUserStory2()}, nil
+		},
+	}
+
+	t.Run("mixed_outcomes", func(t *testing.T) {
+		jiraRepo := &mocks.MockJiraRepository{
+			ValidateSubtaskIssueTypeFunc: func(ctx context.Context, projectKey string) error {
+				return errors.New("subtask issue type 'Sub-task' not found")
+			},
+		}
+		configCheck := func(projectKey string) []string { return nil }
+
+		useCase := NewPreflightUseCase(configCheck, jiraRepo, NewValidateFileUseCase(fileRepo, jiraRepo))
+		report := useCase.Execute(ctx, "entrada", "PROJ")
+
+		if report.Passed() {
+			t.Error("Expected preflight to fail")
+		}
+		if len(report.Sections) != 3 {
+			t.Fatalf("Expected 3 sections, got %d", len(report.Sections))
+		}
+
+		configSection, connection, files := report.Sections[0], report.Sections[1], report.Sections[2]
+		if !configSection.Passed {
+			t.Errorf("Expected config section to pass, got %v", configSection.Details)
+		}
+		if connection.Passed || !containsDetail(connection.Details, "tipo de subtarea: subtask issue type") {
+			t.Errorf("Expected connection section to fail on the subtask type, got %+v", connection)
+		}
+		if !containsDetail(connection.Details, "proyecto PROJ: OK") {
+			t.Errorf("Expected project check to pass, got %v", connection.Details)
+		}
+		if files.Passed || !containsDetail(files.Details, "entrada/roto.csv: missing required columns") {
+			t.Errorf("Expected files section to report the broken file, got %+v", files)
+		}
+		if !containsDetail(files.Details, "entrada/ok.csv: 2 historias") {
+			t.Errorf("Expected files section to report the valid file, got %v", files.Details)
+		}
+	})
+
+	t.Run("config_problems_and_connection_failure", func(t *testing.T) {
+		projectValidated := false
+		jiraRepo := &mocks.MockJiraRepository{
+			TestConnectionFunc: func(ctx context.Context) error {
+				return errors.New("authentication failed: status 401")
+			},
+			ValidateProjectFunc: func(ctx context.Context, projectKey string) error {
+				projectValidated = true
+				return nil
+			},
+		}
+		configCheck := func(projectKey string) []string {
+			return []string{"sin proyecto: configurar PROJECT_KEY o usar --project"}
+		}
+
+		useCase := NewPreflightUseCase(configCheck, jiraRepo, NewValidateFileUseCase(fileRepo, jiraRepo))
+		report := useCase.Execute(ctx, "entrada", "PROJ")
+
+		if report.Sections[0].Passed || report.Sections[1].Passed {
+			t.Errorf("Expected config and connection sections to fail, got %+v %+v", report.Sections[0], report.Sections[1])
+		}
+		if projectValidated {
+			t.Error("Expected project checks to be skipped after an authentication failure")
+		}
+		if report.Files == nil || report.Files.ValidFiles != 1 {
+			t.Errorf("Expected files to be validated regardless of the connection, got %+v", report.Files)
+		}
+	})
+
+	t.Run("all_passing", func(t *testing.T) {
+		okRepo := &mocks.MockFileRepository{
+			GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+				return []string{"entrada/ok.csv"}, nil
+			},
+			ReadFileFunc: fileRepo.ReadFileFunc,
+		}
+		jiraRepo := &mocks.MockJiraRepository{}
+
+		useCase := NewPreflightUseCase(nil, jiraRepo, NewValidateFileUseCase(okRepo, jiraRepo))
+		if report := useCase.Execute(ctx, "entrada", "PROJ"); !report.Passed() {
+			t.Errorf("Expected preflight to pass, got %+v", report.Sections)
+		}
+	})
+}
+
+func containsDetail(details []string, substr string) bool {
+	for _, detail := range details {
+		if strings.Contains(detail, substr) {
+			return true
+		}
+	}
+	return false
+}
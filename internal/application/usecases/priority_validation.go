@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// checkPriorities compara la prioridad de cada fila con las configuradas en Jira y agrega un aviso
+// (no un error) por cada fila con una prioridad desconocida, que Jira rechazaria al crearla.
+// Solo consulta Jira si algun archivo usa la columna prioridad. Devuelve, alineados con results,
+// los avisos agregados a cada uno
+func (uc *ValidateFileUseCase) checkPriorities(ctx context.Context, results ...*ValidationResult) [][]string {
+	added := make([][]string, len(results))
+
+	used := false
+	for _, result := range results {
+		if len(result.priorities) > 0 {
+			used = true
+			break
+		}
+	}
+	if !used {
+		return added
+	}
+
+	known, err := uc.jiraRepo.GetPriorities(ctx)
+	if err != nil {
+		warning := fmt.Sprintf("no se pudieron consultar las prioridades de Jira: %v", err)
+		for i, result := range results {
+			if len(result.priorities) > 0 {
+				result.Warnings = append(result.Warnings, warning)
+				added[i] = append(added[i], warning)
+			}
+		}
+		return added
+	}
+	if len(known) == 0 {
+		return added
+	}
+
+	for i, result := range results {
+		for _, row := range result.Rows {
+			priority, ok := result.priorities[row.Row]
+			if !ok || knownPriority(known, priority) {
+				continue
+			}
+
+			warning := fmt.Sprintf("fila %d: la prioridad %q no existe en Jira (disponibles: %s)", row.Row, priority, strings.Join(known, ", "))
+			result.Warnings = append(result.Warnings, warning)
+			row.Warnings = append(row.Warnings, RowWarningUnknownPriority)
+			added[i] = append(added[i], warning)
+		}
+	}
+
+	return added
+}
+
+// knownPriority indica si priority es una de las prioridades de Jira; Jira las busca por nombre
+// sin distinguir mayusculas
+func knownPriority(known []string, priority string) bool {
+	for _, name := range known {
+		if strings.EqualFold(name, priority) {
+			return true
+		}
+	}
+	return false
+}
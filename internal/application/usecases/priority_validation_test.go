@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/tests/mocks"
+)
+
+func storyWithPriority(titulo, prioridad string) *entities.UserStory {
+	story := entities.NewUserStory(titulo, "Desc", "Criterio", "", "")
+	story.Prioridad = prioridad
+	return story
+}
+
+func TestValidateFileUseCase_CheckPriorities(t *testing.T) {
+	tests := []struct {
+		name          string
+		stories       []*entities.UserStory
+		projectKey    string
+		prioritiesErr error
+		wantLookups   int
+		wantWarnings  []string
+	}{
+		{
+			name:         "unknown_priority_warns",
+			stories:      []*entities.UserStory{storyWithPriority("Login", "high"), storyWithPriority("Logout", "Urgente"), storyWithPriority("Perfil", "")},
+			projectKey:   "PROJ",
+			wantLookups:  1,
+			wantWarnings: []string{`fila 3: la prioridad "Urgente" no existe en Jira (disponibles: High, Medium, Low)`},
+		},
+		{
+			name:        "no_priority_column_skips_lookup",
+			stories:     []*entities.UserStory{storyWithPriority("Login", "")},
+			projectKey:  "PROJ",
+			wantLookups: 0,
+		},
+		{
+			name:        "offline_validation_skips_lookup",
+			stories:     []*entities.UserStory{storyWithPriority("Login", "Urgente")},
+			wantLookups: 0,
+		},
+		{
+			name:          "lookup_failure_only_warns",
+			stories:       []*entities.UserStory{storyWithPriority("Login", "Urgente")},
+			projectKey:    "PROJ",
+			prioritiesErr: errors.New("status 403"),
+			wantLookups:   1,
+			wantWarnings:  []string{"no se pudieron consultar las prioridades de Jira: status 403"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups := 0
+			mockFileRepo := &mocks.MockFileRepository{
+				ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+					return tt.stories, nil
+				},
+			}
+			mockJiraRepo := &mocks.MockJiraRepository{
+				GetPrioritiesFunc: func(ctx context.Context) ([]string, error) {
+					lookups++
+					return []string{"High", "Medium", "Low"}, tt.prioritiesErr
+				},
+			}
+
+			result, err := NewValidateFileUseCase(mockFileRepo, mockJiraRepo).Execute(context.Background(), "stories.csv", tt.projectKey, 5)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("GetPriorities calls = %d, want %d", lookups, tt.wantLookups)
+			}
+			if strings.Join(result.Warnings, "|") != strings.Join(tt.wantWarnings, "|") {
+				t.Errorf("Warnings = %v, want %v", result.Warnings, tt.wantWarnings)
+			}
+			if len(result.Errors) != 0 {
+				t.Errorf("Expected priorities to never be errors, got %v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestValidateFileUseCase_CheckPriorities_Directory(t *testing.T) {
+	storiesByFile := map[string][]*entities.UserStory{
+		"/input/a.csv":  {storyWithPriority("Login", "High")},
+		"/input/b.xlsx": {storyWithPriority("Logout", ""), storyWithPriority("Perfil", "Urgente")},
+	}
+	mockFileRepo := &mocks.MockFileRepository{
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return []string{"/input/a.csv", "/input/b.xlsx"}, nil
+		},
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return storiesByFile[filePath], nil
+		},
+	}
+	lookups := 0
+	mockJiraRepo := &mocks.MockJiraRepository{
+		GetPrioritiesFunc: func(ctx context.Context) ([]string, error) {
+			lookups++
+			return []string{"High", "Low"}, nil
+		},
+	}
+
+	result, err := NewValidateFileUseCase(mockFileRepo, mockJiraRepo).ExecuteDirectory(context.Background(), "/input", "PROJ")
+	if err != nil {
+		t.Fatalf("ExecuteDirectory() error = %v", err)
+	}
+	if lookups != 1 {
+		t.Errorf("Expected priorities to be fetched once, got %d", lookups)
+	}
+
+	want := `/input/b.xlsx: fila 3: la prioridad "Urgente" no existe en Jira (disponibles: High, Low)`
+	if strings.Join(result.Totals.Warnings, "|") != want {
+		t.Errorf("Totals.Warnings = %v, want [%s]", result.Totals.Warnings, want)
+	}
+	rows := result.Files[1].Result.Rows
+	if strings.Join(rows[1].Warnings, ",") != RowWarningUnknownPriority || len(rows[0].Warnings) != 0 {
+		t.Errorf("Expected only row 3 of b.xlsx to carry %s, got %v / %v", RowWarningUnknownPriority, rows[0].Warnings, rows[1].Warnings)
+	}
+}
@@ -2,18 +2,119 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"historiadorgo/internal/domain/entities"
 	"historiadorgo/internal/domain/repositories"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
+// ErrFileAlreadyProcessed indica que un archivo con el mismo contenido ya fue procesado
+var ErrFileAlreadyProcessed = errors.New("file already processed")
+
+// Explainer recibe, por fila, como se resolvio cada campo enviado a Jira (modo --explain)
+type Explainer func(rowNumber int, decisions []string)
+
+// FieldMapping describe los destinos configurados para los campos en Jira, usado al explicar cada fila
+type FieldMapping struct {
+	IssueType               string
+	SubtaskIssueType        string
+	AcceptanceCriteriaField string
+	ParentBySummary         bool
+	EpicLinkField           string
+}
+
+// CrossProjectParentPolicy define que hacer cuando el parent resuelto pertenece a otro proyecto
+type CrossProjectParentPolicy int
+
+const (
+	CrossProjectParentAllow CrossProjectParentPolicy = iota
+	CrossProjectParentWarn
+	CrossProjectParentFail
+)
+
+// ProgressFunc recibe el avance de un archivo despues de cada fila: filas resueltas sobre el total.
+// total es 0 cuando todavia no se conoce (archivo leido por streaming)
+type ProgressFunc func(processed, total int)
+
+// FileSelector filtra los archivos pendientes antes de procesarlos (ej: seleccion interactiva)
+type FileSelector func(files []string) []string
+
 type ProcessFilesUseCase struct {
 	fileRepo    repositories.FileRepository
 	jiraRepo    repositories.JiraRepository
 	featureRepo repositories.FeatureManager
+	ledger      repositories.FileLedger
+	history     repositories.RunHistory
+	results     repositories.ResultsStore
+	force       bool
+	selector    FileSelector
+
+	// projectSeparator habilita el ruteo por nombre de archivo (ej: PROJ__historias.csv)
+	projectSeparator string
+
+	explainer    Explainer
+	fieldMapping FieldMapping
+
+	// featureLinkType habilita un link explicito (ej: "Relates") entre cada historia y su Feature
+	featureLinkType string
+
+	// skipFeatureValidation omite validar el tipo Feature aunque alguna fila lo necesite
+	skipFeatureValidation bool
+
+	crossProjectParents CrossProjectParentPolicy
+
+	// parentResolution es PARENT_RESOLUTION; decide que filas necesitan el tipo Feature
+	parentResolution entities.ParentResolution
+
+	// requestsPerSecond se usa para estimar la duracion de una ejecucion real en dry-run
+	requestsPerSecond int
+
+	// dryRunPrefix reemplaza DRY-RUN en las keys simuladas (ej: PROJ genera PROJ-1)
+	dryRunPrefix string
+
+	// featureSimilarity es el umbral para avisar de Features creadas casi iguales en la misma ejecucion
+	featureSimilarity float64
+
+	// failOnEmpty hace fallar una ejecucion real cuando el archivo no tiene filas procesables
+	failOnEmpty bool
+
+	// failOnPostCreateError marca la fila fallida si falla un paso posterior a crear la historia
+	failOnPostCreateError bool
+
+	// workers es la cantidad de historias que se procesan en paralelo; 0 o 1 procesa en secuencia
+	workers int
+
+	// rollbackOnFailure elimina todo lo creado en un archivo si alguna de sus filas falla
+	rollbackOnFailure bool
+
+	// resumeFrom es el resultado anterior que se completa con --resume; nil fuera de Resume
+	resumeFrom *entities.BatchResult
+
+	// progress es opcional; se invoca una vez por fila, incluidas las omitidas y las retomadas
+	progress ProgressFunc
+
+	// bulkCreator crea las historias de a grupos con un request por grupo; nil crea de a una
+	bulkCreator repositories.BulkStoryCreator
+
+	// streamer lee el archivo fila por fila en las ejecuciones reales; nil lo lee completo
+	streamer repositories.StoryStreamer
 }
 
+// streamChunkSize es la cantidad de filas leidas por streaming que se procesan juntas; coincide
+// con la cantidad de issues de un request de creacion masiva
+const streamChunkSize = repositories.BulkCreateLimit
+
+// errStreamStopped corta la lectura del archivo cuando el lote se detuvo (MAX_ISSUES_PER_RUN o
+// cancelacion); el motivo ya quedo en el resultado
+var errStreamStopped = errors.New("processing stopped")
+
+var filenameProjectPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
 func NewProcessFilesUseCase(
 	fileRepo repositories.FileRepository,
 	jiraRepo repositories.JiraRepository,
@@ -26,6 +127,109 @@ func NewProcessFilesUseCase(
 	}
 }
 
+// SetFileLedger habilita la deteccion de archivos ya procesados por hash de contenido
+func (uc *ProcessFilesUseCase) SetFileLedger(ledger repositories.FileLedger) {
+	uc.ledger = ledger
+}
+
+// SetRunHistory registra un resumen de cada archivo procesado en el historial local
+func (uc *ProcessFilesUseCase) SetRunHistory(history repositories.RunHistory) {
+	uc.history = history
+}
+
+// SetResultsStore guarda el resultado completo de cada archivo procesado (results.json)
+func (uc *ProcessFilesUseCase) SetResultsStore(store repositories.ResultsStore) {
+	uc.results = store
+}
+
+// SetForce permite reprocesar archivos aunque ya figuren en el ledger
+func (uc *ProcessFilesUseCase) SetForce(force bool) {
+	uc.force = force
+}
+
+// SetFileSelector permite elegir que archivos pendientes procesar en ProcessAllFiles
+func (uc *ProcessFilesUseCase) SetFileSelector(selector FileSelector) {
+	uc.selector = selector
+}
+
+// SetProjectFromFilename hace que ProcessAllFiles tome el proyecto del prefijo del nombre
+// de archivo hasta el separador; los archivos sin prefijo usan el proyecto por defecto
+func (uc *ProcessFilesUseCase) SetProjectFromFilename(separator string) {
+	uc.projectSeparator = separator
+}
+
+// SetExplainer habilita el detalle por fila de como se mapea cada campo a Jira
+func (uc *ProcessFilesUseCase) SetExplainer(explainer Explainer, mapping FieldMapping) {
+	uc.explainer = explainer
+	uc.fieldMapping = mapping
+}
+
+// SetFeatureLinkType crea, ademas del parent, un link del tipo indicado entre la historia y su Feature
+func (uc *ProcessFilesUseCase) SetFeatureLinkType(linkType string) {
+	uc.featureLinkType = linkType
+}
+
+// SetSkipFeatureValidation omite la validacion del tipo de issue Feature antes de procesar
+func (uc *ProcessFilesUseCase) SetSkipFeatureValidation(skip bool) {
+	uc.skipFeatureValidation = skip
+}
+
+// SetCrossProjectParentPolicy controla si un parent de otro proyecto se permite, avisa o falla la fila
+func (uc *ProcessFilesUseCase) SetCrossProjectParentPolicy(policy CrossProjectParentPolicy) {
+	uc.crossProjectParents = policy
+}
+
+// SetParentResolution indica como se resuelven los parents (PARENT_RESOLUTION) para validar el
+// tipo Feature solo cuando hace falta y estimar las llamadas del dry-run
+func (uc *ProcessFilesUseCase) SetParentResolution(resolution entities.ParentResolution) {
+	uc.parentResolution = resolution
+}
+
+// SetRequestsPerSecond fija la tasa usada para estimar el tiempo de las llamadas a Jira en dry-run
+func (uc *ProcessFilesUseCase) SetRequestsPerSecond(requestsPerSecond int) {
+	uc.requestsPerSecond = requestsPerSecond
+}
+
+// SetFailOnEmpty hace que una ejecucion real falle si el archivo no tiene filas procesables,
+// lo que suele indicar un error en el mapeo de columnas
+func (uc *ProcessFilesUseCase) SetFailOnEmpty(failOnEmpty bool) {
+	uc.failOnEmpty = failOnEmpty
+}
+
+// SetFailOnPostCreateError hace que un fallo posterior a crear la historia (ej: el link a la Feature)
+// marque la fila fallida en lugar de agregar un aviso; en ambos casos se conserva la key creada
+func (uc *ProcessFilesUseCase) SetFailOnPostCreateError(fail bool) {
+	uc.failOnPostCreateError = fail
+}
+
+// SetWorkers fija cuantas historias se crean en paralelo; el reporte mantiene el orden de las filas
+func (uc *ProcessFilesUseCase) SetWorkers(workers int) {
+	uc.workers = workers
+}
+
+// SetProgress registra un callback que informa el avance de cada archivo fila por fila
+func (uc *ProcessFilesUseCase) SetProgress(progress ProgressFunc) {
+	uc.progress = progress
+}
+
+// SetBulkCreator crea las historias de a repositories.BulkCreateLimit por request (USE_BULK_CREATE)
+// en lugar de una por request; con un creator no se usan los workers
+func (uc *ProcessFilesUseCase) SetBulkCreator(creator repositories.BulkStoryCreator) {
+	uc.bulkCreator = creator
+}
+
+// SetStoryStreamer hace que las ejecuciones reales lean el archivo fila por fila (STREAM_CSV) y
+// creen las historias de a streamChunkSize mientras se lee el resto. No aplica a dry-run ni a --resume
+func (uc *ProcessFilesUseCase) SetStoryStreamer(streamer repositories.StoryStreamer) {
+	uc.streamer = streamer
+}
+
+// SetRollbackOnBatchFailure hace que, si falla alguna fila de un archivo, se eliminen los issues
+// creados para ese archivo (subtareas, historias y Features) y el archivo quede pendiente
+func (uc *ProcessFilesUseCase) SetRollbackOnBatchFailure(rollback bool) {
+	uc.rollbackOnFailure = rollback
+}
+
 func (uc *ProcessFilesUseCase) Execute(ctx context.Context, filePath, projectKey string, dryRun bool) (*entities.BatchResult, error) {
 	// Solo validar inputs si no es dry-run
 	if !dryRun {
@@ -39,34 +243,536 @@ func (uc *ProcessFilesUseCase) Execute(ctx context.Context, filePath, projectKey
 		}
 	}
 
+	// El ledger solo aplica a ejecuciones reales sobre archivos locales
+	remote := repositories.IsRemoteSource(filePath)
+	var fileHash string
+	if !dryRun && !remote && uc.ledger != nil {
+		hash, err := uc.checkAlreadyProcessed(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		fileHash = hash
+	}
+
+	if uc.streamer != nil && !dryRun && uc.resumeFrom == nil {
+		return uc.executeStream(ctx, filePath, projectKey, fileHash)
+	}
+
 	stories, err := uc.fileRepo.ReadFile(ctx, filePath)
 	if err != nil {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
 
+	if !dryRun {
+		if uc.failOnEmpty && countProcessable(stories) == 0 {
+			return nil, fmt.Errorf("file %s has no processable rows (check the column mapping)", filepath.Base(filePath))
+		}
+		if err := uc.validateFeatureType(ctx, stories); err != nil {
+			return nil, err
+		}
+	}
+
 	fileName := filepath.Base(filePath)
 	batchResult := entities.NewBatchResult(fileName, len(stories), dryRun)
+	if dryRun {
+		batchResult.APIEstimate = entities.EstimateAPICalls(stories, uc.parentResolution, uc.requestsPerSecond)
+	}
+
+	uc.addEncodingWarning(ctx, batchResult, filePath)
+
+	// Con --resume las filas ya creadas se conservan y solo se procesan las demas; en dry-run
+	// sobre un resultado real no se reescribe el results.json original
+	var resumed map[int]*entities.ProcessResult
+	var orphaned []*entities.ProcessResult
+	if uc.resumeFrom != nil {
+		resumed, orphaned = uc.resumedRows(stories)
+		if !dryRun || uc.resumeFrom.DryRun {
+			batchResult.ResultsFile = uc.resumeFrom.ResultsFile
+		}
+	}
 
+	rowDone := uc.progressCounter(len(stories))
+
+	var jobs []storyJob
 	for i, story := range stories {
-		rowNumber := i + 2
-		result := uc.processUserStory(ctx, story, projectKey, rowNumber, dryRun)
+		if story.Skip {
+			batchResult.AddSkipped()
+			rowDone()
+			continue
+		}
+		if _, ok := resumed[i]; ok {
+			rowDone()
+			continue
+		}
+		jobs = append(jobs, storyJob{story: story, rowNumber: i + 2, parent: story.Parent})
+	}
+
+	// Los resultados se agregan en orden de fila aunque los workers terminen en otro orden
+	var results []*entities.ProcessResult
+	if uc.bulkCreator != nil && !dryRun {
+		results = uc.processStoriesBulk(ctx, jobs, projectKey, rowDone)
+	} else {
+		results = uc.processStories(ctx, jobs, projectKey, dryRun, rowDone)
+	}
+
+	// Los enlaces pueden referir a filas ya creadas en la ejecucion que se retoma
+	if !dryRun {
+		targets := make(linkTargets)
+		for i, result := range resumed {
+			targets.add(stories[i].Titulo, result.IssueKey)
+		}
+		uc.createLinks(ctx, jobs, results, targets)
+	}
+
+	createdFeatures, _ := uc.addResults(batchResult, jobs, results)
+
+	if uc.resumeFrom != nil {
+		mergeResumed(batchResult, resumed, orphaned)
+	}
+
+	return uc.finishBatch(ctx, batchResult, filePath, fileHash, createdFeatures, false), nil
+}
+
+// executeStream procesa el archivo mientras se lee: junta las filas de a streamChunkSize y las
+// crea antes de leer las siguientes, asi la memoria no depende del largo del archivo. El total
+// de filas recien se conoce al terminar; si la lectura falla despues de crear issues, el lote
+// queda abortado con lo creado hasta ese momento, que con ROLLBACK_ON_BATCH_FAILURE se elimina.
+// Un enlace no resuelve filas de grupos que todavia no se leyeron
+func (uc *ProcessFilesUseCase) executeStream(ctx context.Context, filePath, projectKey, fileHash string) (*entities.BatchResult, error) {
+	batchResult := entities.NewBatchResult(filepath.Base(filePath), 0, false)
+	uc.addEncodingWarning(ctx, batchResult, filePath)
+
+	rowDone := uc.progressCounter(0)
+
+	var (
+		rows, processable int
+		jobs              []storyJob
+		createdFeatures   []createdFeature
+		targets           = make(linkTargets)
+		featureTypeOK     bool
+		processErr        error
+	)
+	process := func() error {
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		// El tipo Feature se valida con el primer grupo que lo necesite
+		if !featureTypeOK {
+			chunk := make([]*entities.UserStory, len(jobs))
+			for i, job := range jobs {
+				chunk[i] = job.story
+			}
+			if needsFeatureType(chunk, uc.parentResolution) {
+				if processErr = uc.validateFeatureType(ctx, chunk); processErr != nil {
+					return processErr
+				}
+				featureTypeOK = true
+			}
+		}
+
+		var results []*entities.ProcessResult
+		if uc.bulkCreator != nil {
+			results = uc.processStoriesBulk(ctx, jobs, projectKey, rowDone)
+		} else {
+			results = uc.processStories(ctx, jobs, projectKey, false, rowDone)
+		}
+		uc.createLinks(ctx, jobs, results, targets)
+
+		features, stopped := uc.addResults(batchResult, jobs, results)
+		createdFeatures = append(createdFeatures, features...)
+		jobs = jobs[:0]
+		if stopped {
+			return errStreamStopped
+		}
+		return nil
+	}
+
+	err := uc.streamer.ReadFileStream(ctx, filePath, func(story *entities.UserStory) error {
+		rows++
+		if story.Skip {
+			batchResult.AddSkipped()
+			rowDone()
+			return nil
+		}
+		processable++
+		jobs = append(jobs, storyJob{story: story, rowNumber: rows + 1, parent: story.Parent})
+		if len(jobs) < streamChunkSize {
+			return nil
+		}
+		return process()
+	})
+	if err == nil {
+		err = process()
+	}
+	batchResult.TotalRows = rows
+
+	streamFailed := err != nil && !errors.Is(err, errStreamStopped)
+	if streamFailed {
+		// Sin issues creados el archivo queda como si no se hubiera procesado
+		if len(batchResult.Results) == 0 {
+			if processErr != nil {
+				return nil, processErr
+			}
+			return nil, fmt.Errorf("error reading file: %w", err)
+		}
+
+		nextRow := rows + 2
+		if len(jobs) > 0 {
+			nextRow = jobs[0].rowNumber
+		}
+		batchResult.Aborted = true
+		batchResult.AddError(fmt.Sprintf("Error: %v; rows from %d on were not processed", err, nextRow))
+	}
+
+	if uc.failOnEmpty && processable == 0 {
+		return nil, fmt.Errorf("file %s has no processable rows (check the column mapping)", filepath.Base(filePath))
+	}
+
+	return uc.finishBatch(ctx, batchResult, filePath, fileHash, createdFeatures, streamFailed), nil
+}
+
+// addEncodingWarning avisa que un archivo que no estaba en UTF-8 se transcodifico al leerlo, por
+// si quedaron caracteres mal
+func (uc *ProcessFilesUseCase) addEncodingWarning(ctx context.Context, batchResult *entities.BatchResult, filePath string) {
+	if encoding, err := uc.fileRepo.DetectEncoding(ctx, filePath); err == nil && encoding != "" && encoding != repositories.EncodingUTF8 {
+		batchResult.AddError(fmt.Sprintf("Warning: %s was transcoded from %s to UTF-8", batchResult.FileName, encoding))
+	}
+}
+
+// addResults agrega al lote los resultados de jobs, en orden de fila, y devuelve las Features
+// creadas. stopped indica que el lote se detuvo (cancelacion o MAX_ISSUES_PER_RUN) y las filas
+// siguientes no deben procesarse
+func (uc *ProcessFilesUseCase) addResults(batchResult *entities.BatchResult, jobs []storyJob, results []*entities.ProcessResult) (features []createdFeature, stopped bool) {
+	for i, result := range results {
+		if result == nil {
+			// Fila no procesada por cancelacion del contexto
+			batchResult.Aborted = true
+			batchResult.AddError(fmt.Sprintf("Error: processing canceled; rows from %d on were not processed", jobs[i].rowNumber))
+			return features, true
+		}
 		batchResult.AddResult(result)
+
+		// Con MAX_ISSUES_PER_RUN alcanzado no se crea nada mas; el archivo queda pendiente
+		if result.ErrorCode == entities.ErrorCodeIssueLimit {
+			batchResult.Aborted = true
+			batchResult.AddError(fmt.Sprintf("Error: %s; rows from %d on were not processed", result.ErrorMessage, jobs[i].rowNumber))
+			return features, true
+		}
+
+		if result.FeatureCreated && result.FeatureKey != "" {
+			features = append(features, createdFeature{key: result.FeatureKey, description: jobs[i].parent})
+		}
+	}
+
+	return features, false
+}
+
+// finishBatch cierra el lote de un archivo: rollback o avisos de Features duplicadas, historial,
+// paso a procesados con su registro en el ledger y results.json. failed indica que el archivo fallo
+// aunque ninguna fila tenga error (ej: la lectura por streaming se corto) y tambien se deshace
+func (uc *ProcessFilesUseCase) finishBatch(ctx context.Context, batchResult *entities.BatchResult, filePath, fileHash string, createdFeatures []createdFeature, failed bool) *entities.BatchResult {
+	dryRun := batchResult.DryRun
+	remote := repositories.IsRemoteSource(filePath)
+
+	rolledBack := false
+	if !dryRun && uc.rollbackOnFailure && (batchResult.ErrorRows > 0 || failed) {
+		uc.rollback(ctx, batchResult)
+		rolledBack = true
+	} else {
+		for _, warning := range uc.duplicateFeatureWarnings(createdFeatures) {
+			batchResult.AddError(warning)
+		}
 	}
 
 	batchResult.Finish()
 
-	if !dryRun && batchResult.SuccessfulRows > 0 {
+	if uc.history != nil {
+		if err := uc.history.Append(ctx, entities.NewRunSummary(batchResult)); err != nil {
+			batchResult.AddError(fmt.Sprintf("Warning: could not record run history: %v", err))
+		}
+	}
+
+	if !dryRun && !remote && !batchResult.Aborted && !rolledBack && batchResult.SuccessfulRows > 0 {
 		if err := uc.fileRepo.MoveToProcessed(ctx, filePath); err != nil {
 			batchResult.AddError(fmt.Sprintf("Warning: could not move file to processed: %v", err))
 		}
+
+		if fileHash != "" {
+			if err := uc.ledger.MarkProcessed(ctx, fileHash, filePath); err != nil {
+				batchResult.AddError(fmt.Sprintf("Warning: could not record file in state ledger: %v", err))
+			}
+		}
 	}
 
-	return batchResult, nil
+	// El artefacto se escribe al final para incluir todos los avisos; no poder escribirlo no hace fallar el lote
+	if uc.results != nil {
+		path, err := uc.results.Save(ctx, batchResult)
+		if err != nil {
+			batchResult.AddError(fmt.Sprintf("Warning: could not write results file: %v", err))
+		} else {
+			batchResult.ResultsFile = path
+		}
+	}
+
+	return batchResult
+}
+
+// rollback elimina los issues creados en el archivo: primero las subtareas, luego las historias y al
+// final las Features creadas, para no borrar un parent antes que sus hijos. Las historias que ya
+// existian (SKIP_EXISTING) no se tocan; los errores de las filas se conservan y cada eliminacion
+// fallida se informa aparte
+func (uc *ProcessFilesUseCase) rollback(ctx context.Context, batchResult *entities.BatchResult) {
+	var subtasks, stories, features []string
+	seenFeatures := make(map[string]bool)
+	for _, result := range batchResult.Results {
+		// Lo creado en una ejecucion anterior (--resume) no es parte de este intento
+		if result.Resumed {
+			continue
+		}
+		for _, subtask := range result.GetSuccessfulSubtasks() {
+			subtasks = append(subtasks, subtask.IssueKey)
+		}
+		if result.WasCreated && result.IssueKey != "" {
+			stories = append(stories, result.IssueKey)
+		}
+		if result.FeatureCreated && result.FeatureKey != "" && !seenFeatures[result.FeatureKey] {
+			seenFeatures[result.FeatureKey] = true
+			features = append(features, result.FeatureKey)
+		}
+	}
+
+	keys := append(append(subtasks, stories...), features...)
+	if len(keys) == 0 {
+		return
+	}
+
+	var failed int
+	for _, key := range keys {
+		// Un issue que ya no existe no queda en Jira: cuenta como deshecho
+		if err := uc.jiraRepo.DeleteIssue(ctx, key); err != nil && !errors.Is(err, repositories.ErrIssueNotFound) {
+			failed++
+			batchResult.AddError(fmt.Sprintf("Error: rollback could not delete %s: %v", key, err))
+			continue
+		}
+		batchResult.RolledBack = append(batchResult.RolledBack, key)
+		// Los archivos siguientes no deben reutilizar una Feature eliminada
+		if seenFeatures[key] {
+			uc.featureRepo.ForgetFeature(key)
+		}
+	}
+
+	if failed > 0 {
+		batchResult.AddError(fmt.Sprintf("Error: rollback incomplete; %d of %d issues created from %s remain in Jira", failed, len(keys), batchResult.FileName))
+	}
+}
+
+// storyJob es una fila a procesar junto a su numero de fila en el archivo; parent conserva la
+// descripcion original porque processUserStory la reemplaza por la key resuelta
+type storyJob struct {
+	story     *entities.UserStory
+	rowNumber int
+	parent    string
+}
+
+// processStories procesa las filas con hasta uc.workers goroutines y devuelve los resultados en el
+// mismo orden que jobs. Tras alcanzar MAX_ISSUES_PER_RUN o cancelarse el contexto no se procesan
+// filas nuevas y sus posiciones quedan en nil; las subtareas de cada historia siguen siendo secuenciales.
+// rowDone se llama al terminar cada fila, desde el worker que la proceso
+func (uc *ProcessFilesUseCase) processStories(ctx context.Context, jobs []storyJob, projectKey string, dryRun bool, rowDone func()) []*entities.ProcessResult {
+	results := make([]*entities.ProcessResult, len(jobs))
+
+	workers := uc.workers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	var stopped atomic.Bool
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if stopped.Load() || ctx.Err() != nil {
+					continue
+				}
+				result := uc.processUserStory(ctx, jobs[i].story, projectKey, jobs[i].rowNumber, dryRun)
+				if result.ErrorCode == entities.ErrorCodeIssueLimit {
+					stopped.Store(true)
+				}
+				results[i] = result
+				rowDone()
+			}
+		}()
+	}
+
+	for i := range jobs {
+		if stopped.Load() || ctx.Err() != nil {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// processStoriesBulk procesa las filas de a repositories.BulkCreateLimit: resuelve las Features de
+// cada una, crea el grupo con un solo request y despues completa cada fila. Como processStories,
+// deja en nil las filas no procesadas por cancelacion o por alcanzar MAX_ISSUES_PER_RUN
+func (uc *ProcessFilesUseCase) processStoriesBulk(ctx context.Context, jobs []storyJob, projectKey string, rowDone func()) []*entities.ProcessResult {
+	results := make([]*entities.ProcessResult, len(jobs))
+
+	for start := 0; start < len(jobs); start += repositories.BulkCreateLimit {
+		if ctx.Err() != nil {
+			break
+		}
+		end := min(start+repositories.BulkCreateLimit, len(jobs))
+
+		var ready []int
+		prepared := make(map[int]*entities.ProcessResult)
+		for i := start; i < end; i++ {
+			result, ok := uc.prepareStory(ctx, jobs[i].story, projectKey, jobs[i].rowNumber, false)
+			if !ok {
+				results[i] = result
+				rowDone()
+				continue
+			}
+			prepared[i] = result
+			ready = append(ready, i)
+		}
+		if len(ready) == 0 {
+			continue
+		}
+
+		stories := make([]*entities.UserStory, len(ready))
+		rowNumbers := make([]int, len(ready))
+		for n, i := range ready {
+			stories[n] = jobs[i].story
+			rowNumbers[n] = jobs[i].rowNumber
+		}
+
+		created, err := uc.bulkCreator.CreateUserStories(ctx, stories, projectKey, rowNumbers)
+		limitReached := false
+		for n, i := range ready {
+			var processResult *entities.ProcessResult
+			if n < len(created) {
+				processResult = created[n]
+			}
+			// El error del grupo solo aplica a las filas que quedaron sin resultado
+			rowErr := err
+			if processResult != nil {
+				rowErr = nil
+			}
+			results[i] = uc.finishStory(ctx, jobs[i].story, prepared[i], processResult, rowErr)
+			rowDone()
+			if results[i] != nil && results[i].ErrorCode == entities.ErrorCodeIssueLimit {
+				limitReached = true
+			}
+		}
+		if limitReached {
+			break
+		}
+	}
+
+	return results
+}
+
+// progressCounter devuelve la funcion que marca una fila terminada y avisa a uc.progress. Los
+// workers la llaman en paralelo; el lock mantiene la cuenta creciente en cada llamada
+func (uc *ProcessFilesUseCase) progressCounter(total int) func() {
+	if uc.progress == nil {
+		return func() {}
+	}
+
+	var mu sync.Mutex
+	processed := 0
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		processed++
+		uc.progress(processed, total)
+	}
+}
+
+// countProcessable cuenta las filas que no se omiten al procesar
+func countProcessable(stories []*entities.UserStory) int {
+	count := 0
+	for _, story := range stories {
+		if !story.Skip {
+			count++
+		}
+	}
+	return count
+}
+
+// createdFeature es una Feature creada durante la ejecucion junto a la descripcion que la origino
+type createdFeature struct {
+	key         string
+	description string
+}
+
+// duplicateFeatureWarnings avisa de pares de Features creadas en la ejecucion con descripciones
+// similares por encima del umbral; la deduplicacion deberia haberlas unificado
+func (uc *ProcessFilesUseCase) duplicateFeatureWarnings(features []createdFeature) []string {
+	threshold := uc.featureSimilarity
+	if threshold <= 0 {
+		threshold = entities.DefaultFeatureSimilarityThreshold
+	}
+
+	var warnings []string
+	for i := 0; i < len(features); i++ {
+		for j := i + 1; j < len(features); j++ {
+			if features[i].key == features[j].key {
+				continue
+			}
+			similarity := entities.FeatureDescriptionSimilarity(
+				entities.NormalizeFeatureDescription(features[i].description),
+				entities.NormalizeFeatureDescription(features[j].description),
+			)
+			if similarity >= threshold {
+				warnings = append(warnings, fmt.Sprintf(
+					"Warning: features %s (%q) and %s (%q) look like duplicates; consider keeping one and moving its stories",
+					features[i].key, features[i].description, features[j].key, features[j].description))
+			}
+		}
+	}
+
+	return warnings
+}
+
+func (uc *ProcessFilesUseCase) checkAlreadyProcessed(ctx context.Context, filePath string) (string, error) {
+	hash, err := uc.fileRepo.ComputeHash(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("error computing file hash: %w", err)
+	}
+
+	// --resume reprocesa a proposito un archivo ya registrado
+	if uc.force || uc.resumeFrom != nil {
+		return hash, nil
+	}
+
+	processed, err := uc.ledger.IsProcessed(ctx, hash)
+	if err != nil {
+		return "", fmt.Errorf("error checking state ledger: %w", err)
+	}
+
+	if processed {
+		return "", fmt.Errorf("%w: %s has identical content to a previous run (use --force to reprocess)", ErrFileAlreadyProcessed, filepath.Base(filePath))
+	}
+
+	return hash, nil
 }
 
 func (uc *ProcessFilesUseCase) ProcessAllFiles(ctx context.Context, inputDir, projectKey string, dryRun bool) ([]*entities.BatchResult, error) {
-	// Solo validar inputs si no es dry-run
-	if !dryRun {
+	// Solo validar inputs si no es dry-run; con ruteo por nombre cada archivo valida su proyecto en Execute
+	if !dryRun && uc.projectSeparator == "" {
 		if err := uc.validateInputs(ctx, projectKey); err != nil {
 			return nil, err
 		}
@@ -74,6 +780,10 @@ func (uc *ProcessFilesUseCase) ProcessAllFiles(ctx context.Context, inputDir, pr
 
 	files, err := uc.fileRepo.GetPendingFiles(ctx, inputDir)
 	if err != nil {
+		// Un directorio inexistente suele ser un error de configuracion, no "sin archivos"
+		if errors.Is(err, repositories.ErrInputDirectoryNotFound) {
+			return nil, fmt.Errorf("%w (check INPUT_DIRECTORY)", err)
+		}
 		return nil, fmt.Errorf("error getting pending files: %w", err)
 	}
 
@@ -81,20 +791,52 @@ func (uc *ProcessFilesUseCase) ProcessAllFiles(ctx context.Context, inputDir, pr
 		return nil, fmt.Errorf("no files found in %s", inputDir)
 	}
 
+	if uc.selector != nil {
+		files = uc.selector(files)
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no files selected")
+		}
+	}
+
 	var results []*entities.BatchResult
 	for _, file := range files {
-		result, err := uc.Execute(ctx, file, projectKey, dryRun)
+		fileProject := uc.projectForFile(file, projectKey)
+
+		var result *entities.BatchResult
+		if fileProject == "" {
+			err = fmt.Errorf("no project key for file (use PROYECTO%sarchivo or --project)", uc.projectSeparator)
+		} else {
+			result, err = uc.Execute(ctx, file, fileProject, dryRun)
+		}
 		if err != nil {
 			result = entities.NewBatchResult(filepath.Base(file), 0, dryRun)
 			result.AddError(fmt.Sprintf("Error processing file: %v", err))
 			result.Finish()
 		}
 		results = append(results, result)
+
+		if result.Aborted {
+			break
+		}
 	}
 
 	return results, nil
 }
 
+// projectForFile devuelve el proyecto codificado en el nombre del archivo o el proyecto por defecto
+func (uc *ProcessFilesUseCase) projectForFile(filePath, defaultProject string) string {
+	if uc.projectSeparator == "" {
+		return defaultProject
+	}
+
+	prefix, _, found := strings.Cut(filepath.Base(filePath), uc.projectSeparator)
+	if !found || !filenameProjectPattern.MatchString(prefix) {
+		return defaultProject
+	}
+
+	return strings.ToUpper(prefix)
+}
+
 func (uc *ProcessFilesUseCase) validateInputs(ctx context.Context, projectKey string) error {
 	if err := uc.jiraRepo.TestConnection(ctx); err != nil {
 		return fmt.Errorf("jira connection failed: %w", err)
@@ -104,10 +846,24 @@ func (uc *ProcessFilesUseCase) validateInputs(ctx context.Context, projectKey st
 		return fmt.Errorf("project validation failed: %w", err)
 	}
 
+	if err := uc.jiraRepo.ValidateStoryIssueType(ctx, projectKey); err != nil {
+		return fmt.Errorf("issue type validation failed: %w", err)
+	}
+
 	if err := uc.jiraRepo.ValidateSubtaskIssueType(ctx, projectKey); err != nil {
 		return fmt.Errorf("subtask type validation failed: %w", err)
 	}
 
+	return nil
+}
+
+// validateFeatureType valida el tipo Feature solo si alguna fila podria buscar o crear una Feature
+// segun PARENT_RESOLUTION (con key_first, si tiene un parent en texto libre en vez de una key)
+func (uc *ProcessFilesUseCase) validateFeatureType(ctx context.Context, stories []*entities.UserStory) error {
+	if uc.skipFeatureValidation || !needsFeatureType(stories, uc.parentResolution) {
+		return nil
+	}
+
 	if err := uc.jiraRepo.ValidateFeatureIssueType(ctx); err != nil {
 		return fmt.Errorf("feature type validation failed: %w", err)
 	}
@@ -115,24 +871,71 @@ func (uc *ProcessFilesUseCase) validateInputs(ctx context.Context, projectKey st
 	return nil
 }
 
+func needsFeatureType(stories []*entities.UserStory, resolution entities.ParentResolution) bool {
+	for _, story := range stories {
+		if !story.Skip && story.ParentSearchesFeature(resolution) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFeatureSimilarityThreshold fija el umbral con el que se detectan Features duplicadas tras la ejecucion
+func (uc *ProcessFilesUseCase) SetFeatureSimilarityThreshold(threshold float64) {
+	uc.featureSimilarity = threshold
+}
+
+// SetDryRunPrefix usa el prefijo indicado en las keys simuladas de dry-run; vacio mantiene DRY-RUN
+func (uc *ProcessFilesUseCase) SetDryRunPrefix(prefix string) {
+	uc.dryRunPrefix = strings.TrimSuffix(strings.TrimSpace(prefix), "-")
+}
+
+// dryRunKeys devuelve la key simulada de la historia y el formato de las keys de sus subtareas
+func (uc *ProcessFilesUseCase) dryRunKeys(rowNumber int) (string, string) {
+	if uc.dryRunPrefix == "" {
+		return fmt.Sprintf("DRY-RUN-%d", rowNumber), fmt.Sprintf("DRY-SUB-%d-%%d", rowNumber)
+	}
+	issueKey := fmt.Sprintf("%s-%d", uc.dryRunPrefix, rowNumber)
+	return issueKey, issueKey + "-%d"
+}
+
 func (uc *ProcessFilesUseCase) processUserStory(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int, dryRun bool) *entities.ProcessResult {
+	result, ready := uc.prepareStory(ctx, story, projectKey, rowNumber, dryRun)
+	if !ready {
+		return result
+	}
+
+	processResult, err := uc.jiraRepo.CreateUserStory(ctx, story, projectKey, rowNumber)
+	return uc.finishStory(ctx, story, result, processResult, err)
+}
+
+// prepareStory hace lo previo a crear la historia: simula la fila en dry-run y resuelve su
+// Feature. Devuelve ready=false si result ya es el resultado final de la fila
+func (uc *ProcessFilesUseCase) prepareStory(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int, dryRun bool) (*entities.ProcessResult, bool) {
 	result := entities.NewProcessResult(rowNumber)
+	result.Summary = story.Titulo
+	result.RowHash = story.ContentHash()
+
+	if uc.explainer != nil {
+		uc.explainer(rowNumber, uc.explainStory(story, projectKey))
+	}
 
 	if dryRun {
+		issueKey, subtaskKeyFormat := uc.dryRunKeys(rowNumber)
 		result.Success = true
-		result.IssueKey = fmt.Sprintf("DRY-RUN-%d", rowNumber)
-		result.IssueURL = fmt.Sprintf("https://dry-run.example.com/browse/DRY-RUN-%d", rowNumber)
+		result.IssueKey = issueKey
+		result.IssueURL = fmt.Sprintf("https://dry-run.example.com/browse/%s", issueKey)
 
 		// Simular subtareas en dry-run
 		if story.HasSubtareas() {
 			for i, subtarea := range story.GetValidSubtareas() {
-				subtaskKey := fmt.Sprintf("DRY-SUB-%d-%d", rowNumber, i+1)
+				subtaskKey := fmt.Sprintf(subtaskKeyFormat, i+1)
 				subtaskURL := fmt.Sprintf("https://dry-run.example.com/browse/%s", subtaskKey)
 				result.AddSubtaskResult(subtarea, true, subtaskKey, subtaskURL, "")
 			}
 		}
 
-		return result
+		return result, false
 	}
 
 	// Handle feature creation/resolution if story has parent
@@ -141,25 +944,157 @@ func (uc *ProcessFilesUseCase) processUserStory(ctx context.Context, story *enti
 		if err != nil {
 			result.Success = false
 			result.ErrorMessage = fmt.Sprintf("feature handling failed: %v", err)
-			return result
+			result.ErrorCode = errorCodeFor(err)
+			return result, false
 		}
 
 		// Update story with the resolved parent key
 		if featureResult.Success && featureResult.IssueKey != "" {
+			if parentProject := issueKeyProject(featureResult.IssueKey); !strings.EqualFold(parentProject, projectKey) {
+				switch uc.crossProjectParents {
+				case CrossProjectParentFail:
+					result.Success = false
+					result.ErrorMessage = fmt.Sprintf("parent %s belongs to project %s, not %s", featureResult.IssueKey, parentProject, projectKey)
+					return result, false
+				case CrossProjectParentWarn:
+					result.AddWarning(fmt.Sprintf("parent %s belongs to project %s, not %s", featureResult.IssueKey, parentProject, projectKey))
+				}
+			}
+
 			story.Parent = featureResult.IssueKey
+			result.SetFeature(featureResult.IssueKey, featureResult.WasCreated)
 		} else if !featureResult.Success {
 			result.Success = false
 			result.ErrorMessage = fmt.Sprintf("feature creation failed: %s", featureResult.ErrorMessage)
-			return result
+			return result, false
 		}
 	}
 
-	processResult, err := uc.jiraRepo.CreateUserStory(ctx, story, rowNumber)
+	return result, true
+}
+
+// finishStory completa el resultado de Jira con lo resuelto antes de crear la historia y la
+// vincula con su Feature; err es el error de la creacion
+func (uc *ProcessFilesUseCase) finishStory(ctx context.Context, story *entities.UserStory, result, processResult *entities.ProcessResult, err error) *entities.ProcessResult {
 	if err != nil {
 		result.Success = false
 		result.ErrorMessage = err.Error()
+		result.ErrorCode = errorCodeFor(err)
 		return result
 	}
 
+	if processResult != nil {
+		processResult.Summary = story.Titulo
+		processResult.RowHash = result.RowHash
+		processResult.Warnings = append(result.Warnings, processResult.Warnings...)
+	}
+
+	if processResult != nil && result.FeatureKey != "" {
+		processResult.SetFeature(result.FeatureKey, result.FeatureCreated)
+		uc.linkStoryToFeature(ctx, processResult)
+	}
+
 	return processResult
 }
+
+// errorCodeFor reconoce los errores que requieren un codigo explicito; el resto se
+// clasifica por mensaje al agregarse al BatchResult
+func errorCodeFor(err error) string {
+	if errors.Is(err, repositories.ErrIssueLimitReached) {
+		return entities.ErrorCodeIssueLimit
+	}
+	return ""
+}
+
+// issueKeyProject devuelve el prefijo de proyecto de una key de Jira (PROJ-123 -> PROJ)
+func issueKeyProject(issueKey string) string {
+	project, _, _ := strings.Cut(issueKey, "-")
+	return project
+}
+
+// linkStoryToFeature crea el link historia-Feature. Se intenta aunque la fila haya fallado despues
+// de crear la historia, porque el issue existe igual
+func (uc *ProcessFilesUseCase) linkStoryToFeature(ctx context.Context, result *entities.ProcessResult) {
+	if uc.featureLinkType == "" || result.IssueKey == "" {
+		return
+	}
+
+	if err := uc.jiraRepo.CreateIssueLink(ctx, result.IssueKey, result.FeatureKey, uc.featureLinkType); err != nil {
+		uc.postCreateFailure(result, fmt.Sprintf("could not link %s to feature %s: %v", result.IssueKey, result.FeatureKey, err))
+	}
+}
+
+// postCreateFailure registra un paso fallido sobre una historia ya creada sin descartar su key:
+// como aviso por defecto, o como error de la fila con SetFailOnPostCreateError
+func (uc *ProcessFilesUseCase) postCreateFailure(result *entities.ProcessResult, message string) {
+	if !uc.failOnPostCreateError {
+		result.AddWarning(message)
+		return
+	}
+
+	if result.Success {
+		result.Success = false
+		result.ErrorMessage = message
+		return
+	}
+	result.ErrorMessage = fmt.Sprintf("%s; %s", result.ErrorMessage, message)
+}
+
+// explainStory describe de donde sale cada campo del issue que se enviara a Jira
+func (uc *ProcessFilesUseCase) explainStory(story *entities.UserStory, projectKey string) []string {
+	mapping := uc.fieldMapping
+	decisions := []string{
+		fmt.Sprintf("project <- %s", projectKey),
+		fmt.Sprintf("summary <- columna titulo (%q)", story.Titulo),
+		fmt.Sprintf("issuetype <- config DEFAULT_ISSUE_TYPE (%s)", mapping.IssueType),
+		"description <- columna descripcion",
+	}
+
+	criteriaCount := len(entities.SplitMultiValue(story.CriterioAceptacion, ";"))
+	if mapping.AcceptanceCriteriaField != "" {
+		decisions = append(decisions, fmt.Sprintf("%s <- columna criterio_aceptacion (%d criterios, config ACCEPTANCE_CRITERIA_FIELD)", mapping.AcceptanceCriteriaField, criteriaCount))
+	} else {
+		decisions = append(decisions, fmt.Sprintf("description <- columna criterio_aceptacion anexada a la descripcion (%d criterios, sin ACCEPTANCE_CRITERIA_FIELD)", criteriaCount))
+	}
+
+	switch {
+	case !story.HasParent():
+		decisions = append(decisions, "parent <- sin parent (columna parent vacia)")
+	case story.ParentIsIssueKey():
+		decisions = append(decisions, fmt.Sprintf("parent <- columna parent %q tratado como key de Jira existente", story.Parent))
+	case mapping.ParentBySummary:
+		decisions = append(decisions, fmt.Sprintf("parent <- columna parent %q tratado como summary de un issue existente (config PARENT_BY_SUMMARY)", story.Parent))
+	default:
+		decisions = append(decisions, fmt.Sprintf("parent <- columna parent %q tratado como descripcion de Feature (se busca o crea la Feature)", story.Parent))
+	}
+	if story.HasParent() && mapping.EpicLinkField != "" {
+		decisions = append(decisions, fmt.Sprintf("%s <- parent resuelto si es un Epic, en lugar de parent (config EPIC_LINK_FIELD)", mapping.EpicLinkField))
+	}
+
+	if story.Environment != "" {
+		decisions = append(decisions, "environment <- columna environment")
+	}
+
+	if len(story.Labels) > 0 {
+		decisions = append(decisions, fmt.Sprintf("labels <- columna labels (%s)", strings.Join(story.Labels, ", ")))
+	}
+
+	if story.Prioridad != "" {
+		decisions = append(decisions, fmt.Sprintf("priority <- columna prioridad (%q)", story.Prioridad))
+	}
+
+	if story.Assignee != "" {
+		decisions = append(decisions, fmt.Sprintf("assignee <- columna asignado (%q)", story.Assignee))
+	}
+
+	if story.HasSubtareas() {
+		valid := len(story.GetValidSubtareas())
+		subtaskType := fmt.Sprintf("%s (config SUBTASK_ISSUE_TYPE)", mapping.SubtaskIssueType)
+		if story.SubtaskType != "" {
+			subtaskType = fmt.Sprintf("%s (columna subtask_type)", story.SubtaskType)
+		}
+		decisions = append(decisions, fmt.Sprintf("subtareas <- columna subtareas (%d validas, %d invalidas) como %s", valid, len(story.Subtareas)-valid, subtaskType))
+	}
+
+	return decisions
+}
@@ -3,10 +3,16 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
 	"historiadorgo/tests/fixtures"
 	"historiadorgo/tests/mocks"
 )
@@ -108,7 +114,7 @@ func TestProcessFilesUseCase_Execute(t *testing.T) {
 			}
 
 			mockJiraRepo := &mocks.MockJiraRepository{
-				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 					if tt.jiraError != nil {
 						result := entities.NewProcessResult(story.Row)
 						result.Success = false
@@ -190,7 +196,7 @@ func TestProcessFilesUseCase_Execute_BatchProcessing(t *testing.T) {
 
 	createCallCount := 0
 	mockJiraRepo := &mocks.MockJiraRepository{
-		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 			createCallCount++
 			result := entities.NewProcessResult(story.Row)
 			result.Success = true
@@ -246,7 +252,7 @@ func TestProcessFilesUseCase_Execute_MixedResults(t *testing.T) {
 	}
 
 	mockJiraRepo := &mocks.MockJiraRepository{
-		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 			result := entities.NewProcessResult(story.Row)
 			if story.Row == 1 {
 				result.Success = true
@@ -367,7 +373,7 @@ func TestProcessFilesUseCase_Execute_FileValidationErrors(t *testing.T) {
 				ValidateFeatureIssueTypeFunc: func(ctx context.Context) error {
 					return nil
 				},
-				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 					return fixtures.SuccessProcessResult(), nil
 				},
 			}
@@ -438,6 +444,15 @@ func TestProcessFilesUseCase_ProcessAllFiles_ErrorPaths(t *testing.T) {
 			wantError:       true,
 			wantErrorString: "no files found",
 		},
+		{
+			name:            "input directory does not exist",
+			inputDir:        "/missing",
+			projectKey:      "PROJ",
+			dryRun:          true,
+			getFilesError:   fmt.Errorf("%w: /missing", repositories.ErrInputDirectoryNotFound),
+			wantError:       true,
+			wantErrorString: "input directory does not exist: /missing",
+		},
 		{
 			name:             "single file processing error creates error result",
 			inputDir:         "/input",
@@ -619,7 +634,7 @@ func TestProcessFilesUseCase_ProcessAllFiles(t *testing.T) {
 				ValidateFeatureIssueTypeFunc: func(ctx context.Context) error {
 					return tt.validationError
 				},
-				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 					result := entities.NewProcessResult(rowNumber)
 					result.Success = true
 					result.IssueKey = "PROJ-123"
@@ -667,8 +682,8 @@ func TestProcessFilesUseCase_validateInputs(t *testing.T) {
 		projectKey            string
 		connectionError       error
 		projectError          error
+		storyTypeError        error
 		subtaskTypeError      error
-		featureTypeError      error
 		wantError             bool
 		expectedErrorContains string
 	}{
@@ -692,18 +707,18 @@ func TestProcessFilesUseCase_validateInputs(t *testing.T) {
 			expectedErrorContains: "project validation failed",
 		},
 		{
-			name:                  "subtask type validation fails",
+			name:                  "story issue type validation fails",
 			projectKey:            "PROJ",
-			subtaskTypeError:      errors.New("subtask type not found"),
+			storyTypeError:        errors.New("issue type 'Story' is not available in project 'PROJ' (available: Task, Bug)"),
 			wantError:             true,
-			expectedErrorContains: "subtask type validation failed",
+			expectedErrorContains: "available: Task, Bug",
 		},
 		{
-			name:                  "feature type validation fails",
+			name:                  "subtask type validation fails",
 			projectKey:            "PROJ",
-			featureTypeError:      errors.New("feature type not found"),
+			subtaskTypeError:      errors.New("subtask type not found"),
 			wantError:             true,
-			expectedErrorContains: "feature type validation failed",
+			expectedErrorContains: "subtask type validation failed",
 		},
 	}
 
@@ -716,11 +731,15 @@ func TestProcessFilesUseCase_validateInputs(t *testing.T) {
 				ValidateProjectFunc: func(ctx context.Context, projectKey string) error {
 					return tt.projectError
 				},
+				ValidateStoryIssueTypeFunc: func(ctx context.Context, projectKey string) error {
+					return tt.storyTypeError
+				},
 				ValidateSubtaskIssueTypeFunc: func(ctx context.Context, projectKey string) error {
 					return tt.subtaskTypeError
 				},
 				ValidateFeatureIssueTypeFunc: func(ctx context.Context) error {
-					return tt.featureTypeError
+					t.Error("validateInputs should not validate the feature type")
+					return nil
 				},
 			}
 
@@ -744,6 +763,100 @@ func TestProcessFilesUseCase_validateInputs(t *testing.T) {
 	}
 }
 
+func TestProcessFilesUseCase_Execute_FeatureTypeValidation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		stories        []*entities.UserStory
+		skipValidation bool
+		resolution     entities.ParentResolution
+		wantValidated  bool
+	}{
+		{
+			name:          "free-text parent validates feature type",
+			stories:       []*entities.UserStory{fixtures.UserStoryWithParent()},
+			wantValidated: true,
+		},
+		{
+			name: "no free-text parents skips feature validation",
+			stories: []*entities.UserStory{
+				fixtures.ValidUserStory1(),
+				entities.NewUserStory("Historia con key", "Descripcion", "Criterio", "", "PROJ-123"),
+			},
+			wantValidated: false,
+		},
+		{
+			name:           "SKIP_FEATURE_VALIDATION skips even with free-text parents",
+			stories:        []*entities.UserStory{fixtures.UserStoryWithParent()},
+			skipValidation: true,
+			wantValidated:  false,
+		},
+		{
+			name:          "feature_first validates feature type for key parents",
+			stories:       []*entities.UserStory{entities.NewUserStory("Historia con key", "Descripcion", "Criterio", "", "PROJ-123")},
+			resolution:    entities.ParentResolutionFeatureFirst,
+			wantValidated: true,
+		},
+		{
+			name:          "feature_only validates feature type for key parents",
+			stories:       []*entities.UserStory{entities.NewUserStory("Historia con key", "Descripcion", "Criterio", "", "PROJ-123")},
+			resolution:    entities.ParentResolutionFeatureOnly,
+			wantValidated: true,
+		},
+		{
+			name:          "key_only skips feature validation with free-text parents",
+			stories:       []*entities.UserStory{fixtures.UserStoryWithParent()},
+			resolution:    entities.ParentResolutionKeyOnly,
+			wantValidated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validated := false
+			mockFileRepo := &mocks.MockFileRepository{
+				ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+					return tt.stories, nil
+				},
+			}
+			mockJiraRepo := &mocks.MockJiraRepository{
+				ValidateFeatureIssueTypeFunc: func(ctx context.Context) error {
+					validated = true
+					return errors.New("feature type not found")
+				},
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+					return fixtures.SuccessProcessResult(), nil
+				},
+			}
+			mockFeatureRepo := &mocks.MockFeatureManager{
+				CreateOrGetFeatureFunc: func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
+					result := entities.NewFeatureResult(description)
+					result.SetExisting("PROJ-900")
+					return result, nil
+				},
+			}
+
+			useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, mockFeatureRepo)
+			useCase.SetSkipFeatureValidation(tt.skipValidation)
+			useCase.SetParentResolution(tt.resolution)
+
+			_, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+
+			if validated != tt.wantValidated {
+				t.Errorf("feature type validated = %v, want %v", validated, tt.wantValidated)
+			}
+			if tt.wantValidated {
+				if err == nil || !strings.Contains(err.Error(), "feature type validation failed") {
+					t.Errorf("Execute() error = %v, want feature type validation failure", err)
+				}
+			} else if err != nil {
+				t.Errorf("Execute() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
 func TestProcessFilesUseCase_processUserStory_DryRun(t *testing.T) {
 	ctx := context.Background()
 
@@ -810,7 +923,7 @@ func TestProcessFilesUseCase_processUserStory_Production(t *testing.T) {
 			story := fixtures.ValidUserStory1()
 
 			mockJiraRepo := &mocks.MockJiraRepository{
-				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 					if tt.jiraError != nil {
 						return nil, tt.jiraError
 					}
@@ -895,7 +1008,7 @@ func TestProcessFilesUseCase_processUserStory_WithFeature(t *testing.T) {
 			}
 
 			mockJiraRepo := &mocks.MockJiraRepository{
-				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 					result := entities.NewProcessResult(rowNumber)
 					result.Success = true
 					result.IssueKey = "PROJ-123"
@@ -921,6 +1034,218 @@ func TestProcessFilesUseCase_processUserStory_WithFeature(t *testing.T) {
 	}
 }
 
+func TestProcessFilesUseCase_processUserStory_CrossProjectParent(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		policy       CrossProjectParentPolicy
+		featureKey   string
+		wantSuccess  bool
+		wantCreated  bool
+		wantWarnings int
+	}{
+		{name: "allow ignores other project", policy: CrossProjectParentAllow, featureKey: "OTHER-10", wantSuccess: true, wantCreated: true},
+		{name: "warn keeps the story", policy: CrossProjectParentWarn, featureKey: "OTHER-10", wantSuccess: true, wantCreated: true, wantWarnings: 1},
+		{name: "fail rejects the row", policy: CrossProjectParentFail, featureKey: "OTHER-10", wantSuccess: false},
+		{name: "same project passes with fail policy", policy: CrossProjectParentFail, featureKey: "proj-10", wantSuccess: true, wantCreated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			mockJiraRepo := &mocks.MockJiraRepository{
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+					created = true
+					return fixtures.SuccessProcessResult(), nil
+				},
+			}
+			mockFeatureRepo := &mocks.MockFeatureManager{
+				CreateOrGetFeatureFunc: func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
+					result := entities.NewFeatureResult(description)
+					result.SetExisting(tt.featureKey)
+					return result, nil
+				},
+			}
+
+			useCase := NewProcessFilesUseCase(&mocks.MockFileRepository{}, mockJiraRepo, mockFeatureRepo)
+			useCase.SetCrossProjectParentPolicy(tt.policy)
+
+			result := useCase.processUserStory(ctx, fixtures.UserStoryWithParent(), "PROJ", 2, false)
+
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Success = %v, want %v (error: %s)", result.Success, tt.wantSuccess, result.ErrorMessage)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("story created = %v, want %v", created, tt.wantCreated)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+			if !tt.wantSuccess && !strings.Contains(result.ErrorMessage, "belongs to project OTHER") {
+				t.Errorf("ErrorMessage = %q, want cross-project message", result.ErrorMessage)
+			}
+		})
+	}
+}
+
+func TestProcessFilesUseCase_processUserStory_FeatureLink(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		linkType    string
+		linkError   error
+		wantLinks   int
+		wantWarning bool
+	}{
+		{
+			name:      "link created",
+			linkType:  "Relates",
+			wantLinks: 1,
+		},
+		{
+			name:        "link failure is a warning",
+			linkType:    "Relates",
+			linkError:   errors.New("status 404"),
+			wantLinks:   1,
+			wantWarning: true,
+		},
+		{
+			name:      "linking disabled",
+			wantLinks: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFeatureRepo := &mocks.MockFeatureManager{
+				CreateOrGetFeatureFunc: func(ctx context.Context, featureDesc, projectKey string) (*entities.FeatureResult, error) {
+					result := entities.NewFeatureResult(featureDesc)
+					result.SetSuccess("PROJ-10", "https://example.com/browse/PROJ-10", false)
+					return result, nil
+				},
+			}
+
+			links := 0
+			mockJiraRepo := &mocks.MockJiraRepository{
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+					result := entities.NewProcessResult(rowNumber)
+					result.Success = true
+					result.IssueKey = "PROJ-123"
+					return result, nil
+				},
+				CreateIssueLinkFunc: func(ctx context.Context, inwardKey, outwardKey, linkType string) error {
+					links++
+					if linkType != "Relates" || inwardKey != "PROJ-123" || outwardKey != "PROJ-10" {
+						t.Errorf("Unexpected link %s %s -> %s", linkType, inwardKey, outwardKey)
+					}
+					return tt.linkError
+				},
+			}
+
+			useCase := NewProcessFilesUseCase(&mocks.MockFileRepository{}, mockJiraRepo, mockFeatureRepo)
+			useCase.SetFeatureLinkType(tt.linkType)
+
+			result := useCase.processUserStory(ctx, fixtures.UserStoryWithParent(), "PROJ", 2, false)
+
+			if !result.Success {
+				t.Errorf("Expected story to succeed, got error: %s", result.ErrorMessage)
+			}
+			if links != tt.wantLinks {
+				t.Errorf("Expected %d link calls, got %d", tt.wantLinks, links)
+			}
+			if hasWarning := len(result.Warnings) > 0; hasWarning != tt.wantWarning {
+				t.Errorf("Expected warning = %v, got %v", tt.wantWarning, result.Warnings)
+			}
+		})
+	}
+}
+
+func TestProcessFilesUseCase_processUserStory_PostCreateFailureKeepsKey(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		failOnError   bool
+		storyFailed   bool
+		wantSuccess   bool
+		wantWarnings  int
+		wantErrSubstr string
+	}{
+		{
+			name:         "link failure is a warning by default",
+			wantSuccess:  true,
+			wantWarnings: 1,
+		},
+		{
+			name:          "link failure fails the row when configured",
+			failOnError:   true,
+			wantSuccess:   false,
+			wantErrSubstr: "could not link PROJ-123",
+		},
+		{
+			name:          "story already failed after creation is still linked",
+			failOnError:   true,
+			storyFailed:   true,
+			wantSuccess:   false,
+			wantErrSubstr: "all 1 subtasks failed; could not link PROJ-123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFeatureRepo := &mocks.MockFeatureManager{
+				CreateOrGetFeatureFunc: func(ctx context.Context, featureDesc, projectKey string) (*entities.FeatureResult, error) {
+					result := entities.NewFeatureResult(featureDesc)
+					result.SetSuccess("PROJ-10", "https://example.com/browse/PROJ-10", false)
+					return result, nil
+				},
+			}
+
+			links := 0
+			mockJiraRepo := &mocks.MockJiraRepository{
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+					result := entities.NewProcessResult(rowNumber)
+					result.Success = !tt.storyFailed
+					result.IssueKey = "PROJ-123"
+					result.IssueURL = "https://example.com/browse/PROJ-123"
+					if tt.storyFailed {
+						result.ErrorMessage = "all 1 subtasks failed"
+					}
+					return result, nil
+				},
+				CreateIssueLinkFunc: func(ctx context.Context, inwardKey, outwardKey, linkType string) error {
+					links++
+					return errors.New("status 404")
+				},
+			}
+
+			useCase := NewProcessFilesUseCase(&mocks.MockFileRepository{}, mockJiraRepo, mockFeatureRepo)
+			useCase.SetFeatureLinkType("Relates")
+			useCase.SetFailOnPostCreateError(tt.failOnError)
+
+			result := useCase.processUserStory(ctx, fixtures.UserStoryWithParent(), "PROJ", 2, false)
+
+			if result.IssueKey != "PROJ-123" || result.IssueURL != "https://example.com/browse/PROJ-123" {
+				t.Errorf("Expected created key and URL to be kept, got %q %q", result.IssueKey, result.IssueURL)
+			}
+			if links != 1 {
+				t.Errorf("Expected 1 link call, got %d", links)
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Expected success = %v, got %v (%s)", tt.wantSuccess, result.Success, result.ErrorMessage)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Expected %d warnings, got %v", tt.wantWarnings, result.Warnings)
+			}
+			if tt.wantErrSubstr != "" && !strings.Contains(result.ErrorMessage, tt.wantErrSubstr) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErrSubstr, result.ErrorMessage)
+			}
+		})
+	}
+}
+
 func TestProcessFilesUseCase_processUserStory_DryRunWithSubtasks(t *testing.T) {
 	ctx := context.Background()
 
@@ -968,13 +1293,39 @@ func TestProcessFilesUseCase_processUserStory_DryRunWithSubtasks(t *testing.T) {
 	}
 }
 
+func TestProcessFilesUseCase_processUserStory_DryRunPrefix(t *testing.T) {
+	ctx := context.Background()
+
+	story := fixtures.UserStoryWithSubtasks()
+	useCase := &ProcessFilesUseCase{}
+	useCase.SetDryRunPrefix("PROJ-")
+
+	result := useCase.processUserStory(ctx, story, "PROJ", 5, true)
+
+	if result.IssueKey != "PROJ-5" {
+		t.Errorf("processUserStory() IssueKey = %v, want PROJ-5", result.IssueKey)
+	}
+	if result.IssueURL != "https://dry-run.example.com/browse/PROJ-5" {
+		t.Errorf("processUserStory() IssueURL = %v", result.IssueURL)
+	}
+	if len(result.Subtareas) == 0 {
+		t.Fatal("processUserStory() should create subtask results for dry run")
+	}
+	for i, subtask := range result.Subtareas {
+		want := fmt.Sprintf("PROJ-5-%d", i+1)
+		if subtask.IssueKey != want {
+			t.Errorf("subtask %d key = %v, want %v", i, subtask.IssueKey, want)
+		}
+	}
+}
+
 func TestProcessFilesUseCase_processUserStory_JiraCreationError(t *testing.T) {
 	ctx := context.Background()
 
 	story := fixtures.ValidUserStory1()
 
 	mockJiraRepo := &mocks.MockJiraRepository{
-		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 			return nil, errors.New("JIRA API connection failed")
 		},
 	}
@@ -990,3 +1341,1573 @@ func TestProcessFilesUseCase_processUserStory_JiraCreationError(t *testing.T) {
 		t.Errorf("processUserStory() ErrorMessage should contain JIRA error: %v", result.ErrorMessage)
 	}
 }
+
+func TestProcessFilesUseCase_Execute_DuplicateFileGuard(t *testing.T) {
+	ctx := context.Background()
+
+	fileContents := map[string]string{
+		"stories.csv": "contenido-original",
+	}
+	recorded := make(map[string]bool)
+	createCalls := 0
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{fixtures.ValidUserStory1()}, nil
+		},
+		ComputeHashFunc: func(ctx context.Context, filePath string) (string, error) {
+			return "hash-" + fileContents[filePath], nil
+		},
+	}
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			createCalls++
+			return fixtures.SuccessProcessResult(), nil
+		},
+	}
+	ledger := &mocks.MockFileLedger{
+		IsProcessedFunc: func(ctx context.Context, hash string) (bool, error) {
+			return recorded[hash], nil
+		},
+		MarkProcessedFunc: func(ctx context.Context, hash, filePath string) error {
+			recorded[hash] = true
+			return nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetFileLedger(ledger)
+
+	// Primera ejecucion: se procesa y se registra
+	if _, err := useCase.Execute(ctx, "stories.csv", "PROJ", false); err != nil {
+		t.Fatalf("first Execute() error = %v", err)
+	}
+	if createCalls != 1 {
+		t.Fatalf("expected 1 create call after first run, got %d", createCalls)
+	}
+
+	// Segunda ejecucion con el mismo contenido: se omite
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if !errors.Is(err, ErrFileAlreadyProcessed) {
+		t.Fatalf("second Execute() error = %v, want ErrFileAlreadyProcessed", err)
+	}
+	if result != nil {
+		t.Errorf("second Execute() result = %v, want nil", result)
+	}
+	if !strings.Contains(err.Error(), "--force") {
+		t.Errorf("error should hint at --force, got: %v", err)
+	}
+	if createCalls != 1 {
+		t.Errorf("duplicate file should not create issues, got %d create calls", createCalls)
+	}
+
+	// Archivo modificado: se procesa de nuevo
+	fileContents["stories.csv"] = "contenido-modificado"
+	if _, err := useCase.Execute(ctx, "stories.csv", "PROJ", false); err != nil {
+		t.Fatalf("modified file Execute() error = %v", err)
+	}
+	if createCalls != 2 {
+		t.Errorf("modified file should be processed, got %d create calls", createCalls)
+	}
+
+	// Con --force se reprocesa aunque el contenido sea identico
+	useCase.SetForce(true)
+	if _, err := useCase.Execute(ctx, "stories.csv", "PROJ", false); err != nil {
+		t.Fatalf("forced Execute() error = %v", err)
+	}
+	if createCalls != 3 {
+		t.Errorf("forced run should be processed, got %d create calls", createCalls)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_DuplicateFileGuard_DryRunIgnored(t *testing.T) {
+	ctx := context.Background()
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{fixtures.ValidUserStory1()}, nil
+		},
+		ComputeHashFunc: func(ctx context.Context, filePath string) (string, error) {
+			t.Error("dry-run should not hash files")
+			return "", nil
+		},
+	}
+	ledger := &mocks.MockFileLedger{
+		MarkProcessedFunc: func(ctx context.Context, hash, filePath string) error {
+			t.Error("dry-run should not record files in the ledger")
+			return nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+	useCase.SetFileLedger(ledger)
+
+	if _, err := useCase.Execute(ctx, "stories.csv", "PROJ", true); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_DryRunAPIEstimate(t *testing.T) {
+	ctx := context.Background()
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return fixtures.GetSampleUserStories(), nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+	useCase.SetRequestsPerSecond(5)
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", true)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	estimate := result.APIEstimate
+	if estimate == nil {
+		t.Fatal("Expected API estimate in dry-run result")
+	}
+
+	// 4 historias, 7 subtareas validas, 2 parents con key y 1 Feature por descripcion
+	if estimate.StoryCalls != 4 || estimate.SubtaskCalls != 7 {
+		t.Errorf("StoryCalls/SubtaskCalls = %d/%d, want 4/7", estimate.StoryCalls, estimate.SubtaskCalls)
+	}
+	if estimate.ParentValidations != 2 {
+		t.Errorf("ParentValidations = %d, want 2", estimate.ParentValidations)
+	}
+	if estimate.FeatureSearches != 1 || estimate.FeatureCreates != 1 {
+		t.Errorf("FeatureSearches/FeatureCreates = %d/%d, want 1/1", estimate.FeatureSearches, estimate.FeatureCreates)
+	}
+	if estimate.TotalCalls() != 15 {
+		t.Errorf("TotalCalls() = %d, want 15", estimate.TotalCalls())
+	}
+	if estimate.EstimatedDuration() != 3*time.Second {
+		t.Errorf("EstimatedDuration() = %v, want 3s", estimate.EstimatedDuration())
+	}
+
+	mockFileRepo.ReadFileFunc = func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+		return []*entities.UserStory{fixtures.ValidUserStory1()}, nil
+	}
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			return fixtures.SuccessProcessResult(), nil
+		},
+	}
+
+	realRun, err := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{}).Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if realRun.APIEstimate != nil {
+		t.Error("Expected no API estimate outside dry-run")
+	}
+}
+
+func TestProcessFilesUseCase_Execute_SkipRows(t *testing.T) {
+	ctx := context.Background()
+
+	skipped := fixtures.ValidUserStory2()
+	skipped.Skip = true
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{fixtures.ValidUserStory1(), skipped}, nil
+		},
+	}
+
+	var created []string
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			created = append(created, story.Titulo)
+			return fixtures.SuccessProcessResult(), nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(created) != 1 || created[0] == skipped.Titulo {
+		t.Errorf("Expected only the non-skipped story to be created, got %v", created)
+	}
+	if result.SkippedRows != 1 {
+		t.Errorf("SkippedRows = %d, want 1", result.SkippedRows)
+	}
+	if result.TotalRows != 2 || result.ProcessedRows != 1 {
+		t.Errorf("TotalRows/ProcessedRows = %d/%d, want 2/1", result.TotalRows, result.ProcessedRows)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_DuplicateFeaturesWarning(t *testing.T) {
+	ctx := context.Background()
+
+	parents := []string{"Gestion de usuarios del sistema", "Gestion usuarios del sistema!", "Reportes financieros"}
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			var stories []*entities.UserStory
+			for i, parent := range parents {
+				stories = append(stories, entities.NewUserStory(fmt.Sprintf("Story %d", i), "Desc", "Criteria", "", parent))
+			}
+			return stories, nil
+		},
+	}
+
+	// Simula una deduplicacion fallida: cada descripcion crea una Feature nueva
+	created := 0
+	mockFeatureRepo := &mocks.MockFeatureManager{
+		CreateOrGetFeatureFunc: func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
+			created++
+			result := entities.NewFeatureResult(description)
+			result.SetSuccess(fmt.Sprintf("PROJ-%d", 100+created), "", true)
+			return result, nil
+		},
+	}
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			return fixtures.SuccessProcessResult(), nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, mockFeatureRepo)
+	useCase.SetSkipFeatureValidation(true)
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 duplicate feature warning, got %v", result.Errors)
+	}
+	if !strings.Contains(result.Errors[0], "PROJ-101") || !strings.Contains(result.Errors[0], "PROJ-102") {
+		t.Errorf("Expected warning to name PROJ-101 and PROJ-102, got %q", result.Errors[0])
+	}
+
+	// Con un umbral mas exigente el par deja de considerarse duplicado
+	created = 0
+	useCase.SetFeatureSimilarityThreshold(0.95)
+	result, err = useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Expected no warnings with threshold 0.95, got %v", result.Errors)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_RemoteSourceNotMoved(t *testing.T) {
+	ctx := context.Background()
+
+	moved := false
+	hashed := false
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{fixtures.ValidUserStory1()}, nil
+		},
+		MoveToProcessedFunc: func(ctx context.Context, filePath string) error {
+			moved = true
+			return nil
+		},
+		ComputeHashFunc: func(ctx context.Context, filePath string) (string, error) {
+			hashed = true
+			return "hash", nil
+		},
+	}
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			return fixtures.SuccessProcessResult(), nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetFileLedger(&mocks.MockFileLedger{})
+
+	result, err := useCase.Execute(ctx, "https://artifacts.example.com/stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.SuccessfulRows != 1 {
+		t.Errorf("SuccessfulRows = %d, want 1", result.SuccessfulRows)
+	}
+	if moved {
+		t.Error("Expected URL source not to be moved to processed")
+	}
+	if hashed {
+		t.Error("Expected URL source to skip the processed-file ledger")
+	}
+}
+
+func TestProcessFilesUseCase_ProcessAllFiles_FileSelector(t *testing.T) {
+	ctx := context.Background()
+
+	var readFiles []string
+	mockFileRepo := &mocks.MockFileRepository{
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return []string{"/input/a.csv", "/input/b.csv", "/input/c.csv"}, nil
+		},
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			readFiles = append(readFiles, filePath)
+			return []*entities.UserStory{fixtures.ValidUserStory1()}, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+	useCase.SetFileSelector(func(files []string) []string {
+		return []string{files[0], files[2]}
+	})
+
+	results, err := useCase.ProcessAllFiles(ctx, "/input", "PROJ", true)
+	if err != nil {
+		t.Fatalf("ProcessAllFiles() error = %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if len(readFiles) != 2 || readFiles[0] != "/input/a.csv" || readFiles[1] != "/input/c.csv" {
+		t.Errorf("Expected only selected files to be processed, got %v", readFiles)
+	}
+}
+
+func TestProcessFilesUseCase_ProcessAllFiles_FileSelectorEmpty(t *testing.T) {
+	mockFileRepo := &mocks.MockFileRepository{
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return []string{"/input/a.csv"}, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+	useCase.SetFileSelector(func(files []string) []string { return nil })
+
+	_, err := useCase.ProcessAllFiles(context.Background(), "/input", "PROJ", true)
+	if err == nil || !strings.Contains(err.Error(), "no files selected") {
+		t.Errorf("Expected 'no files selected' error, got: %v", err)
+	}
+}
+
+func TestProcessFilesUseCase_ProcessAllFiles_ProjectFromFilename(t *testing.T) {
+	ctx := context.Background()
+
+	var currentFile string
+
+	mockFileRepo := &mocks.MockFileRepository{
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return []string{"/input/ALPHA__stories.csv", "/input/beta__backlog.xlsx", "/input/plain.csv"}, nil
+		},
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			currentFile = filepath.Base(filePath)
+			return []*entities.UserStory{fixtures.ValidUserStory1()}, nil
+		},
+	}
+
+	var validatedProjects []string
+	createdIn := make(map[string][]string)
+	mockJiraRepo := &mocks.MockJiraRepository{
+		ValidateProjectFunc: func(ctx context.Context, projectKey string) error {
+			validatedProjects = append(validatedProjects, projectKey)
+			return nil
+		},
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			createdIn[projectKey] = append(createdIn[projectKey], currentFile)
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = projectKey + "-1"
+			return result, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetProjectFromFilename("__")
+
+	results, err := useCase.ProcessAllFiles(ctx, "/input", "DEFAULT", false)
+	if err != nil {
+		t.Fatalf("ProcessAllFiles() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+
+	expected := []string{"ALPHA", "BETA", "DEFAULT"}
+	if strings.Join(validatedProjects, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected projects %v to be validated, got %v", expected, validatedProjects)
+	}
+	if len(createdIn["ALPHA"]) != 1 || createdIn["ALPHA"][0] != "ALPHA__stories.csv" {
+		t.Errorf("Expected ALPHA__stories.csv routed to ALPHA, got %v", createdIn)
+	}
+	if len(createdIn["BETA"]) != 1 || createdIn["BETA"][0] != "beta__backlog.xlsx" {
+		t.Errorf("Expected beta__backlog.xlsx routed to BETA, got %v", createdIn)
+	}
+	if len(createdIn["DEFAULT"]) != 1 || createdIn["DEFAULT"][0] != "plain.csv" {
+		t.Errorf("Expected plain.csv to use the default project, got %v", createdIn)
+	}
+}
+
+func TestProcessFilesUseCase_projectForFile(t *testing.T) {
+	useCase := NewProcessFilesUseCase(&mocks.MockFileRepository{}, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+
+	if got := useCase.projectForFile("/input/PROJ__a.csv", "DEF"); got != "DEF" {
+		t.Errorf("Expected default project when routing is disabled, got %s", got)
+	}
+
+	useCase.SetProjectFromFilename("__")
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"/input/PROJ__a.csv", "PROJ"},
+		{"/input/proj_2__a.csv", "PROJ_2"},
+		{"/input/sin_prefijo.csv", "DEF"},
+		{"/input/__a.csv", "DEF"},
+		{"/input/mi proyecto__a.csv", "DEF"},
+	}
+
+	for _, tt := range tests {
+		if got := useCase.projectForFile(tt.file, "DEF"); got != tt.expected {
+			t.Errorf("projectForFile(%q) = %s, want %s", tt.file, got, tt.expected)
+		}
+	}
+}
+
+func TestProcessFilesUseCase_Explainer_ParentResolution(t *testing.T) {
+	tests := []struct {
+		name     string
+		parent   string
+		expected string
+	}{
+		{"issue_key_parent", "PROJ-42", `parent <- columna parent "PROJ-42" tratado como key de Jira existente`},
+		{"free_text_parent", "Gestión de Usuarios", `parent <- columna parent "Gestión de Usuarios" tratado como descripcion de Feature`},
+		{"no_parent", "", "parent <- sin parent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			story := entities.NewUserStory("Login", "Descripcion", "C1;C2", "Sub 1", tt.parent)
+
+			var explained []string
+			var explainedRow int
+			useCase := NewProcessFilesUseCase(&mocks.MockFileRepository{}, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+			useCase.SetExplainer(func(rowNumber int, decisions []string) {
+				explainedRow = rowNumber
+				explained = decisions
+			}, FieldMapping{IssueType: "Story", SubtaskIssueType: "Sub-task", AcceptanceCriteriaField: "customfield_10001"})
+
+			useCase.processUserStory(context.Background(), story, "PROJ", 3, true)
+
+			if explainedRow != 3 {
+				t.Errorf("Expected row 3 to be explained, got %d", explainedRow)
+			}
+
+			joined := strings.Join(explained, "\n")
+			if !strings.Contains(joined, tt.expected) {
+				t.Errorf("Expected explanation to contain %q, got:\n%s", tt.expected, joined)
+			}
+			for _, expected := range []string{
+				"project <- PROJ",
+				`summary <- columna titulo ("Login")`,
+				"customfield_10001 <- columna criterio_aceptacion (2 criterios",
+				"subtareas <- columna subtareas (1 validas, 0 invalidas) como Sub-task",
+			} {
+				if !strings.Contains(joined, expected) {
+					t.Errorf("Expected explanation to contain %q, got:\n%s", expected, joined)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessFilesUseCase_Explainer_CriteriaInDescription(t *testing.T) {
+	var explained []string
+	useCase := NewProcessFilesUseCase(&mocks.MockFileRepository{}, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+	useCase.SetExplainer(func(rowNumber int, decisions []string) {
+		explained = decisions
+	}, FieldMapping{IssueType: "Story"})
+
+	useCase.processUserStory(context.Background(), fixtures.ValidUserStory1(), "PROJ", 2, true)
+
+	if !strings.Contains(strings.Join(explained, "\n"), "description <- columna criterio_aceptacion anexada") {
+		t.Errorf("Expected criteria to be explained as appended to description, got: %v", explained)
+	}
+}
+
+func TestProcessFilesUseCase_Explainer_EpicLinkField(t *testing.T) {
+	var explained []string
+	useCase := NewProcessFilesUseCase(&mocks.MockFileRepository{}, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+	useCase.SetExplainer(func(rowNumber int, decisions []string) {
+		explained = decisions
+	}, FieldMapping{IssueType: "Story", EpicLinkField: "customfield_10014"})
+
+	story := entities.NewUserStory("Login", "Desc", "Criteria", "", "PROJ-42")
+	useCase.processUserStory(context.Background(), story, "PROJ", 2, true)
+
+	if !strings.Contains(strings.Join(explained, "\n"), "customfield_10014 <- parent resuelto si es un Epic") {
+		t.Errorf("Expected the Epic Link field to be explained, got: %v", explained)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_FeatureCounters(t *testing.T) {
+	ctx := context.Background()
+
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Story 1", "Desc", "Criteria", "", "Nueva Feature"),
+		entities.NewUserStory("Story 2", "Desc", "Criteria", "", "Nueva Feature"),
+		entities.NewUserStory("Story 3", "Desc", "Criteria", "", "PROJ-50"),
+		entities.NewUserStory("Story 4", "Desc", "Criteria", "", ""),
+	}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			return result, nil
+		},
+	}
+
+	// La primera referencia a "Nueva Feature" la crea, las siguientes la reutilizan
+	created := make(map[string]bool)
+	mockFeatureManager := &mocks.MockFeatureManager{
+		CreateOrGetFeatureFunc: func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
+			result := entities.NewFeatureResult(description)
+			if strings.HasPrefix(description, "PROJ-") {
+				result.SetExisting(description)
+				return result, nil
+			}
+			if created[description] {
+				result.SetExisting("PROJ-100")
+				return result, nil
+			}
+			created[description] = true
+			result.SetSuccess("PROJ-100", "", true)
+			return result, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, mockFeatureManager)
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if result.FeaturesCreated != 1 {
+		t.Errorf("FeaturesCreated = %d, want 1", result.FeaturesCreated)
+	}
+	// Story 2 usa la Feature creada por Story 1: solo PROJ-50 cuenta como reutilizada
+	if result.FeaturesReused != 1 {
+		t.Errorf("FeaturesReused = %d, want 1", result.FeaturesReused)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_RunHistory(t *testing.T) {
+	ctx := context.Background()
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return fixtures.GetSampleUserStories()[:2], nil
+		},
+	}
+
+	var recorded []*entities.RunSummary
+	history := &mocks.MockRunHistory{
+		AppendFunc: func(ctx context.Context, summary *entities.RunSummary) error {
+			recorded = append(recorded, summary)
+			return nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+	useCase.SetRunHistory(history)
+
+	result, err := useCase.Execute(ctx, "/input/sprint.csv", "PROJ", true)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(recorded) != 1 {
+		t.Fatalf("Expected 1 history entry, got %d", len(recorded))
+	}
+	if recorded[0].FileName != "sprint.csv" || recorded[0].TotalRows != 2 || !recorded[0].DryRun {
+		t.Errorf("Unexpected history entry: %+v", recorded[0])
+	}
+	if recorded[0].SuccessRate != result.GetSuccessRate() {
+		t.Errorf("Expected success rate %.1f, got %.1f", result.GetSuccessRate(), recorded[0].SuccessRate)
+	}
+
+	history.AppendFunc = func(ctx context.Context, summary *entities.RunSummary) error {
+		return errors.New("disk full")
+	}
+	result, err = useCase.Execute(ctx, "/input/sprint.csv", "PROJ", true)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "could not record run history") {
+		t.Errorf("Expected history warning in batch errors, got %v", result.Errors)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_ResultsStore(t *testing.T) {
+	ctx := context.Background()
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{
+				entities.NewUserStory("Login", "Desc", "Criterio", "", ""),
+				entities.NewUserStory("Logout", "Desc", "Criterio", "", ""),
+			}, nil
+		},
+		MoveToProcessedFunc: func(ctx context.Context, filePath string) error {
+			return errors.New("permission denied")
+		},
+	}
+
+	var saved *entities.BatchResult
+	store := &mocks.MockResultsStore{
+		SaveFunc: func(ctx context.Context, result *entities.BatchResult) (string, error) {
+			saved = result
+			return "logs/results/sprint_20240305-143000.results.json", nil
+		},
+	}
+
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			return &entities.ProcessResult{Success: true, IssueKey: fmt.Sprintf("PROJ-%d", rowNumber), RowNumber: rowNumber}, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetResultsStore(store)
+
+	result, err := useCase.Execute(ctx, "/input/sprint.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if saved != result {
+		t.Fatal("Expected the batch result to be saved")
+	}
+	if result.ResultsFile != "logs/results/sprint_20240305-143000.results.json" {
+		t.Errorf("ResultsFile = %q", result.ResultsFile)
+	}
+	// El artefacto se escribe despues de mover el archivo, asi incluye ese aviso
+	if len(saved.Errors) != 1 || !strings.Contains(saved.Errors[0], "could not move file to processed") {
+		t.Errorf("Expected the move warning in the saved result, got %v", saved.Errors)
+	}
+
+	store.SaveFunc = func(ctx context.Context, result *entities.BatchResult) (string, error) {
+		return "", errors.New("error creating results directory: read-only file system")
+	}
+	result, err = useCase.Execute(ctx, "/input/sprint.csv", "PROJ", true)
+	if err != nil {
+		t.Fatalf("Execute() should not fail when the results file cannot be written, got %v", err)
+	}
+	if result.ResultsFile != "" || len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "could not write results file") {
+		t.Errorf("Expected results warning in batch errors, got %v", result.Errors)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_FailOnEmpty(t *testing.T) {
+	ctx := context.Background()
+
+	skipped := fixtures.ValidUserStory1()
+	skipped.Skip = true
+
+	tests := []struct {
+		name        string
+		failOnEmpty bool
+		dryRun      bool
+		wantErr     bool
+	}{
+		{"fails_under_policy", true, false, true},
+		{"succeeds_without_policy", false, false, false},
+		{"dry_run_ignores_policy", true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			mockFileRepo := &mocks.MockFileRepository{
+				ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+					return []*entities.UserStory{skipped}, nil
+				},
+			}
+			mockJiraRepo := &mocks.MockJiraRepository{
+				CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+					created = true
+					return fixtures.SuccessProcessResult(), nil
+				},
+			}
+
+			useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+			useCase.SetFailOnEmpty(tt.failOnEmpty)
+
+			result, err := useCase.Execute(ctx, "invalid.csv", "PROJ", tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), "no processable rows") {
+					t.Errorf("Expected no processable rows error, got %v", err)
+				}
+				return
+			}
+			if result.SuccessfulRows != 0 || created {
+				t.Errorf("Expected zero processed rows, got %d", result.SuccessfulRows)
+			}
+		})
+	}
+}
+
+func TestProcessFilesUseCase_Execute_TranscodedFileWarning(t *testing.T) {
+	ctx := context.Background()
+
+	for _, encoding := range []string{"UTF-16LE", repositories.EncodingUTF8} {
+		mockFileRepo := &mocks.MockFileRepository{
+			ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+				return []*entities.UserStory{fixtures.ValidUserStory1()}, nil
+			},
+			DetectEncodingFunc: func(ctx context.Context, filePath string) (string, error) {
+				return encoding, nil
+			},
+		}
+
+		useCase := NewProcessFilesUseCase(mockFileRepo, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+		result, err := useCase.Execute(ctx, "entrada/windows.csv", "", true)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		warned := false
+		for _, message := range result.Errors {
+			if strings.Contains(message, "windows.csv was transcoded from UTF-16LE") {
+				warned = true
+			}
+		}
+		if warned != (encoding != repositories.EncodingUTF8) {
+			t.Errorf("encoding %s: transcoding warning = %v, errors %v", encoding, warned, result.Errors)
+		}
+	}
+}
+
+func TestProcessFilesUseCase_Execute_IssueLimitStopsProcessing(t *testing.T) {
+	ctx := context.Background()
+
+	stories := []*entities.UserStory{
+		fixtures.ValidUserStory1(),
+		fixtures.ValidUserStory2(),
+		fixtures.ValidUserStory1(),
+		fixtures.ValidUserStory2(),
+	}
+
+	moved := false
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return []string{"a.csv", "b.csv"}, nil
+		},
+		MoveToProcessedFunc: func(ctx context.Context, filePath string) error {
+			moved = true
+			return nil
+		},
+	}
+
+	calls := 0
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			calls++
+			if calls > 2 {
+				return nil, fmt.Errorf("%w: MAX_ISSUES_PER_RUN=2 issues already created in this run", repositories.ErrIssueLimitReached)
+			}
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", calls)
+			return result, nil
+		},
+	}
+
+	mockFeatureManager := &mocks.MockFeatureManager{
+		CreateOrGetFeatureFunc: func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
+			featureResult := entities.NewFeatureResult(description)
+			featureResult.SetExisting("PROJ-100")
+			return featureResult, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, mockFeatureManager)
+
+	results, err := useCase.ProcessAllFiles(ctx, "entrada", "PROJ", false)
+	if err != nil {
+		t.Fatalf("ProcessAllFiles() error = %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected processing to stop after the first file, got %d results", len(results))
+	}
+	result := results[0]
+	if !result.Aborted {
+		t.Error("Expected batch to be marked as aborted")
+	}
+	if calls != 3 || len(result.Results) != 3 {
+		t.Errorf("Expected 3 creation attempts and 3 results, got %d and %d", calls, len(result.Results))
+	}
+	if result.SuccessfulRows != 2 || result.ErrorRows != 1 {
+		t.Errorf("Expected 2 created and 1 failed row, got %d and %d", result.SuccessfulRows, result.ErrorRows)
+	}
+	if code := result.Results[2].ErrorCode; code != entities.ErrorCodeIssueLimit {
+		t.Errorf("Expected %s error code, got %q", entities.ErrorCodeIssueLimit, code)
+	}
+	if !result.HasErrors() || !strings.Contains(result.Errors[len(result.Errors)-1], "rows from 4 on were not processed") {
+		t.Errorf("Expected an error naming the first unprocessed row, got %v", result.Errors)
+	}
+	if moved {
+		t.Error("Expected an aborted file to stay pending")
+	}
+}
+
+func TestProcessFilesUseCase_Execute_BulkCreate(t *testing.T) {
+	ctx := context.Background()
+
+	withFeature := entities.NewUserStory("Login", "Desc", "Criteria", "", "Autenticacion")
+	stories := []*entities.UserStory{withFeature}
+	for i := 0; i < repositories.BulkCreateLimit+1; i++ {
+		stories = append(stories, entities.NewUserStory(fmt.Sprintf("Story %d", i), "Desc", "Criteria", "", ""))
+	}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+
+	var links []string
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			t.Errorf("CreateUserStory should not be called with a bulk creator (row %d)", rowNumber)
+			return nil, nil
+		},
+		CreateIssueLinkFunc: func(ctx context.Context, inwardKey, outwardKey, linkType string) error {
+			links = append(links, inwardKey+"->"+outwardKey)
+			return nil
+		},
+	}
+
+	mockFeatureManager := &mocks.MockFeatureManager{
+		CreateOrGetFeatureFunc: func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
+			featureResult := entities.NewFeatureResult(description)
+			featureResult.SetExisting("PROJ-100")
+			return featureResult, nil
+		},
+	}
+
+	// El primer grupo tiene una fila rechazada por Jira; en el segundo ya no quedan issues por crear
+	var groups [][]int
+	bulk := &mocks.MockBulkStoryCreator{
+		CreateUserStoriesFunc: func(ctx context.Context, stories []*entities.UserStory, projectKey string, rowNumbers []int) ([]*entities.ProcessResult, error) {
+			groups = append(groups, rowNumbers)
+			results := make([]*entities.ProcessResult, len(stories))
+			if len(groups) > 1 {
+				return results, fmt.Errorf("%w: MAX_ISSUES_PER_RUN=50 issues already created in this run", repositories.ErrIssueLimitReached)
+			}
+			for i, rowNumber := range rowNumbers {
+				results[i] = entities.NewProcessResult(rowNumber)
+				if rowNumber == 3 {
+					results[i].ErrorMessage = "jira error: Summary rejected"
+					continue
+				}
+				results[i].Success = true
+				results[i].IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			}
+			return results, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, mockFeatureManager)
+	useCase.SetBulkCreator(bulk)
+	useCase.SetFeatureLinkType("Relates")
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(groups) != 2 || len(groups[0]) != repositories.BulkCreateLimit || groups[0][0] != 2 || groups[1][0] != repositories.BulkCreateLimit+2 {
+		t.Fatalf("Expected a full group from row 2 and then the rest, got %v", groups)
+	}
+	if result.SuccessfulRows != repositories.BulkCreateLimit-1 || result.Results[1].Success || result.Results[1].ErrorMessage != "jira error: Summary rejected" {
+		t.Errorf("Expected every row of the first group but the rejected one to succeed, got %d successful", result.SuccessfulRows)
+	}
+
+	first := result.Results[0]
+	if first.FeatureKey != "PROJ-100" || first.Summary != "Login" || first.RowHash != withFeature.ContentHash() {
+		t.Errorf("Expected the row to keep its Feature, summary and hash, got %+v", first)
+	}
+	if strings.Join(links, ",") != "PROJ-2->PROJ-100" {
+		t.Errorf("Links = %v, want the story linked to its Feature", links)
+	}
+
+	if !result.Aborted || result.Results[len(result.Results)-1].ErrorCode != entities.ErrorCodeIssueLimit {
+		t.Error("Expected the issue limit in the second group to stop the batch")
+	}
+}
+
+// streamerOf devuelve un streamer que entrega stories de a una y cuenta las leidas en read; con
+// readErr distinto de nil falla al llegar a la fila failAt (indice en stories)
+func streamerOf(stories []*entities.UserStory, read *int, failAt int, readErr error) *mocks.MockStoryStreamer {
+	return &mocks.MockStoryStreamer{
+		ReadFileStreamFunc: func(ctx context.Context, filePath string, fn func(story *entities.UserStory) error) error {
+			for i, story := range stories {
+				if readErr != nil && i == failAt {
+					return readErr
+				}
+				*read++
+				if err := fn(story); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestProcessFilesUseCase_Execute_Stream(t *testing.T) {
+	ctx := context.Background()
+
+	skipped := entities.NewUserStory("Skipped", "Desc", "Criteria", "", "")
+	skipped.Skip = true
+	stories := []*entities.UserStory{skipped}
+	for i := 0; i < 2*streamChunkSize+10; i++ {
+		stories = append(stories, entities.NewUserStory(fmt.Sprintf("Story %d", i), "Desc", "Criteria", "", ""))
+	}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			t.Error("ReadFile should not be called when streaming")
+			return stories, nil
+		},
+	}
+
+	// Cada historia se crea antes de leer el grupo siguiente
+	read := 0
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			if maxRead := (rowNumber-3)/streamChunkSize*streamChunkSize + streamChunkSize + 1; read > maxRead {
+				t.Errorf("Row %d created after reading %d rows, want at most %d", rowNumber, read, maxRead)
+			}
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			return result, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetStoryStreamer(streamerOf(stories, &read, 0, nil))
+
+	var totals []int
+	useCase.SetProgress(func(processed, total int) {
+		totals = append(totals, total)
+	})
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if result.TotalRows != len(stories) || result.SkippedRows != 1 || result.SuccessfulRows != len(stories)-1 {
+		t.Errorf("Got %d rows, %d skipped, %d successful; want %d, 1, %d", result.TotalRows, result.SkippedRows, result.SuccessfulRows, len(stories), len(stories)-1)
+	}
+	if first, last := result.Results[0], result.Results[len(result.Results)-1]; first.RowNumber != 3 || last.RowNumber != len(stories)+1 {
+		t.Errorf("Row numbers go from %d to %d, want 3 to %d", first.RowNumber, last.RowNumber, len(stories)+1)
+	}
+	if len(totals) != len(stories) || totals[0] != 0 {
+		t.Errorf("Expected one progress call per row with an unknown total, got %d calls (%v...)", len(totals), totals[:1])
+	}
+
+	// dry-run sigue leyendo el archivo completo
+	mockFileRepo.ReadFileFunc = func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+		return stories, nil
+	}
+	read = 0
+	if _, err := useCase.Execute(ctx, "stories.csv", "PROJ", true); err != nil {
+		t.Fatalf("Execute() dry-run error = %v", err)
+	}
+	if read != 0 {
+		t.Errorf("Dry-run streamed %d rows, want the file read with ReadFile", read)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_StreamErrors(t *testing.T) {
+	ctx := context.Background()
+
+	var stories []*entities.UserStory
+	for i := 0; i < streamChunkSize+5; i++ {
+		stories = append(stories, entities.NewUserStory(fmt.Sprintf("Story %d", i), "Desc", "Criteria", "", ""))
+	}
+
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			return result, nil
+		},
+	}
+
+	moved := false
+	mockFileRepo := &mocks.MockFileRepository{
+		MoveToProcessedFunc: func(ctx context.Context, filePath string) error {
+			moved = true
+			return nil
+		},
+	}
+	readErr := errors.New("error parsing CSV: bare quote")
+
+	t.Run("before_any_issue", func(t *testing.T) {
+		read := 0
+		useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+		useCase.SetStoryStreamer(streamerOf(stories, &read, 10, readErr))
+
+		_, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+		if err == nil || !strings.Contains(err.Error(), "error reading file") {
+			t.Errorf("Execute() error = %v, want the read error", err)
+		}
+	})
+
+	t.Run("after_creating_issues", func(t *testing.T) {
+		read := 0
+		useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+		useCase.SetStoryStreamer(streamerOf(stories, &read, streamChunkSize+2, readErr))
+
+		result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if !result.Aborted || result.SuccessfulRows != streamChunkSize || moved {
+			t.Errorf("Expected the first group created and the file left pending, got %d successful (aborted=%v, moved=%v)", result.SuccessfulRows, result.Aborted, moved)
+		}
+		want := fmt.Sprintf("rows from %d on were not processed", streamChunkSize+2)
+		if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], readErr.Error()) || !strings.Contains(result.Errors[0], want) {
+			t.Errorf("Errors = %v, want the read error and %q", result.Errors, want)
+		}
+	})
+
+	t.Run("after_creating_issues_with_rollback", func(t *testing.T) {
+		var deleted []string
+		rollbackRepo := &mocks.MockJiraRepository{
+			CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+				result := entities.NewProcessResult(rowNumber)
+				result.Success = true
+				result.WasCreated = true
+				result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+				return result, nil
+			},
+			DeleteIssueFunc: func(ctx context.Context, issueKey string) error {
+				deleted = append(deleted, issueKey)
+				return nil
+			},
+		}
+
+		read := 0
+		useCase := NewProcessFilesUseCase(mockFileRepo, rollbackRepo, &mocks.MockFeatureManager{})
+		useCase.SetStoryStreamer(streamerOf(stories, &read, streamChunkSize+2, readErr))
+		useCase.SetRollbackOnBatchFailure(true)
+
+		result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if len(deleted) != streamChunkSize || len(result.RolledBack) != streamChunkSize {
+			t.Errorf("Expected the %d issues of the first group to be rolled back, deleted %d (RolledBack %d)", streamChunkSize, len(deleted), len(result.RolledBack))
+		}
+		if !result.Aborted || moved {
+			t.Errorf("Expected the file left pending, got aborted=%v, moved=%v", result.Aborted, moved)
+		}
+	})
+
+	t.Run("fail_on_empty", func(t *testing.T) {
+		skipped := entities.NewUserStory("Skipped", "Desc", "Criteria", "", "")
+		skipped.Skip = true
+
+		read := 0
+		useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+		useCase.SetStoryStreamer(streamerOf([]*entities.UserStory{skipped}, &read, 0, nil))
+		useCase.SetFailOnEmpty(true)
+
+		_, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+		if err == nil || !strings.Contains(err.Error(), "no processable rows") {
+			t.Errorf("Execute() error = %v, want the no processable rows error", err)
+		}
+	})
+}
+
+func TestProcessFilesUseCase_Execute_WorkersKeepRowOrder(t *testing.T) {
+	ctx := context.Background()
+	const workers = 3
+
+	var stories []*entities.UserStory
+	for i := 0; i < 3*workers; i++ {
+		stories = append(stories, entities.NewUserStory(fmt.Sprintf("Story %d", i), "Desc", "Criteria", "", ""))
+	}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+
+	// Cada llamada espera a que haya workers llamadas en curso a la vez, asi se verifica el paralelismo exacto
+	var inFlight, maxInFlight int
+	var mu sync.Mutex
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+				mu.Lock()
+				reached := maxInFlight >= workers
+				mu.Unlock()
+				if reached {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			// Las filas mas bajas terminan ultimas para desordenar la finalizacion
+			time.Sleep(time.Duration(len(stories)+2-rowNumber) * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			return result, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetWorkers(workers)
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if maxInFlight != workers {
+		t.Errorf("Expected exactly %d concurrent requests, got %d", workers, maxInFlight)
+	}
+	if len(result.Results) != len(stories) || result.SuccessfulRows != len(stories) {
+		t.Fatalf("Expected %d successful results, got %d (%d successful)", len(stories), len(result.Results), result.SuccessfulRows)
+	}
+	for i, row := range result.Results {
+		if row.RowNumber != i+2 || row.IssueKey != fmt.Sprintf("PROJ-%d", i+2) {
+			t.Errorf("Result %d: got row %d (%s), want row %d", i, row.RowNumber, row.IssueKey, i+2)
+		}
+	}
+}
+
+func TestProcessFilesUseCase_Execute_Progress(t *testing.T) {
+	ctx := context.Background()
+
+	skipped := entities.NewUserStory("Skipped", "Desc", "Criteria", "", "")
+	skipped.Skip = true
+	stories := []*entities.UserStory{skipped}
+	for i := 0; i < 6; i++ {
+		stories = append(stories, entities.NewUserStory(fmt.Sprintf("Story %d", i), "Desc", "Criteria", "", ""))
+	}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			return result, nil
+		},
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+		useCase.SetWorkers(3)
+
+		var mu sync.Mutex
+		var counts []int
+		useCase.SetProgress(func(processed, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			if total != len(stories) {
+				t.Errorf("dryRun=%v: total = %d, want %d", dryRun, total, len(stories))
+			}
+			counts = append(counts, processed)
+		})
+
+		result, err := useCase.Execute(ctx, "stories.csv", "PROJ", dryRun)
+		if err != nil {
+			t.Fatalf("dryRun=%v: Execute() error = %v", dryRun, err)
+		}
+
+		if len(counts) != result.TotalRows {
+			t.Fatalf("dryRun=%v: progress called %d times, want %d", dryRun, len(counts), result.TotalRows)
+		}
+		for i, processed := range counts {
+			if processed != i+1 {
+				t.Errorf("dryRun=%v: call %d reported %d, want %d", dryRun, i, processed, i+1)
+			}
+		}
+	}
+}
+
+func TestProcessFilesUseCase_Execute_WorkersStopOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stories []*entities.UserStory
+	for i := 0; i < 10; i++ {
+		stories = append(stories, entities.NewUserStory(fmt.Sprintf("Story %d", i), "Desc", "Criteria", "", ""))
+	}
+
+	moved := false
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+		MoveToProcessedFunc: func(ctx context.Context, filePath string) error {
+			moved = true
+			return nil
+		},
+	}
+
+	var calls int32
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			atomic.AddInt32(&calls, 1)
+			cancel()
+			return fixtures.SuccessProcessResult(), nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetWorkers(2)
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got > 2 {
+		t.Errorf("Expected no new rows to start after cancel, got %d calls", got)
+	}
+	if !result.Aborted {
+		t.Error("Expected batch to be marked as aborted")
+	}
+	if !strings.Contains(result.Errors[len(result.Errors)-1], "processing canceled") {
+		t.Errorf("Expected a cancellation error, got %v", result.Errors)
+	}
+	if moved {
+		t.Error("Expected a canceled file to stay pending")
+	}
+}
+
+func TestProcessFilesUseCase_Execute_RollbackOnBatchFailure(t *testing.T) {
+	ctx := context.Background()
+
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Login", "Desc", "Criterio", "", "Gestion de usuarios"),
+		entities.NewUserStory("Logout", "Desc", "Criterio", "", "Gestion de usuarios"),
+		entities.NewUserStory("Perfil", "Desc", "Criterio", "", ""),
+		entities.NewUserStory("Ya creada", "Desc", "Criterio", "", ""),
+		entities.NewUserStory("Falla", "Desc", "Criterio", "", ""),
+	}
+
+	moved := false
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+		MoveToProcessedFunc: func(ctx context.Context, filePath string) error {
+			moved = true
+			return nil
+		},
+	}
+
+	var deleted []string
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			result := entities.NewProcessResult(rowNumber)
+			switch story.Titulo {
+			case "Falla":
+				result.ErrorMessage = "jira error: summary: Field is required"
+				return result, nil
+			case "Ya creada":
+				// SKIP_EXISTING: la historia es de una ejecucion anterior y no se elimina
+				result.Success = true
+				result.IssueKey = "PROJ-5"
+				return result, nil
+			}
+			result.Success = true
+			result.WasCreated = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber*10)
+			if story.Titulo == "Login" {
+				result.AddSubtaskResult("Formulario", true, "PROJ-21", "", "")
+				result.AddSubtaskResult("Backend", false, "", "", "error")
+			}
+			return result, nil
+		},
+		DeleteIssueFunc: func(ctx context.Context, issueKey string) error {
+			if issueKey == "PROJ-40" {
+				return fmt.Errorf("error deleting issue %s: status 403", issueKey)
+			}
+			deleted = append(deleted, issueKey)
+			return nil
+		},
+	}
+
+	featureCalls := 0
+	mockFeatureManager := &mocks.MockFeatureManager{
+		CreateOrGetFeatureFunc: func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
+			featureCalls++
+			featureResult := entities.NewFeatureResult(description)
+			if featureCalls == 1 {
+				featureResult.SetSuccess("PROJ-1", "", true)
+			} else {
+				featureResult.SetExisting("PROJ-1")
+			}
+			return featureResult, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, mockFeatureManager)
+	useCase.SetRollbackOnBatchFailure(true)
+
+	result, err := useCase.Execute(ctx, "historias.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// Subtareas antes que historias y la Feature al final
+	wantDeleted := []string{"PROJ-21", "PROJ-20", "PROJ-30", "PROJ-1"}
+	if strings.Join(deleted, ",") != strings.Join(wantDeleted, ",") {
+		t.Errorf("Deleted %v, want %v", deleted, wantDeleted)
+	}
+	if strings.Join(result.RolledBack, ",") != strings.Join(wantDeleted, ",") {
+		t.Errorf("RolledBack = %v, want %v", result.RolledBack, wantDeleted)
+	}
+
+	if result.ErrorRows != 1 || result.Results[4].ErrorMessage != "jira error: summary: Field is required" {
+		t.Errorf("Expected the original row error to be kept, got %d error rows and %q", result.ErrorRows, result.Results[4].ErrorMessage)
+	}
+	messages := strings.Join(result.Errors, "\n")
+	if !strings.Contains(messages, "rollback could not delete PROJ-40") || !strings.Contains(messages, "1 of 5 issues created from historias.csv remain") {
+		t.Errorf("Expected the failed deletion to be reported, got %v", result.Errors)
+	}
+	if moved {
+		t.Error("Expected a rolled back file to stay pending")
+	}
+}
+
+func TestProcessFilesUseCase_Execute_RollbackForgetsDeletedFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	files := map[string][]*entities.UserStory{
+		"primero.csv": {
+			entities.NewUserStory("Login", "Desc", "Criterio", "", "Gestion de usuarios"),
+			entities.NewUserStory("Falla", "Desc", "Criterio", "", ""),
+		},
+		"segundo.csv": {
+			entities.NewUserStory("Logout", "Desc", "Criterio", "", "Gestion de usuarios"),
+		},
+	}
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return files[filePath], nil
+		},
+	}
+
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			result := entities.NewProcessResult(rowNumber)
+			if story.Titulo == "Falla" {
+				result.ErrorMessage = "jira error"
+				return result, nil
+			}
+			result.Success = true
+			result.WasCreated = true
+			result.IssueKey = "PROJ-" + story.Titulo
+			return result, nil
+		},
+		DeleteIssueFunc: func(ctx context.Context, issueKey string) error {
+			return nil
+		},
+	}
+
+	// Como el FeatureManager de Jira, recuerda la key de cada descripcion ya resuelta
+	resolved := make(map[string]string)
+	created := 0
+	mockFeatureManager := &mocks.MockFeatureManager{
+		CreateOrGetFeatureFunc: func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
+			featureResult := entities.NewFeatureResult(description)
+			if key, ok := resolved[description]; ok {
+				featureResult.SetExisting(key)
+				return featureResult, nil
+			}
+			created++
+			key := fmt.Sprintf("PROJ-%d", created)
+			resolved[description] = key
+			featureResult.SetSuccess(key, "", true)
+			return featureResult, nil
+		},
+		ForgetFeatureFunc: func(featureKey string) {
+			for description, key := range resolved {
+				if key == featureKey {
+					delete(resolved, description)
+				}
+			}
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, mockFeatureManager)
+	useCase.SetRollbackOnBatchFailure(true)
+
+	first, err := useCase.Execute(ctx, "primero.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute(primero.csv) error = %v", err)
+	}
+	if strings.Join(first.RolledBack, ",") != "PROJ-Login,PROJ-1" {
+		t.Fatalf("RolledBack = %v, want [PROJ-Login PROJ-1]", first.RolledBack)
+	}
+
+	second, err := useCase.Execute(ctx, "segundo.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute(segundo.csv) error = %v", err)
+	}
+	result := second.Results[0]
+	if result.FeatureKey != "PROJ-2" || !result.FeatureCreated {
+		t.Errorf("Expected the rolled back feature to be created again, got feature %s (created=%v)", result.FeatureKey, result.FeatureCreated)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_NoRollbackWithoutFailures(t *testing.T) {
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{entities.NewUserStory("Login", "Desc", "Criterio", "", "")}, nil
+		},
+	}
+
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.WasCreated = true
+			result.IssueKey = "PROJ-1"
+			return result, nil
+		},
+		DeleteIssueFunc: func(ctx context.Context, issueKey string) error {
+			t.Errorf("Unexpected DeleteIssue(%s)", issueKey)
+			return nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetRollbackOnBatchFailure(true)
+
+	result, err := useCase.Execute(context.Background(), "historias.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(result.RolledBack) != 0 {
+		t.Errorf("Expected no rollback, got %v", result.RolledBack)
+	}
+}
+
+func TestProcessFilesUseCase_Execute_IssueLinks(t *testing.T) {
+	ctx := context.Background()
+
+	login := entities.NewUserStory("Login", "Desc", "Criteria", "", "")
+	login.Links = []entities.Link{{Type: "Blocks", Target: "perfil"}, {Type: "Relates", Target: "PROJ-99"}}
+	perfil := entities.NewUserStory("Perfil", "Desc", "Criteria", "", "")
+	perfil.Links = []entities.Link{{Type: "Duplicate", Target: "Login", Inward: true}}
+	falla := entities.NewUserStory("Falla", "Desc", "Criteria", "", "")
+	falla.Links = []entities.Link{{Type: "Relates", Target: "Login"}}
+	logout := entities.NewUserStory("Logout", "Desc", "Criteria", "", "")
+	logout.Links = []entities.Link{{Type: "Relates", Target: "Falla"}, {Type: "Blocks", Target: "PROJ-50"}}
+	stories := []*entities.UserStory{login, perfil, falla, logout}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+
+	var links []string
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			if story.Titulo == "Falla" {
+				return nil, errors.New("status 400")
+			}
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			return result, nil
+		},
+		CreateIssueLinkFunc: func(ctx context.Context, inwardKey, outwardKey, linkType string) error {
+			// Jira lee inwardIssue=A, outwardIssue=B como "A blocks B": se registra como A->B
+			links = append(links, fmt.Sprintf("%s %s->%s", linkType, inwardKey, outwardKey))
+			if outwardKey == "PROJ-50" {
+				return errors.New("status 404")
+			}
+			return nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+
+	if _, err := useCase.Execute(ctx, "stories.csv", "PROJ", true); err != nil {
+		t.Fatalf("Execute() dry-run error = %v", err)
+	}
+	if len(links) != 0 {
+		t.Fatalf("Dry-run should not create links, got %v", links)
+	}
+
+	result, err := useCase.Execute(ctx, "stories.csv", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// Los titulos se resuelven a la key creada, tambien para filas posteriores
+	want := []string{"Blocks PROJ-2->PROJ-3", "Relates PROJ-2->PROJ-99", "Duplicate PROJ-2->PROJ-3", "Blocks PROJ-5->PROJ-50"}
+	if fmt.Sprint(links) != fmt.Sprint(want) {
+		t.Errorf("Links = %v, want %v", links, want)
+	}
+
+	if result.SuccessfulRows != 3 || result.ErrorRows != 1 {
+		t.Errorf("Got %d successful and %d failed rows, want 3 and 1", result.SuccessfulRows, result.ErrorRows)
+	}
+	logoutResult := result.Results[3]
+	if !logoutResult.Success || len(logoutResult.Warnings) != 2 {
+		t.Fatalf("Logout = %+v, want a successful row with two link warnings", logoutResult)
+	}
+	if !strings.Contains(logoutResult.Warnings[0], `no story titled "Falla" was created`) || !strings.Contains(logoutResult.Warnings[1], "could not link PROJ-5 to PROJ-50") {
+		t.Errorf("Unexpected warnings: %v", logoutResult.Warnings)
+	}
+}
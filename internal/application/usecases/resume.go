@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// Resume vuelve a procesar el archivo de un results.json anterior (--resume): las filas que ya
+// tienen una key creada se conservan y solo se intentan las fallidas o no procesadas. Las filas
+// se reconocen por ContentHash, asi el archivo puede haberse editado entre ejecuciones. Sin
+// filePath se usa el archivo registrado en el results.json dentro de inputDir o, si la ejecucion
+// anterior ya lo movio, dentro de processedDir
+func (uc *ProcessFilesUseCase) Resume(ctx context.Context, resultsPath, filePath, inputDir, processedDir, projectKey string, dryRun bool) (*entities.BatchResult, error) {
+	if uc.results == nil {
+		return nil, fmt.Errorf("resume requires a results directory (RESULTS_DIRECTORY)")
+	}
+
+	previous, err := uc.results.Load(ctx, resultsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous.DryRun && !dryRun {
+		return nil, fmt.Errorf("cannot resume a real run from the dry-run results %s: its issue keys were simulated", resultsPath)
+	}
+	for _, prior := range previous.Results {
+		if resumable(prior) && prior.RowHash == "" {
+			return nil, fmt.Errorf("results file %s has no row hashes (written by an older version) and cannot be resumed", resultsPath)
+		}
+	}
+
+	if filePath == "" {
+		filePath, err = uc.resumeFilePath(ctx, previous.FileName, inputDir, processedDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	projectKey = uc.projectForFile(filePath, projectKey)
+	if projectKey == "" {
+		return nil, fmt.Errorf("no project key for file (use PROYECTO%sarchivo or --project)", uc.projectSeparator)
+	}
+
+	uc.resumeFrom = previous
+	defer func() { uc.resumeFrom = nil }()
+
+	return uc.Execute(ctx, filePath, projectKey, dryRun)
+}
+
+// resumeFilePath busca el archivo de la ejecucion anterior en inputDir y luego en processedDir: una
+// ejecucion con filas creadas y filas fallidas (sin rollback) ya movio el archivo a procesados
+func (uc *ProcessFilesUseCase) resumeFilePath(ctx context.Context, fileName, inputDir, processedDir string) (string, error) {
+	inputPath := filepath.Join(inputDir, fileName)
+	if exists, err := uc.fileRepo.Exists(ctx, inputPath); err != nil || exists {
+		return inputPath, err
+	}
+
+	if processedDir != "" {
+		processedPath := filepath.Join(processedDir, fileName)
+		exists, err := uc.fileRepo.Exists(ctx, processedPath)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return processedPath, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s is not in %s nor in the processed directory; pass the file with -f", fileName, inputDir)
+}
+
+// resumable indica si la fila de la ejecucion anterior ya quedo creada y no se vuelve a intentar
+func resumable(result *entities.ProcessResult) bool {
+	return result.Success && result.IssueKey != ""
+}
+
+// resumedRows empareja las filas del archivo con las creadas en la ejecucion anterior. Devuelve
+// las filas emparejadas por indice y las creadas que ya no aparecen en el archivo, que se
+// conservan en el resultado para no perder sus keys
+func (uc *ProcessFilesUseCase) resumedRows(stories []*entities.UserStory) (map[int]*entities.ProcessResult, []*entities.ProcessResult) {
+	done := make(map[string][]*entities.ProcessResult)
+	for _, prior := range uc.resumeFrom.Results {
+		if resumable(prior) {
+			done[prior.RowHash] = append(done[prior.RowHash], prior)
+		}
+	}
+
+	// Filas identicas se emparejan en orden, una key anterior por fila
+	matched := make(map[*entities.ProcessResult]bool)
+	resumed := make(map[int]*entities.ProcessResult)
+	for i, story := range stories {
+		if story.Skip {
+			continue
+		}
+		candidates := done[story.ContentHash()]
+		if len(candidates) == 0 {
+			continue
+		}
+		prior := candidates[0]
+		done[story.ContentHash()] = candidates[1:]
+
+		prior.RowNumber = i + 2
+		prior.Resumed = true
+		matched[prior] = true
+		resumed[i] = prior
+	}
+
+	var orphaned []*entities.ProcessResult
+	for _, prior := range uc.resumeFrom.Results {
+		if resumable(prior) && !matched[prior] {
+			prior.Resumed = true
+			orphaned = append(orphaned, prior)
+		}
+	}
+
+	return resumed, orphaned
+}
+
+// mergeResumed agrega al lote las filas conservadas de la ejecucion anterior, en orden de fila
+func mergeResumed(batchResult *entities.BatchResult, resumed map[int]*entities.ProcessResult, orphaned []*entities.ProcessResult) {
+	for _, prior := range resumed {
+		batchResult.AddResult(prior)
+	}
+	sort.SliceStable(batchResult.Results, func(i, j int) bool {
+		return batchResult.Results[i].RowNumber < batchResult.Results[j].RowNumber
+	})
+
+	for _, prior := range orphaned {
+		batchResult.AddError(fmt.Sprintf("Warning: %s (%s) from the previous run no longer matches a row of %s; kept in the results", prior.IssueKey, prior.Summary, batchResult.FileName))
+		batchResult.AddResult(prior)
+	}
+}
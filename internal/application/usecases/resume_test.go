@@ -0,0 +1,312 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/tests/mocks"
+)
+
+func TestProcessFilesUseCase_Resume(t *testing.T) {
+	ctx := context.Background()
+
+	login := entities.NewUserStory("Login", "Ingresar con email", "Criterio", "", "")
+	logout := entities.NewUserStory("Logout", "Cerrar sesion", "Criterio", "", "")
+	perfil := entities.NewUserStory("Perfil", "Editar perfil", "Criterio", "", "")
+	nueva := entities.NewUserStory("Nueva", "Fila agregada despues", "Criterio", "", "")
+
+	// Primera ejecucion: Login creada, Logout fallida, Perfil no procesada. El archivo se edito
+	// despues: se agrego una fila al principio, asi ninguna conserva su numero de fila
+	previous := entities.NewBatchResult("sprint.csv", 3, false)
+	created := &entities.ProcessResult{Success: true, IssueKey: "PROJ-1", Summary: "Login", RowNumber: 2, RowHash: login.ContentHash(), WasCreated: true}
+	created.AddSubtaskResult("Subtarea", true, "PROJ-2", "", "")
+	previous.AddResult(created)
+	previous.AddResult(&entities.ProcessResult{Summary: "Logout", RowNumber: 3, RowHash: logout.ContentHash(), ErrorMessage: "field required"})
+	previous.ResultsFile = "logs/results/sprint_20240305-143000.results.json"
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{nueva, login, logout, perfil}, nil
+		},
+	}
+
+	var attempted []string
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			attempted = append(attempted, story.Titulo)
+			return &entities.ProcessResult{Success: true, IssueKey: fmt.Sprintf("PROJ-%d", 10+rowNumber), RowNumber: rowNumber, WasCreated: true}, nil
+		},
+	}
+
+	var loadedPath, savedPath string
+	store := &mocks.MockResultsStore{
+		LoadFunc: func(ctx context.Context, path string) (*entities.BatchResult, error) {
+			loadedPath = path
+			return previous, nil
+		},
+		SaveFunc: func(ctx context.Context, result *entities.BatchResult) (string, error) {
+			savedPath = result.ResultsFile
+			return result.ResultsFile, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetResultsStore(store)
+
+	result, err := useCase.Resume(ctx, previous.ResultsFile, "", "entrada", "procesados", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	if loadedPath != previous.ResultsFile || savedPath != previous.ResultsFile {
+		t.Errorf("Expected the results file to be read and rewritten, got load=%q save=%q", loadedPath, savedPath)
+	}
+	if strings.Join(attempted, ",") != "Nueva,Logout,Perfil" {
+		t.Errorf("Only the rows without a created key should be attempted, got %v", attempted)
+	}
+
+	if result.TotalRows != 4 || result.SuccessfulRows != 4 || result.ErrorRows != 0 || result.TotalSubtasksCreated != 1 {
+		t.Errorf("Unexpected merged totals: %+v", result)
+	}
+	wantKeys := []string{"PROJ-12", "PROJ-1", "PROJ-14", "PROJ-15"}
+	for i, want := range wantKeys {
+		if result.Results[i].IssueKey != want || result.Results[i].RowNumber != i+2 {
+			t.Errorf("Results[%d] = %s (row %d), want %s (row %d)", i, result.Results[i].IssueKey, result.Results[i].RowNumber, want, i+2)
+		}
+	}
+	if !result.Results[1].Resumed || result.Results[0].Resumed {
+		t.Error("Only the row kept from the previous run should be marked as resumed")
+	}
+	// Las filas creadas ahora tambien llevan su hash, asi el resultado se puede retomar otra vez
+	if result.Results[0].RowHash != nueva.ContentHash() {
+		t.Errorf("Results[0].RowHash = %q, want the row content hash", result.Results[0].RowHash)
+	}
+}
+
+// TestProcessFilesUseCase_Resume_AfterRealRun retoma el results.json que escribio una ejecucion
+// real, con los resultados tal como los devuelve CreateUserStory
+func TestProcessFilesUseCase_Resume_AfterRealRun(t *testing.T) {
+	ctx := context.Background()
+
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Login", "Ingresar con email", "Criterio", "", ""),
+		entities.NewUserStory("Logout", "Cerrar sesion", "Criterio", "", ""),
+	}
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+
+	failLogout := true
+	var attempted []string
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			attempted = append(attempted, story.Titulo)
+			if story.Titulo == "Logout" && failLogout {
+				return nil, fmt.Errorf("jira error: field required")
+			}
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			return result, nil
+		},
+	}
+
+	var saved *entities.BatchResult
+	store := &mocks.MockResultsStore{
+		LoadFunc: func(ctx context.Context, path string) (*entities.BatchResult, error) {
+			return saved, nil
+		},
+		SaveFunc: func(ctx context.Context, result *entities.BatchResult) (string, error) {
+			saved = result
+			return "logs/results/sprint.results.json", nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetResultsStore(store)
+
+	if _, err := useCase.Execute(ctx, "entrada/sprint.csv", "PROJ", false); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if saved == nil || saved.Results[0].RowHash != stories[0].ContentHash() {
+		t.Fatalf("The created row should be saved with its content hash, got %+v", saved)
+	}
+
+	failLogout = false
+	attempted = nil
+	result, err := useCase.Resume(ctx, "logs/results/sprint.results.json", "", "entrada", "procesados", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if strings.Join(attempted, ",") != "Logout" {
+		t.Errorf("Only the failed row should be attempted again, got %v", attempted)
+	}
+	if result.SuccessfulRows != 2 || result.Results[0].IssueKey != "PROJ-2" || !result.Results[0].Resumed {
+		t.Errorf("Unexpected resumed result: %+v", result.Results[0])
+	}
+}
+
+func TestProcessFilesUseCase_Resume_OrphanedRows(t *testing.T) {
+	ctx := context.Background()
+
+	login := entities.NewUserStory("Login", "Ingresar con email", "Criterio", "", "")
+	previous := entities.NewBatchResult("sprint.csv", 1, false)
+	previous.AddResult(&entities.ProcessResult{Success: true, IssueKey: "PROJ-1", Summary: "Login", RowNumber: 2, RowHash: login.ContentHash()})
+
+	// La descripcion se edito: la fila ya no coincide y se vuelve a crear
+	edited := entities.NewUserStory("Login", "Ingresar con email o SSO", "Criterio", "", "")
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{edited}, nil
+		},
+	}
+	created := 0
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			created++
+			return &entities.ProcessResult{Success: true, IssueKey: "PROJ-2", RowNumber: rowNumber}, nil
+		},
+	}
+
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetResultsStore(&mocks.MockResultsStore{
+		LoadFunc: func(ctx context.Context, path string) (*entities.BatchResult, error) {
+			return previous, nil
+		},
+	})
+
+	result, err := useCase.Resume(ctx, "results.json", "/input/sprint.csv", "", "", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if created != 1 || len(result.Results) != 2 || result.Results[1].IssueKey != "PROJ-1" {
+		t.Errorf("Expected the edited row to be created and the previous key kept, got %+v", result.Results)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "PROJ-1 (Login) from the previous run no longer matches") {
+		t.Errorf("Expected a warning for the unmatched previous row, got %v", result.Errors)
+	}
+}
+
+func TestProcessFilesUseCase_Resume_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	dryRun := entities.NewBatchResult("sprint.csv", 1, true)
+	dryRun.AddResult(&entities.ProcessResult{Success: true, IssueKey: "DRY-RUN-2", RowHash: "abc"})
+	legacy := entities.NewBatchResult("sprint.csv", 1, false)
+	legacy.AddResult(&entities.ProcessResult{Success: true, IssueKey: "PROJ-1"})
+
+	tests := []struct {
+		name         string
+		store        *mocks.MockResultsStore
+		wantErr      string
+		withoutStore bool
+	}{
+		{"without_results_store", nil, "requires a results directory", true},
+		{"dry_run_results", &mocks.MockResultsStore{LoadFunc: func(ctx context.Context, path string) (*entities.BatchResult, error) {
+			return dryRun, nil
+		}}, "from the dry-run results", false},
+		{"results_without_row_hashes", &mocks.MockResultsStore{LoadFunc: func(ctx context.Context, path string) (*entities.BatchResult, error) {
+			return legacy, nil
+		}}, "has no row hashes", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewProcessFilesUseCase(&mocks.MockFileRepository{}, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+			if !tt.withoutStore {
+				useCase.SetResultsStore(tt.store)
+			}
+
+			_, err := useCase.Resume(ctx, "results.json", "/input/sprint.csv", "", "", "PROJ", false)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Resume() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestProcessFilesUseCase_Resume_MovedFile retoma un archivo que la primera ejecucion movio a
+// procesados porque tuvo filas creadas ademas de la fallida
+func TestProcessFilesUseCase_Resume_MovedFile(t *testing.T) {
+	ctx := context.Background()
+
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Login", "Ingresar con email", "Criterio", "", ""),
+		entities.NewUserStory("Logout", "Cerrar sesion", "Criterio", "", ""),
+	}
+	files := map[string]bool{filepath.Join("entrada", "sprint.csv"): true}
+	var readPaths []string
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			if !files[filePath] {
+				return nil, fmt.Errorf("open %s: no such file or directory", filePath)
+			}
+			readPaths = append(readPaths, filePath)
+			return stories, nil
+		},
+		MoveToProcessedFunc: func(ctx context.Context, filePath string) error {
+			delete(files, filePath)
+			files[filepath.Join("procesados", filepath.Base(filePath))] = true
+			return nil
+		},
+		ExistsFunc: func(ctx context.Context, filePath string) (bool, error) {
+			return files[filePath], nil
+		},
+	}
+
+	failLogout := true
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			if story.Titulo == "Logout" && failLogout {
+				return nil, fmt.Errorf("jira error: field required")
+			}
+			result := entities.NewProcessResult(rowNumber)
+			result.Success = true
+			result.IssueKey = fmt.Sprintf("PROJ-%d", rowNumber)
+			return result, nil
+		},
+	}
+
+	var saved *entities.BatchResult
+	useCase := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	useCase.SetResultsStore(&mocks.MockResultsStore{
+		LoadFunc: func(ctx context.Context, path string) (*entities.BatchResult, error) {
+			return saved, nil
+		},
+		SaveFunc: func(ctx context.Context, result *entities.BatchResult) (string, error) {
+			saved = result
+			return "logs/results/sprint.results.json", nil
+		},
+	})
+
+	if _, err := useCase.Execute(ctx, filepath.Join("entrada", "sprint.csv"), "PROJ", false); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if files[filepath.Join("entrada", "sprint.csv")] {
+		t.Fatal("The first run should move a file with created rows to procesados")
+	}
+
+	failLogout = false
+	result, err := useCase.Resume(ctx, "logs/results/sprint.results.json", "", "entrada", "procesados", "PROJ", false)
+	if err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if want := filepath.Join("procesados", "sprint.csv"); readPaths[len(readPaths)-1] != want {
+		t.Errorf("Resume read %s, want %s", readPaths[len(readPaths)-1], want)
+	}
+	if result.SuccessfulRows != 2 {
+		t.Errorf("Expected both rows created after resuming, got %d", result.SuccessfulRows)
+	}
+
+	delete(files, filepath.Join("procesados", "sprint.csv"))
+	_, err = useCase.Resume(ctx, "logs/results/sprint.results.json", "", "entrada", "procesados", "PROJ", false)
+	if err == nil || !strings.Contains(err.Error(), "pass the file with -f") {
+		t.Errorf("Resume() error = %v, want a request for -f when the file is missing", err)
+	}
+}
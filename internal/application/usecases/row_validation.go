@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// RowSeverity indica si un hallazgo es un aviso o un error que impediria crear la fila tal cual
+type RowSeverity int
+
+const (
+	RowSeverityWarning RowSeverity = iota
+	RowSeverityError
+)
+
+// RowIssue es un hallazgo de un RowValidator sobre una fila. Code es el codigo estable del
+// manifiesto; Message es el texto de la salida de validate y vacio lo deja solo en el manifiesto
+type RowIssue struct {
+	Code     string
+	Message  string
+	Severity RowSeverity
+}
+
+// RowValidator es un chequeo independiente sobre una fila; validate corre todos los registrados
+// sobre cada fila y agrega sus hallazgos al resultado y al manifiesto
+type RowValidator interface {
+	ValidateRow(rowNumber int, story *entities.UserStory) []RowIssue
+}
+
+// RowValidatorFunc permite registrar una funcion como RowValidator
+type RowValidatorFunc func(rowNumber int, story *entities.UserStory) []RowIssue
+
+// ValidateRow implementa RowValidator
+func (f RowValidatorFunc) ValidateRow(rowNumber int, story *entities.UserStory) []RowIssue {
+	return f(rowNumber, story)
+}
+
+// AddRowValidator registra un chequeo por fila que corre despues de los incluidos
+func (uc *ValidateFileUseCase) AddRowValidator(validator RowValidator) {
+	uc.rowValidators = append(uc.rowValidators, validator)
+}
+
+// validators devuelve los chequeos incluidos, segun la configuracion, seguidos de los registrados
+func (uc *ValidateFileUseCase) validators() []RowValidator {
+	var validators []RowValidator
+	if uc.maxDescriptionLength > 0 {
+		validators = append(validators, descriptionLengthValidator{max: uc.maxDescriptionLength, truncate: uc.truncateDescriptions})
+	}
+	validators = append(validators,
+		RowValidatorFunc(validateTitleLength),
+		RowValidatorFunc(validateSubtasks),
+		RowValidatorFunc(validateColumnsOrder),
+		labelSpacesValidator{reject: uc.rejectLabelSpaces},
+	)
+	return append(validators, uc.rowValidators...)
+}
+
+// validateRow corre la cadena de validadores sobre una fila en orden de registro
+func validateRow(validators []RowValidator, rowNumber int, story *entities.UserStory) []RowIssue {
+	var issues []RowIssue
+	for _, validator := range validators {
+		issues = append(issues, validator.ValidateRow(rowNumber, story)...)
+	}
+	return issues
+}
+
+// descriptionLengthValidator avisa de descripciones que superan MAX_DESCRIPTION_LENGTH
+type descriptionLengthValidator struct {
+	max      int
+	truncate bool
+}
+
+func (v descriptionLengthValidator) ValidateRow(rowNumber int, story *entities.UserStory) []RowIssue {
+	length := utf8.RuneCountInString(story.Descripcion)
+	if length <= v.max {
+		return nil
+	}
+
+	message := fmt.Sprintf("fila %d: la descripcion tiene %d caracteres y excede MAX_DESCRIPTION_LENGTH (%d)", rowNumber, length, v.max)
+	if v.truncate {
+		message = fmt.Sprintf("fila %d: la descripcion tiene %d caracteres y se truncara a %d (MAX_DESCRIPTION_LENGTH)", rowNumber, length, v.max)
+	}
+	return []RowIssue{{Code: RowWarningDescriptionTooLong, Message: message}}
+}
+
+// validateTitleLength marca titulos mas largos que el summary que acepta Jira
+func validateTitleLength(rowNumber int, story *entities.UserStory) []RowIssue {
+	if utf8.RuneCountInString(story.Titulo) <= maxTitleLength {
+		return nil
+	}
+	return []RowIssue{{Code: RowWarningTitleTooLong}}
+}
+
+// validateSubtasks marca la fila si alguna subtarea esta vacia o excede el largo del summary;
+// el total de subtareas invalidas ya se informa en las estadisticas
+func validateSubtasks(rowNumber int, story *entities.UserStory) []RowIssue {
+	for _, subtarea := range story.Subtareas {
+		if invalidSubtask(subtarea) {
+			return []RowIssue{{Code: RowWarningInvalidSubtasks}}
+		}
+	}
+	return nil
+}
+
+// validateColumnsOrder aplica la heuristica de columnas invertidas a una sola fila; el aviso
+// para todo el archivo se calcula aparte con los promedios
+func validateColumnsOrder(rowNumber int, story *entities.UserStory) []RowIssue {
+	if !looksSwapped([]*entities.UserStory{story}) {
+		return nil
+	}
+	return []RowIssue{{Code: RowWarningColumnsSwapped}}
+}
+
+// labelSpacesValidator informa las etiquetas con espacios: con reject son un error de la fila,
+// si no un aviso con el nombre con el que se enviaran
+type labelSpacesValidator struct {
+	reject bool
+}
+
+func (v labelSpacesValidator) ValidateRow(rowNumber int, story *entities.UserStory) []RowIssue {
+	var issues []RowIssue
+	for _, label := range story.LabelsWithSpaces() {
+		if v.reject {
+			issues = append(issues, RowIssue{
+				Code:     RowWarningLabelSpaces,
+				Message:  fmt.Sprintf("fila %d: la etiqueta %q tiene espacios y Jira la rechaza (LABEL_SPACES=error)", rowNumber, label),
+				Severity: RowSeverityError,
+			})
+			continue
+		}
+		issues = append(issues, RowIssue{
+			Code:    RowWarningLabelSpaces,
+			Message: fmt.Sprintf("fila %d: la etiqueta %q se enviara como %q", rowNumber, label, entities.NormalizeLabel(label)),
+		})
+	}
+	return issues
+}
+
+// invalidSubtask indica si una subtarea no se podria crear: vacia o mas larga que el summary de Jira
+func invalidSubtask(subtarea string) bool {
+	return strings.TrimSpace(subtarea) == "" || len(subtarea) > maxTitleLength
+}
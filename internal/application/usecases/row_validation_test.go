@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/tests/mocks"
+)
+
+func TestValidateFileUseCase_RowValidatorPipeline(t *testing.T) {
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Login", "Descripcion demasiado larga", "Criterio", "", ""),
+		entities.NewUserStory("Logout", "Desc", "Criterio", "", ""),
+		entities.NewUserStory("Login", "Desc", "", "", ""),
+	}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+
+	uc := NewValidateFileUseCase(mockFileRepo, &mocks.MockJiraRepository{})
+	uc.SetMaxDescriptionLength(20, false)
+
+	// Titulos repetidos: aviso con mensaje
+	seen := map[string]int{}
+	uc.AddRowValidator(RowValidatorFunc(func(rowNumber int, story *entities.UserStory) []RowIssue {
+		if first, ok := seen[story.Titulo]; ok {
+			return []RowIssue{{Code: "duplicate_title", Message: fmt.Sprintf("fila %d: titulo repetido de la fila %d", rowNumber, first)}}
+		}
+		seen[story.Titulo] = rowNumber
+		return nil
+	}))
+	// Criterios obligatorios: error con mensaje
+	uc.AddRowValidator(RowValidatorFunc(func(rowNumber int, story *entities.UserStory) []RowIssue {
+		if story.CriterioAceptacion == "" {
+			return []RowIssue{{Code: "missing_criteria", Message: fmt.Sprintf("fila %d: sin criterios de aceptacion", rowNumber), Severity: RowSeverityError}}
+		}
+		return nil
+	}))
+	// Chequeo solo para el manifiesto
+	uc.AddRowValidator(RowValidatorFunc(func(rowNumber int, story *entities.UserStory) []RowIssue {
+		if rowNumber == 3 {
+			return []RowIssue{{Code: "manifest_only"}}
+		}
+		return nil
+	}))
+
+	result, err := uc.Execute(context.Background(), "stories.csv", "", 5)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	wantWarnings := []string{
+		"fila 2: la descripcion tiene 27 caracteres y excede MAX_DESCRIPTION_LENGTH (20)",
+		"fila 4: titulo repetido de la fila 2",
+	}
+	if strings.Join(result.Warnings, "|") != strings.Join(wantWarnings, "|") {
+		t.Errorf("Warnings = %v, want %v", result.Warnings, wantWarnings)
+	}
+	if len(result.Errors) != 1 || result.Errors[0] != "fila 4: sin criterios de aceptacion" {
+		t.Errorf("Errors = %v, want the missing criteria error", result.Errors)
+	}
+
+	tests := []struct {
+		warnings []string
+		errors   []string
+	}{
+		{[]string{RowWarningDescriptionTooLong}, nil},
+		{[]string{"manifest_only"}, nil},
+		{[]string{"duplicate_title"}, []string{"missing_criteria"}},
+	}
+	for i, tt := range tests {
+		row := result.Rows[i]
+		if strings.Join(row.Warnings, ",") != strings.Join(tt.warnings, ",") {
+			t.Errorf("Rows[%d].Warnings = %v, want %v", i, row.Warnings, tt.warnings)
+		}
+		if strings.Join(row.Errors, ",") != strings.Join(tt.errors, ",") {
+			t.Errorf("Rows[%d].Errors = %v, want %v", i, row.Errors, tt.errors)
+		}
+	}
+}
+
+func TestValidateFileUseCase_LabelSpaces(t *testing.T) {
+	first := entities.NewUserStory("Login", "Desc", "Criterio", "", "")
+	first.Labels = []string{"backend", "tech debt"}
+	second := entities.NewUserStory("Logout", "Desc", "Criterio", "", "")
+	second.Labels = []string{"backend"}
+	stories := []*entities.UserStory{first, second, entities.NewUserStory("Perfil", "Desc", "Criterio", "", "")}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+
+	for _, reject := range []bool{false, true} {
+		uc := NewValidateFileUseCase(mockFileRepo, &mocks.MockJiraRepository{})
+		uc.SetRejectLabelSpaces(reject)
+
+		result, err := uc.Execute(context.Background(), "stories.csv", "", 5)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if result.WithLabels != 2 || strings.Join(result.Labels, ",") != "backend,tech debt" {
+			t.Errorf("reject=%v: WithLabels = %d, Labels = %v", reject, result.WithLabels, result.Labels)
+		}
+
+		want := `fila 2: la etiqueta "tech debt" se enviara como "tech_debt"`
+		got := strings.Join(result.Warnings, "|")
+		if reject {
+			want = `fila 2: la etiqueta "tech debt" tiene espacios y Jira la rechaza (LABEL_SPACES=error)`
+			got = strings.Join(result.Errors, "|")
+		}
+		if got != want {
+			t.Errorf("reject=%v: got %q, want %q", reject, got, want)
+		}
+	}
+}
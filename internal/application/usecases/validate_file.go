@@ -11,15 +11,88 @@ import (
 type ValidateFileUseCase struct {
 	fileRepo repositories.FileRepository
 	jiraRepo repositories.JiraRepository
+
+	maxDescriptionLength int
+	truncateDescriptions bool
+
+	// truncationMarker cierra los textos recortados en el preview
+	truncationMarker string
+
+	// rejectLabelSpaces marca como error las etiquetas con espacios en lugar de avisar que se enviaran con "_"
+	rejectLabelSpaces bool
+
+	// rowValidators son los chequeos por fila registrados ademas de los incluidos
+	rowValidators []RowValidator
 }
 
+// defaultTruncationMarker se usa si no se configuro TRUNCATION_MARKER
+const defaultTruncationMarker = "..."
+
 type ValidationResult struct {
-	TotalStories    int
-	WithSubtasks    int
-	TotalSubtasks   int
-	WithParent      int
-	InvalidSubtasks int
-	Preview         string
+	TotalStories    int            `json:"total_stories"`
+	WithSubtasks    int            `json:"with_subtasks"`
+	TotalSubtasks   int            `json:"total_subtasks"`
+	WithParent      int            `json:"with_parent"`
+	InvalidSubtasks int            `json:"invalid_subtasks"`
+	WithLabels      int            `json:"with_labels"`
+	Labels          []string       `json:"labels,omitempty"`
+	Preview         string         `json:"-"`
+	Warnings        []string       `json:"warnings"`
+	Errors          []string       `json:"errors"`
+	Rows            []*RowManifest `json:"rows,omitempty"`
+	// SubtaskHistogram cuenta las historias por cantidad de subtareas (0, 1-3, 4+)
+	SubtaskHistogram []SubtaskBucket `json:"subtask_histogram,omitempty"`
+
+	// priorities guarda la prioridad de cada fila que la indica, para compararlas con las de Jira
+	priorities map[int]string
+}
+
+// SubtaskBucket es un rango del histograma de subtareas; Max < 0 indica rango sin tope
+type SubtaskBucket struct {
+	Label   string `json:"label"`
+	Min     int    `json:"min"`
+	Max     int    `json:"max"`
+	Stories int    `json:"stories"`
+}
+
+// newSubtaskHistogram devuelve los rangos del histograma de subtareas, sin historias contadas
+func newSubtaskHistogram() []SubtaskBucket {
+	return []SubtaskBucket{
+		{Label: "0", Min: 0, Max: 0},
+		{Label: "1-3", Min: 1, Max: 3},
+		{Label: "4+", Min: 4, Max: -1},
+	}
+}
+
+// countSubtasks suma una historia con count subtareas al rango que le corresponde
+func countSubtasks(histogram []SubtaskBucket, count int) {
+	for i := range histogram {
+		if count >= histogram[i].Min && (histogram[i].Max < 0 || count <= histogram[i].Max) {
+			histogram[i].Stories++
+			return
+		}
+	}
+}
+
+// Heuristica de columnas invertidas: titulos que parecen parrafos y descripciones que parecen titulos
+const (
+	swappedColumnsRatio       = 3.0
+	swappedColumnsMinTitleLen = 60.0
+)
+
+// FileValidation es el resultado de validar un archivo dentro de un directorio
+type FileValidation struct {
+	FilePath string
+	Result   *ValidationResult
+	Err      error
+}
+
+// DirectoryValidationResult agrupa la validacion de todos los archivos pendientes de un directorio
+type DirectoryValidationResult struct {
+	Files        []*FileValidation
+	Totals       *ValidationResult
+	ValidFiles   int
+	InvalidFiles int
 }
 
 func NewValidateFileUseCase(fileRepo repositories.FileRepository, jiraRepo repositories.JiraRepository) *ValidateFileUseCase {
@@ -29,47 +102,157 @@ func NewValidateFileUseCase(fileRepo repositories.FileRepository, jiraRepo repos
 	}
 }
 
+// SetMaxDescriptionLength avisa de descripciones que superan el maximo; truncate indica
+// si al crear se recortaran o se enviaran completas
+func (uc *ValidateFileUseCase) SetMaxDescriptionLength(max int, truncate bool) {
+	uc.maxDescriptionLength = max
+	uc.truncateDescriptions = truncate
+}
+
+// SetRejectLabelSpaces hace que una etiqueta con espacios sea un error de la fila (LABEL_SPACES=error);
+// por defecto solo se avisa con que nombre se enviara
+func (uc *ValidateFileUseCase) SetRejectLabelSpaces(reject bool) {
+	uc.rejectLabelSpaces = reject
+}
+
+// SetTruncationMarker cambia el marcador de los textos recortados en el preview (ej: "…")
+func (uc *ValidateFileUseCase) SetTruncationMarker(marker string) {
+	uc.truncationMarker = marker
+}
+
 func (uc *ValidateFileUseCase) Execute(ctx context.Context, filePath, projectKey string, rows int) (*ValidationResult, error) {
-	if err := uc.fileRepo.ValidateFile(ctx, filePath); err != nil {
+	result, err := uc.validateFile(ctx, filePath)
+	if err != nil {
 		return nil, err
 	}
 
-	stories, err := uc.fileRepo.ReadFile(ctx, filePath)
+	if err := uc.validateProject(ctx, projectKey); err != nil {
+		return result, err
+	}
+
+	if projectKey != "" {
+		uc.checkPriorities(ctx, result)
+	}
+
+	return result, nil
+}
+
+// ExecuteDirectory valida todos los archivos pendientes de inputDir sin crear nada en Jira
+func (uc *ValidateFileUseCase) ExecuteDirectory(ctx context.Context, inputDir, projectKey string) (*DirectoryValidationResult, error) {
+	files, err := uc.fileRepo.GetPendingFiles(ctx, inputDir)
 	if err != nil {
-		return nil, fmt.Errorf("error reading file for statistics: %w", err)
+		return nil, fmt.Errorf("error getting pending files: %w", err)
 	}
 
-	result := uc.generateStatistics(stories)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found in %s", inputDir)
+	}
 
-	if projectKey != "" {
-		if err := uc.jiraRepo.ValidateProject(ctx, projectKey); err != nil {
-			return result, err
+	dirResult := &DirectoryValidationResult{
+		Totals: &ValidationResult{SubtaskHistogram: newSubtaskHistogram()},
+	}
+
+	for _, file := range files {
+		result, err := uc.validateFile(ctx, file)
+		dirResult.Files = append(dirResult.Files, &FileValidation{
+			FilePath: file,
+			Result:   result,
+			Err:      err,
+		})
+
+		if err != nil {
+			dirResult.InvalidFiles++
+			continue
 		}
 
-		if err := uc.jiraRepo.ValidateSubtaskIssueType(ctx, projectKey); err != nil {
-			return result, err
+		dirResult.ValidFiles++
+		dirResult.Totals.TotalStories += result.TotalStories
+		dirResult.Totals.WithSubtasks += result.WithSubtasks
+		dirResult.Totals.TotalSubtasks += result.TotalSubtasks
+		dirResult.Totals.WithParent += result.WithParent
+		dirResult.Totals.InvalidSubtasks += result.InvalidSubtasks
+		dirResult.Totals.WithLabels += result.WithLabels
+		dirResult.Totals.Labels = appendDistinct(dirResult.Totals.Labels, result.Labels...)
+		for i, bucket := range result.SubtaskHistogram {
+			dirResult.Totals.SubtaskHistogram[i].Stories += bucket.Stories
+		}
+		for _, warning := range result.Warnings {
+			dirResult.Totals.Warnings = append(dirResult.Totals.Warnings, fmt.Sprintf("%s: %s", file, warning))
 		}
+		for _, rowError := range result.Errors {
+			dirResult.Totals.Errors = append(dirResult.Totals.Errors, fmt.Sprintf("%s: %s", file, rowError))
+		}
+	}
 
-		if err := uc.jiraRepo.ValidateFeatureIssueType(ctx); err != nil {
-			return result, err
+	// El proyecto se valida una sola vez para todo el directorio
+	if err := uc.validateProject(ctx, projectKey); err != nil {
+		return dirResult, err
+	}
+
+	if projectKey != "" {
+		// Las prioridades de Jira se consultan una vez y se comparan con las de cada archivo
+		var validated []*FileValidation
+		var results []*ValidationResult
+		for _, file := range dirResult.Files {
+			if file.Result != nil {
+				validated = append(validated, file)
+				results = append(results, file.Result)
+			}
+		}
+		for i, warnings := range uc.checkPriorities(ctx, results...) {
+			for _, warning := range warnings {
+				dirResult.Totals.Warnings = append(dirResult.Totals.Warnings, fmt.Sprintf("%s: %s", validated[i].FilePath, warning))
+			}
 		}
 	}
 
-	return result, nil
+	return dirResult, nil
+}
+
+func (uc *ValidateFileUseCase) validateFile(ctx context.Context, filePath string) (*ValidationResult, error) {
+	if err := uc.fileRepo.ValidateFile(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	stories, err := uc.fileRepo.ReadFile(ctx, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file for statistics: %w", err)
+	}
+
+	return uc.generateStatistics(stories), nil
+}
+
+func (uc *ValidateFileUseCase) validateProject(ctx context.Context, projectKey string) error {
+	if projectKey == "" {
+		return nil
+	}
+
+	if err := uc.jiraRepo.ValidateProject(ctx, projectKey); err != nil {
+		return err
+	}
+
+	if err := uc.jiraRepo.ValidateSubtaskIssueType(ctx, projectKey); err != nil {
+		return err
+	}
+
+	return uc.jiraRepo.ValidateFeatureIssueType(ctx)
 }
 
 func (uc *ValidateFileUseCase) generateStatistics(stories []*entities.UserStory) *ValidationResult {
 	result := &ValidationResult{
-		TotalStories: len(stories),
+		TotalStories:     len(stories),
+		SubtaskHistogram: newSubtaskHistogram(),
 	}
 
-	for _, story := range stories {
+	for i, story := range stories {
+		countSubtasks(result.SubtaskHistogram, len(story.Subtareas))
+
 		if story.HasSubtareas() {
 			result.WithSubtasks++
 			result.TotalSubtasks += len(story.Subtareas)
 
 			for _, subtarea := range story.Subtareas {
-				if strings.TrimSpace(subtarea) == "" || len(subtarea) > 255 {
+				if invalidSubtask(subtarea) {
 					result.InvalidSubtasks++
 				}
 			}
@@ -78,20 +261,94 @@ func (uc *ValidateFileUseCase) generateStatistics(stories []*entities.UserStory)
 		if story.HasParent() {
 			result.WithParent++
 		}
+
+		if story.Prioridad != "" {
+			if result.priorities == nil {
+				result.priorities = make(map[int]string)
+			}
+			result.priorities[i+2] = story.Prioridad
+		}
+
+		if len(story.Labels) > 0 {
+			result.WithLabels++
+			result.Labels = appendDistinct(result.Labels, story.Labels...)
+		}
 	}
 
 	if len(stories) > 0 {
 		result.Preview = uc.generatePreview(stories, 5)
 	}
 
+	validators := uc.validators()
+	for i, story := range stories {
+		issues := validateRow(validators, i+2, story)
+		result.Rows = append(result.Rows, uc.rowManifest(i+2, story, issues))
+
+		for _, issue := range issues {
+			if issue.Message == "" {
+				continue
+			}
+			if issue.Severity == RowSeverityError {
+				result.Errors = append(result.Errors, issue.Message)
+			} else {
+				result.Warnings = append(result.Warnings, issue.Message)
+			}
+		}
+	}
+
+	if looksSwapped(stories) {
+		result.Warnings = append(result.Warnings,
+			"los titulos son mucho mas largos que las descripciones; las columnas titulo y descripcion podrian estar invertidas")
+	}
+
 	return result
 }
 
+// appendDistinct agrega a list los valores que todavia no tiene, conservando el orden de aparicion
+func appendDistinct(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// looksSwapped detecta si el largo promedio del titulo supera ampliamente al de la descripcion
+func looksSwapped(stories []*entities.UserStory) bool {
+	if len(stories) == 0 {
+		return false
+	}
+
+	var titleLen, descLen int
+	for _, story := range stories {
+		titleLen += len(strings.TrimSpace(story.Titulo))
+		descLen += len(strings.TrimSpace(story.Descripcion))
+	}
+
+	avgTitle := float64(titleLen) / float64(len(stories))
+	avgDesc := float64(descLen) / float64(len(stories))
+
+	return avgTitle >= swappedColumnsMinTitleLen && avgTitle > avgDesc*swappedColumnsRatio
+}
+
 func (uc *ValidateFileUseCase) generatePreview(stories []*entities.UserStory, maxRows int) string {
 	var preview strings.Builder
 
-	preview.WriteString(fmt.Sprintf("%-30s %-50s %-20s %-15s\n", "TITULO", "DESCRIPCION", "SUBTAREAS", "PARENT"))
-	preview.WriteString(strings.Repeat("-", 115) + "\n")
+	preview.WriteString(fmt.Sprintf("%-30s %-50s %-20s %-15s %-20s\n", "TITULO", "DESCRIPCION", "SUBTAREAS", "PARENT", "ETIQUETAS"))
+	preview.WriteString(strings.Repeat("-", 136) + "\n")
+
+	marker := uc.truncationMarker
+	if marker == "" {
+		marker = defaultTruncationMarker
+	}
 
 	for i, story := range stories {
 		if i >= maxRows {
@@ -100,12 +357,12 @@ func (uc *ValidateFileUseCase) generatePreview(stories []*entities.UserStory, ma
 
 		titulo := story.Titulo
 		if len(titulo) > 28 {
-			titulo = titulo[:25] + "..."
+			titulo = titulo[:25] + marker
 		}
 
 		descripcion := story.Descripcion
 		if len(descripcion) > 48 {
-			descripcion = descripcion[:45] + "..."
+			descripcion = descripcion[:45] + marker
 		}
 
 		subtareas := ""
@@ -115,10 +372,15 @@ func (uc *ValidateFileUseCase) generatePreview(stories []*entities.UserStory, ma
 
 		parent := story.Parent
 		if len(parent) > 13 {
-			parent = parent[:10] + "..."
+			parent = parent[:10] + marker
+		}
+
+		labels := strings.Join(story.Labels, ",")
+		if len(labels) > 20 {
+			labels = labels[:17] + marker
 		}
 
-		preview.WriteString(fmt.Sprintf("%-30s %-50s %-20s %-15s\n", titulo, descripcion, subtareas, parent))
+		preview.WriteString(fmt.Sprintf("%-30s %-50s %-20s %-15s %-20s\n", titulo, descripcion, subtareas, parent, labels))
 	}
 
 	if len(stories) > maxRows {
@@ -328,6 +328,49 @@ func TestValidateFileUseCase_Statistics(t *testing.T) {
 	}
 }
 
+func TestValidateFileUseCase_SubtaskHistogram(t *testing.T) {
+	ctx := context.Background()
+
+	manySubtasks := entities.NewUserStory("Historia grande", "Desc", "Crit", "S1;S2;S3;S4;S5", "")
+	stories := append(fixtures.GetSampleUserStories(), manySubtasks)
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return []string{"a.csv", "b.csv"}, nil
+		},
+	}
+	useCase := NewValidateFileUseCase(mockFileRepo, &mocks.MockJiraRepository{})
+
+	// Fixture: 3, 3, 0 y 2 subtareas, mas una historia con 5
+	want := map[string]int{"0": 1, "1-3": 3, "4+": 1}
+
+	result, err := useCase.Execute(ctx, "test.csv", "", 5)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(result.SubtaskHistogram) != len(want) {
+		t.Fatalf("Expected %d buckets, got %+v", len(want), result.SubtaskHistogram)
+	}
+	for _, bucket := range result.SubtaskHistogram {
+		if bucket.Stories != want[bucket.Label] {
+			t.Errorf("Bucket %s = %d stories, want %d", bucket.Label, bucket.Stories, want[bucket.Label])
+		}
+	}
+
+	dirResult, err := useCase.ExecuteDirectory(ctx, "entrada", "")
+	if err != nil {
+		t.Fatalf("ExecuteDirectory() error = %v", err)
+	}
+	for _, bucket := range dirResult.Totals.SubtaskHistogram {
+		if bucket.Stories != 2*want[bucket.Label] {
+			t.Errorf("Directory bucket %s = %d stories, want %d", bucket.Label, bucket.Stories, 2*want[bucket.Label])
+		}
+	}
+}
+
 func TestValidateFileUseCase_GeneratePreview_EdgeCases(t *testing.T) {
 	mockFileRepo := &mocks.MockFileRepository{}
 	mockJiraRepo := &mocks.MockJiraRepository{}
@@ -443,3 +486,250 @@ func TestValidateFileUseCase_GeneratePreview_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFileUseCase_GeneratePreview_TruncationMarker(t *testing.T) {
+	uc := NewValidateFileUseCase(&mocks.MockFileRepository{}, &mocks.MockJiraRepository{})
+	uc.SetTruncationMarker(" [+]")
+
+	stories := []*entities.UserStory{
+		{
+			Titulo:      "Titulo suficientemente largo para ser recortado",
+			Descripcion: "Descripcion",
+			Parent:      "PROYECTO-CON-NOMBRE-MUY-LARGO-123",
+		},
+	}
+
+	preview := uc.generatePreview(stories, 5)
+	for _, expected := range []string{"Titulo suficientemente la [+]", "PROYECTO-C [+]"} {
+		if !strings.Contains(preview, expected) {
+			t.Errorf("Expected preview to contain %q, got: %s", expected, preview)
+		}
+	}
+	if strings.Contains(preview, "...") {
+		t.Errorf("Expected the default marker to be replaced, got: %s", preview)
+	}
+}
+
+func TestValidateFileUseCase_ExecuteDirectory(t *testing.T) {
+	ctx := context.Background()
+
+	storiesByFile := map[string][]*entities.UserStory{
+		"/input/a.csv": {
+			entities.NewUserStory("Story 1", "Desc", "Criteria", "Task 1;Task 2", "PROJ-1"),
+			entities.NewUserStory("Story 2", "Desc", "Criteria", "", ""),
+		},
+		"/input/b.xlsx": {
+			entities.NewUserStory("Story 3", "Desc", "Criteria", "Task 3", "Feature"),
+		},
+	}
+
+	mockFileRepo := &mocks.MockFileRepository{
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return []string{"/input/a.csv", "/input/b.xlsx"}, nil
+		},
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return storiesByFile[filePath], nil
+		},
+	}
+
+	projectValidations := 0
+	mockJiraRepo := &mocks.MockJiraRepository{
+		ValidateProjectFunc: func(ctx context.Context, projectKey string) error {
+			projectValidations++
+			return nil
+		},
+	}
+
+	useCase := NewValidateFileUseCase(mockFileRepo, mockJiraRepo)
+
+	result, err := useCase.ExecuteDirectory(ctx, "/input", "PROJ")
+	if err != nil {
+		t.Fatalf("ExecuteDirectory() error = %v", err)
+	}
+
+	if len(result.Files) != 2 || result.ValidFiles != 2 || result.InvalidFiles != 0 {
+		t.Errorf("Expected 2 valid files, got files=%d valid=%d invalid=%d", len(result.Files), result.ValidFiles, result.InvalidFiles)
+	}
+	if result.Totals.TotalStories != 3 {
+		t.Errorf("TotalStories = %d, want 3", result.Totals.TotalStories)
+	}
+	if result.Totals.WithSubtasks != 2 || result.Totals.TotalSubtasks != 3 {
+		t.Errorf("Subtask totals = %d/%d, want 2/3", result.Totals.WithSubtasks, result.Totals.TotalSubtasks)
+	}
+	if result.Totals.WithParent != 2 {
+		t.Errorf("WithParent = %d, want 2", result.Totals.WithParent)
+	}
+	if result.Files[1].Result.TotalStories != 1 {
+		t.Errorf("Expected per-file statistics for b.xlsx, got %+v", result.Files[1].Result)
+	}
+	if projectValidations != 1 {
+		t.Errorf("Expected project to be validated once, got %d", projectValidations)
+	}
+}
+
+func TestValidateFileUseCase_ExecuteDirectory_ErrorPaths(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid file is reported without stopping", func(t *testing.T) {
+		mockFileRepo := &mocks.MockFileRepository{
+			GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+				return []string{"/input/bad.csv", "/input/good.csv"}, nil
+			},
+			ValidateFileFunc: func(ctx context.Context, filePath string) error {
+				if filePath == "/input/bad.csv" {
+					return errors.New("file contains no valid stories")
+				}
+				return nil
+			},
+			ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+				return fixtures.GetSingleUserStory(), nil
+			},
+		}
+
+		result, err := NewValidateFileUseCase(mockFileRepo, &mocks.MockJiraRepository{}).ExecuteDirectory(ctx, "/input", "")
+		if err != nil {
+			t.Fatalf("ExecuteDirectory() error = %v", err)
+		}
+		if result.ValidFiles != 1 || result.InvalidFiles != 1 {
+			t.Errorf("Expected 1 valid and 1 invalid file, got %d/%d", result.ValidFiles, result.InvalidFiles)
+		}
+		if result.Files[0].Err == nil {
+			t.Error("Expected error recorded for bad.csv")
+		}
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		mockFileRepo := &mocks.MockFileRepository{
+			GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+				return nil, nil
+			},
+		}
+
+		_, err := NewValidateFileUseCase(mockFileRepo, &mocks.MockJiraRepository{}).ExecuteDirectory(ctx, "/empty", "")
+		if err == nil || !strings.Contains(err.Error(), "no files found") {
+			t.Errorf("Expected 'no files found' error, got: %v", err)
+		}
+	})
+}
+
+func TestValidateFileUseCase_SwappedColumnsWarning(t *testing.T) {
+	ctx := context.Background()
+
+	longText := "Como usuario quiero poder iniciar sesion en el sistema con mi correo y contrasena para acceder a mis datos"
+
+	tests := []struct {
+		name        string
+		stories     []*entities.UserStory
+		wantWarning bool
+	}{
+		{
+			name: "swapped-looking columns",
+			stories: []*entities.UserStory{
+				entities.NewUserStory(longText, "Login", "Criterio", "", ""),
+				entities.NewUserStory(longText+" desde el movil", "Login movil", "Criterio", "", ""),
+			},
+			wantWarning: true,
+		},
+		{
+			name:        "normal columns",
+			stories:     fixtures.GetSampleUserStories(),
+			wantWarning: false,
+		},
+		{
+			name: "short titles and short descriptions",
+			stories: []*entities.UserStory{
+				entities.NewUserStory("Login de usuario", "Login", "Criterio", "", ""),
+			},
+			wantWarning: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFileRepo := &mocks.MockFileRepository{
+				ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+					return tt.stories, nil
+				},
+			}
+
+			useCase := NewValidateFileUseCase(mockFileRepo, &mocks.MockJiraRepository{})
+			result, err := useCase.Execute(ctx, "test.csv", "", 5)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			hasWarning := false
+			for _, warning := range result.Warnings {
+				if strings.Contains(warning, "invertidas") {
+					hasWarning = true
+				}
+			}
+
+			if hasWarning != tt.wantWarning {
+				t.Errorf("Expected swapped columns warning = %v, got warnings: %v", tt.wantWarning, result.Warnings)
+			}
+		})
+	}
+}
+
+func TestValidateFileUseCase_MaxDescriptionLength(t *testing.T) {
+	ctx := context.Background()
+
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Historia corta", "Breve", "Criterio", "", ""),
+		entities.NewUserStory("Historia larga", strings.Repeat("á", 30), "Criterio", "", ""),
+	}
+
+	tests := []struct {
+		name        string
+		max         int
+		truncate    bool
+		wantWarning string
+	}{
+		{
+			name:        "truncate policy",
+			max:         20,
+			truncate:    true,
+			wantWarning: "fila 3: la descripcion tiene 30 caracteres y se truncara a 20",
+		},
+		{
+			name:        "warn policy",
+			max:         20,
+			truncate:    false,
+			wantWarning: "fila 3: la descripcion tiene 30 caracteres y excede MAX_DESCRIPTION_LENGTH (20)",
+		},
+		{
+			name: "no limit",
+			max:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFileRepo := &mocks.MockFileRepository{
+				ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+					return stories, nil
+				},
+			}
+
+			useCase := NewValidateFileUseCase(mockFileRepo, &mocks.MockJiraRepository{})
+			useCase.SetMaxDescriptionLength(tt.max, tt.truncate)
+
+			result, err := useCase.Execute(ctx, "test.csv", "", 5)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if tt.wantWarning == "" {
+				if len(result.Warnings) != 0 {
+					t.Errorf("Expected no warnings, got %v", result.Warnings)
+				}
+				return
+			}
+
+			if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], tt.wantWarning) {
+				t.Errorf("Expected warning containing %q, got %v", tt.wantWarning, result.Warnings)
+			}
+		})
+	}
+}
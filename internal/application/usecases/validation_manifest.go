@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"strings"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// Avisos por fila del manifiesto de validacion
+const (
+	RowWarningDescriptionTooLong = "description_too_long"
+	RowWarningTitleTooLong       = "title_too_long"
+	RowWarningInvalidSubtasks    = "invalid_subtasks"
+	RowWarningColumnsSwapped     = "columns_swapped"
+	RowWarningLabelSpaces        = "label_spaces"
+	RowWarningUnknownPriority    = "unknown_priority"
+)
+
+// maxTitleLength es el largo maximo del summary en Jira
+const maxTitleLength = 255
+
+// RowManifest describe una fila validada: que columnas tienen valor y que avisos genera
+type RowManifest struct {
+	Row      int             `json:"row"`
+	Fields   map[string]bool `json:"fields"`
+	Skipped  bool            `json:"skipped"`
+	Warnings []string        `json:"warnings"`
+	Errors   []string        `json:"errors,omitempty"`
+}
+
+// FileManifest agrupa las filas de un archivo validado; Error indica que no se pudo leer
+type FileManifest struct {
+	File  string         `json:"file"`
+	Error string         `json:"error,omitempty"`
+	Rows  []*RowManifest `json:"rows"`
+}
+
+// ValidationManifest es el resultado de validate en formato legible por maquinas (--manifest)
+type ValidationManifest struct {
+	Files []*FileManifest `json:"files"`
+}
+
+// BuildValidationManifest arma el manifiesto a partir de los archivos validados
+func BuildValidationManifest(files []*FileValidation) *ValidationManifest {
+	manifest := &ValidationManifest{Files: []*FileManifest{}}
+
+	for _, file := range files {
+		entry := &FileManifest{File: file.FilePath, Rows: []*RowManifest{}}
+		if file.Err != nil {
+			entry.Error = file.Err.Error()
+		}
+		if file.Result != nil && file.Result.Rows != nil {
+			entry.Rows = file.Result.Rows
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+
+	return manifest
+}
+
+// rowManifest describe una historia con los hallazgos de los validadores por fila
+func (uc *ValidateFileUseCase) rowManifest(rowNumber int, story *entities.UserStory, issues []RowIssue) *RowManifest {
+	row := &RowManifest{
+		Row: rowNumber,
+		Fields: map[string]bool{
+			"titulo":              strings.TrimSpace(story.Titulo) != "",
+			"descripcion":         strings.TrimSpace(story.Descripcion) != "",
+			"criterio_aceptacion": strings.TrimSpace(story.CriterioAceptacion) != "",
+			"subtareas":           story.HasSubtareas(),
+			"parent":              story.HasParent(),
+			"subtask_type":        story.SubtaskType != "",
+			"environment":         story.Environment != "",
+			"asignado":            story.Assignee != "",
+			"labels":              len(story.Labels) > 0,
+			"prioridad":           story.Prioridad != "",
+			"enlaces":             len(story.Links) > 0,
+		},
+		Skipped:  story.Skip,
+		Warnings: []string{},
+	}
+
+	for _, issue := range issues {
+		if issue.Severity == RowSeverityError {
+			row.Errors = append(row.Errors, issue.Code)
+		} else {
+			row.Warnings = append(row.Warnings, issue.Code)
+		}
+	}
+
+	return row
+}
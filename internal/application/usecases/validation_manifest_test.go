@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/tests/mocks"
+)
+
+func TestValidateFileUseCase_Execute_RowManifest(t *testing.T) {
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Historia completa", "Descripcion", "Criterio", "Tarea 1;Tarea 2", "PROJ-1"),
+		entities.NewUserStory("Historia con descripcion larga", strings.Repeat("d", 30), "Criterio", "", ""),
+		entities.NewUserStory(strings.Repeat("Titulo largo ", 6), "Corta", "", "", ""),
+		{Titulo: "Subtarea invalida", Descripcion: "Desc", Subtareas: []string{strings.Repeat("s", 300)}},
+	}
+	stories[1].Skip = true
+
+	mockFileRepo := &mocks.MockFileRepository{
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return stories, nil
+		},
+	}
+
+	uc := NewValidateFileUseCase(mockFileRepo, &mocks.MockJiraRepository{})
+	uc.SetMaxDescriptionLength(20, true)
+
+	result, err := uc.Execute(context.Background(), "stories.csv", "", 5)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(result.Rows) != len(stories) {
+		t.Fatalf("Expected one manifest entry per story, got %d", len(result.Rows))
+	}
+
+	tests := []struct {
+		row      int
+		warnings []string
+	}{
+		{2, []string{}},
+		{3, []string{RowWarningDescriptionTooLong}},
+		{4, []string{RowWarningColumnsSwapped}},
+		{5, []string{RowWarningInvalidSubtasks}},
+	}
+	for i, tt := range tests {
+		row := result.Rows[i]
+		if row.Row != tt.row {
+			t.Errorf("Rows[%d].Row = %d, want %d", i, row.Row, tt.row)
+		}
+		if strings.Join(row.Warnings, ",") != strings.Join(tt.warnings, ",") {
+			t.Errorf("Rows[%d].Warnings = %v, want %v", i, row.Warnings, tt.warnings)
+		}
+	}
+
+	first := result.Rows[0]
+	for _, field := range []string{"titulo", "descripcion", "criterio_aceptacion", "subtareas", "parent"} {
+		if !first.Fields[field] {
+			t.Errorf("Expected field %s to be present in row 2", field)
+		}
+	}
+	if first.Fields["subtask_type"] || first.Fields["environment"] {
+		t.Errorf("Expected optional empty fields to be absent, got %v", first.Fields)
+	}
+	if result.Rows[2].Fields["criterio_aceptacion"] {
+		t.Error("Expected criterio_aceptacion to be absent in row 4")
+	}
+	if !result.Rows[1].Skipped {
+		t.Error("Expected row 3 to be flagged as skipped")
+	}
+}
+
+func TestBuildValidationManifest(t *testing.T) {
+	files := []*FileValidation{
+		{FilePath: "ok.csv", Result: &ValidationResult{Rows: []*RowManifest{{Row: 2}}}},
+		{FilePath: "roto.csv", Err: errors.New("unsupported file format: .txt")},
+	}
+
+	manifest := BuildValidationManifest(files)
+
+	if len(manifest.Files) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(manifest.Files))
+	}
+	if len(manifest.Files[0].Rows) != 1 || manifest.Files[0].Error != "" {
+		t.Errorf("Unexpected entry for ok.csv: %+v", manifest.Files[0])
+	}
+	if manifest.Files[1].Error != "unsupported file format: .txt" || manifest.Files[1].Rows == nil {
+		t.Errorf("Unexpected entry for roto.csv: %+v", manifest.Files[1])
+	}
+}
@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
+)
+
+// DefaultWatchInterval es cada cuanto se revisa el directorio de entrada en modo watch
+const DefaultWatchInterval = 30 * time.Second
+
+// WatchReporter recibe lo que ocurre en cada ciclo de watch, para la consola y el log
+type WatchReporter interface {
+	// WatchCycle se llama al terminar cada revision del directorio
+	WatchCycle(pending, processed int)
+	// WatchFile se llama con el resultado de cada archivo procesado
+	WatchFile(filePath string, result *entities.BatchResult, err error)
+}
+
+// WatchDirectoryUseCase procesa los archivos que van apareciendo en el directorio de entrada.
+// Un archivo se procesa recien cuando su contenido no cambio entre dos revisiones, para no
+// leerlo mientras se sigue escribiendo
+type WatchDirectoryUseCase struct {
+	processUseCase *ProcessFilesUseCase
+	fileRepo       repositories.FileRepository
+	interval       time.Duration
+	reporter       WatchReporter
+
+	// pending guarda el hash visto en la revision anterior de los archivos que aun no se procesan
+	pending map[string]string
+	// done guarda el hash de los archivos ya procesados que siguen en el directorio (fallidos o
+	// en dry-run), para no repetirlos hasta que cambie su contenido
+	done map[string]string
+}
+
+func NewWatchDirectoryUseCase(processUseCase *ProcessFilesUseCase, fileRepo repositories.FileRepository) *WatchDirectoryUseCase {
+	return &WatchDirectoryUseCase{
+		processUseCase: processUseCase,
+		fileRepo:       fileRepo,
+		interval:       DefaultWatchInterval,
+		pending:        make(map[string]string),
+		done:           make(map[string]string),
+	}
+}
+
+// SetInterval cambia cada cuanto se revisa el directorio (--interval)
+func (uc *WatchDirectoryUseCase) SetInterval(interval time.Duration) {
+	if interval > 0 {
+		uc.interval = interval
+	}
+}
+
+// SetReporter registra quien recibe los ciclos y archivos procesados
+func (uc *WatchDirectoryUseCase) SetReporter(reporter WatchReporter) {
+	uc.reporter = reporter
+}
+
+// Run revisa inputDir hasta que se cancele ctx. Al cancelarse termina el archivo en curso
+// (Execute y el movimiento a procesados) antes de volver
+func (uc *WatchDirectoryUseCase) Run(ctx context.Context, inputDir, projectKey string, dryRun bool) error {
+	ticker := time.NewTicker(uc.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.poll(ctx, inputDir, projectKey, dryRun); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll hace una revision del directorio y procesa los archivos cuyo contenido ya se estabilizo
+func (uc *WatchDirectoryUseCase) poll(ctx context.Context, inputDir, projectKey string, dryRun bool) error {
+	files, err := uc.fileRepo.GetPendingFiles(ctx, inputDir)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInputDirectoryNotFound) {
+			return fmt.Errorf("%w (check INPUT_DIRECTORY)", err)
+		}
+		return fmt.Errorf("error getting pending files: %w", err)
+	}
+
+	present := make(map[string]bool, len(files))
+	processed := 0
+	for _, file := range files {
+		present[file] = true
+		if ctx.Err() != nil {
+			break
+		}
+
+		hash, err := uc.fileRepo.ComputeHash(ctx, file)
+		if err != nil {
+			// Puede haberse movido o estar bloqueado; se reintenta en la proxima revision
+			delete(uc.pending, file)
+			continue
+		}
+		if uc.done[file] == hash {
+			continue
+		}
+		if uc.pending[file] != hash {
+			uc.pending[file] = hash
+			continue
+		}
+		delete(uc.pending, file)
+
+		uc.processFile(ctx, file, projectKey, dryRun)
+		uc.done[file] = hash
+		processed++
+	}
+
+	// Los archivos que ya no estan (movidos a procesados o borrados) se olvidan
+	for file := range uc.pending {
+		if !present[file] {
+			delete(uc.pending, file)
+		}
+	}
+	for file := range uc.done {
+		if !present[file] {
+			delete(uc.done, file)
+		}
+	}
+
+	if uc.reporter != nil {
+		uc.reporter.WatchCycle(len(uc.pending), processed)
+	}
+
+	return nil
+}
+
+// processFile procesa un archivo con un contexto que no se cancela, asi una interrupcion no lo
+// deja a medio crear
+func (uc *WatchDirectoryUseCase) processFile(ctx context.Context, file, projectKey string, dryRun bool) {
+	ctx = context.WithoutCancel(ctx)
+
+	fileProject := uc.processUseCase.projectForFile(file, projectKey)
+
+	var (
+		result *entities.BatchResult
+		err    error
+	)
+	if fileProject == "" && !dryRun {
+		err = fmt.Errorf("no project key for file (use PROYECTO%sarchivo or --project)", uc.processUseCase.projectSeparator)
+	} else {
+		result, err = uc.processUseCase.Execute(ctx, file, fileProject, dryRun)
+	}
+
+	if uc.reporter != nil {
+		uc.reporter.WatchFile(file, result, err)
+	}
+}
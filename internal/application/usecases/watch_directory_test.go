@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/tests/mocks"
+)
+
+// recordingWatchReporter guarda los archivos procesados por watch
+type recordingWatchReporter struct {
+	files  []string
+	errs   []error
+	cycles int
+}
+
+func (r *recordingWatchReporter) WatchCycle(pending, processed int) {
+	r.cycles++
+}
+
+func (r *recordingWatchReporter) WatchFile(filePath string, result *entities.BatchResult, err error) {
+	r.files = append(r.files, filePath)
+	r.errs = append(r.errs, err)
+}
+
+func TestWatchDirectoryUseCase_poll(t *testing.T) {
+	ctx := context.Background()
+
+	files := []string{"/input/a.csv"}
+	hashes := map[string]string{"/input/a.csv": "v1"}
+	reads := 0
+	mockFileRepo := &mocks.MockFileRepository{
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			return files, nil
+		},
+		ComputeHashFunc: func(ctx context.Context, filePath string) (string, error) {
+			return hashes[filePath], nil
+		},
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			reads++
+			return []*entities.UserStory{entities.NewUserStory("Login", "Desc", "Criterio", "", "")}, nil
+		},
+	}
+
+	process := NewProcessFilesUseCase(mockFileRepo, &mocks.MockJiraRepository{}, &mocks.MockFeatureManager{})
+	watch := NewWatchDirectoryUseCase(process, mockFileRepo)
+	reporter := &recordingWatchReporter{}
+	watch.SetReporter(reporter)
+
+	steps := []struct {
+		name      string
+		change    func()
+		wantFiles int
+	}{
+		{"first_sight_waits", func() {}, 0},
+		{"still_being_written", func() { hashes["/input/a.csv"] = "v2" }, 0},
+		{"stable_is_processed", func() {}, 1},
+		// En dry-run el archivo queda en el directorio; no se repite mientras no cambie
+		{"unchanged_is_not_repeated", func() {}, 1},
+		{"changed_is_processed_again", func() { hashes["/input/a.csv"] = "v3" }, 1},
+		{"changed_and_stable", func() {}, 2},
+	}
+
+	for _, step := range steps {
+		step.change()
+		if err := watch.poll(ctx, "/input", "PROJ", true); err != nil {
+			t.Fatalf("%s: poll() error = %v", step.name, err)
+		}
+		if len(reporter.files) != step.wantFiles {
+			t.Fatalf("%s: processed %d files, want %d", step.name, len(reporter.files), step.wantFiles)
+		}
+	}
+	if reads != 2 || reporter.cycles != len(steps) {
+		t.Errorf("reads = %d, cycles = %d", reads, reporter.cycles)
+	}
+
+	// Un archivo que desaparece se olvida
+	files = nil
+	if err := watch.poll(ctx, "/input", "PROJ", true); err != nil {
+		t.Fatalf("poll() error = %v", err)
+	}
+	if len(watch.done) != 0 || len(watch.pending) != 0 {
+		t.Errorf("Expected removed files to be forgotten, got done=%v pending=%v", watch.done, watch.pending)
+	}
+
+	mockFileRepo.GetPendingFilesFunc = func(ctx context.Context, inputDir string) ([]string, error) {
+		return nil, errors.New("permission denied")
+	}
+	if err := watch.poll(ctx, "/input", "PROJ", true); err == nil || !strings.Contains(err.Error(), "error getting pending files") {
+		t.Errorf("Expected a pending files error, got %v", err)
+	}
+}
+
+func TestWatchDirectoryUseCase_Run_FinishesInFlightFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	moved := false
+	mockFileRepo := &mocks.MockFileRepository{
+		GetPendingFilesFunc: func(ctx context.Context, inputDir string) ([]string, error) {
+			if moved {
+				return nil, nil
+			}
+			return []string{"/input/a.csv", "/input/b.csv"}, nil
+		},
+		ComputeHashFunc: func(ctx context.Context, filePath string) (string, error) {
+			return "hash-" + filePath, nil
+		},
+		ReadFileFunc: func(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+			return []*entities.UserStory{
+				entities.NewUserStory("Login", "Desc", "Criterio", "", ""),
+				entities.NewUserStory("Logout", "Desc", "Criterio", "", ""),
+			}, nil
+		},
+		MoveToProcessedFunc: func(ctx context.Context, filePath string) error {
+			moved = true
+			return nil
+		},
+	}
+
+	// La interrupcion llega mientras se crea la primera historia
+	created := 0
+	mockJiraRepo := &mocks.MockJiraRepository{
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
+			created++
+			cancel()
+			return &entities.ProcessResult{Success: true, IssueKey: "PROJ-1", RowNumber: rowNumber}, nil
+		},
+	}
+
+	process := NewProcessFilesUseCase(mockFileRepo, mockJiraRepo, &mocks.MockFeatureManager{})
+	watch := NewWatchDirectoryUseCase(process, mockFileRepo)
+	watch.SetInterval(1)
+	reporter := &recordingWatchReporter{}
+	watch.SetReporter(reporter)
+
+	if err := watch.Run(ctx, "/input", "PROJ", false); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if created != 2 || !moved {
+		t.Errorf("The in-flight file should be finished and moved, created = %d, moved = %v", created, moved)
+	}
+	if len(reporter.files) != 1 || reporter.files[0] != "/input/a.csv" || reporter.errs[0] != nil {
+		t.Errorf("Only the in-flight file should be processed after the interrupt, got %v %v", reporter.files, reporter.errs)
+	}
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// APIEstimate aproxima las llamadas a Jira que haria una ejecucion real de un lote.
+// Las Features por descripcion se cuentan como busqueda + creacion (cota superior)
+type APIEstimate struct {
+	StoryCalls        int `json:"story_calls"`
+	SubtaskCalls      int `json:"subtask_calls"`
+	ParentValidations int `json:"parent_validations"`
+	FeatureSearches   int `json:"feature_searches"`
+	FeatureCreates    int `json:"feature_creates"`
+	RequestsPerSecond int `json:"requests_per_second"`
+}
+
+// EstimateAPICalls cuenta 1 llamada por historia y por subtarea valida, 1 validacion por parent
+// usado como key de Jira y 1 busqueda + 1 creacion por cada Feature distinta referida por
+// descripcion, segun resolution. Con feature_first un parent con forma de key cuenta la busqueda
+// y la validacion pero no la creacion; con key_only un parent sin forma de key no hace llamadas
+func EstimateAPICalls(stories []*UserStory, resolution ParentResolution, requestsPerSecond int) *APIEstimate {
+	estimate := &APIEstimate{RequestsPerSecond: requestsPerSecond}
+	searches := make(map[string]bool)
+	creates := make(map[string]bool)
+
+	for _, story := range stories {
+		if story.Skip {
+			continue
+		}
+
+		estimate.StoryCalls++
+		estimate.SubtaskCalls += len(story.GetValidSubtareas())
+
+		if !story.HasParent() {
+			continue
+		}
+
+		isKey := story.ParentIsIssueKey()
+		parent := strings.ToLower(strings.TrimSpace(story.Parent))
+		if story.ParentSearchesFeature(resolution) {
+			searches[parent] = true
+		}
+
+		switch {
+		case resolution == ParentResolutionFeatureOnly:
+			creates[parent] = true
+		case isKey:
+			estimate.ParentValidations++
+		case resolution != ParentResolutionKeyOnly:
+			creates[parent] = true
+		}
+	}
+
+	estimate.FeatureSearches = len(searches)
+	estimate.FeatureCreates = len(creates)
+
+	return estimate
+}
+
+func (e *APIEstimate) TotalCalls() int {
+	return e.StoryCalls + e.SubtaskCalls + e.ParentValidations + e.FeatureSearches + e.FeatureCreates
+}
+
+// EstimatedDuration devuelve el tiempo minimo a RequestsPerSecond; 0 si la tasa no esta configurada
+func (e *APIEstimate) EstimatedDuration() time.Duration {
+	if e.RequestsPerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(e.TotalCalls()) * time.Second / time.Duration(e.RequestsPerSecond)
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateAPICalls(t *testing.T) {
+	stories := []*UserStory{
+		NewUserStory("Historia 1", "Desc", "Criterio", "Sub 1;Sub 2", "PROJ-1"),
+		NewUserStory("Historia 2", "Desc", "Criterio", "Sub 3", "Modulo de Reportes"),
+		NewUserStory("Historia 3", "Desc", "Criterio", "", " modulo de reportes "),
+		NewUserStory("Historia 4", "Desc", "Criterio", "", ""),
+	}
+
+	estimate := EstimateAPICalls(stories, ParentResolutionKeyFirst, 4)
+
+	if estimate.StoryCalls != 4 {
+		t.Errorf("StoryCalls = %v, want 4", estimate.StoryCalls)
+	}
+	if estimate.SubtaskCalls != 3 {
+		t.Errorf("SubtaskCalls = %v, want 3", estimate.SubtaskCalls)
+	}
+	if estimate.ParentValidations != 1 {
+		t.Errorf("ParentValidations = %v, want 1", estimate.ParentValidations)
+	}
+	if estimate.FeatureSearches != 1 || estimate.FeatureCreates != 1 {
+		t.Errorf("FeatureSearches/FeatureCreates = %v/%v, want 1/1", estimate.FeatureSearches, estimate.FeatureCreates)
+	}
+	if estimate.TotalCalls() != 10 {
+		t.Errorf("TotalCalls() = %v, want 10", estimate.TotalCalls())
+	}
+	if estimate.EstimatedDuration() != 2500*time.Millisecond {
+		t.Errorf("EstimatedDuration() = %v, want 2.5s", estimate.EstimatedDuration())
+	}
+}
+
+func TestEstimateAPICalls_ParentResolution(t *testing.T) {
+	stories := []*UserStory{
+		NewUserStory("Historia 1", "Desc", "Criterio", "", "PROJ-1"),
+		NewUserStory("Historia 2", "Desc", "Criterio", "", "Modulo de Reportes"),
+		NewUserStory("Historia 3", "Desc", "Criterio", "", " modulo de reportes "),
+	}
+
+	tests := []struct {
+		name            string
+		resolution      ParentResolution
+		wantValidations int
+		wantSearches    int
+		wantCreates     int
+	}{
+		{name: "key_first", resolution: ParentResolutionKeyFirst, wantValidations: 1, wantSearches: 1, wantCreates: 1},
+		{name: "feature_first", resolution: ParentResolutionFeatureFirst, wantValidations: 1, wantSearches: 2, wantCreates: 1},
+		{name: "key_only", resolution: ParentResolutionKeyOnly, wantValidations: 1, wantSearches: 0, wantCreates: 0},
+		{name: "feature_only", resolution: ParentResolutionFeatureOnly, wantValidations: 0, wantSearches: 2, wantCreates: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate := EstimateAPICalls(stories, tt.resolution, 0)
+
+			if estimate.ParentValidations != tt.wantValidations {
+				t.Errorf("ParentValidations = %v, want %v", estimate.ParentValidations, tt.wantValidations)
+			}
+			if estimate.FeatureSearches != tt.wantSearches {
+				t.Errorf("FeatureSearches = %v, want %v", estimate.FeatureSearches, tt.wantSearches)
+			}
+			if estimate.FeatureCreates != tt.wantCreates {
+				t.Errorf("FeatureCreates = %v, want %v", estimate.FeatureCreates, tt.wantCreates)
+			}
+		})
+	}
+}
+
+func TestAPIEstimate_EstimatedDuration_NoRate(t *testing.T) {
+	estimate := EstimateAPICalls([]*UserStory{NewUserStory("Historia", "Desc", "Criterio", "", "")}, ParentResolutionKeyFirst, 0)
+
+	if estimate.EstimatedDuration() != 0 {
+		t.Errorf("EstimatedDuration() = %v, want 0 without REQUESTS_PER_SECOND", estimate.EstimatedDuration())
+	}
+}
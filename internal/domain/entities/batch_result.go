@@ -3,19 +3,34 @@ package entities
 import "time"
 
 type BatchResult struct {
-	FileName         string           `json:"file_name"`
-	TotalRows        int              `json:"total_rows"`
-	ProcessedRows    int              `json:"processed_rows"`
-	SuccessfulRows   int              `json:"successful_rows"`
-	ErrorRows        int              `json:"error_rows"`
-	SkippedRows      int              `json:"skipped_rows"`
-	StartTime        time.Time        `json:"start_time"`
-	EndTime          time.Time        `json:"end_time"`
-	Duration         time.Duration    `json:"duration"`
-	Results          []*ProcessResult `json:"results"`
-	Errors           []string         `json:"errors"`
-	ValidationErrors []string         `json:"validation_errors"`
-	DryRun           bool             `json:"dry_run"`
+	FileName             string           `json:"file_name"`
+	TotalRows            int              `json:"total_rows"`
+	ProcessedRows        int              `json:"processed_rows"`
+	SuccessfulRows       int              `json:"successful_rows"`
+	ErrorRows            int              `json:"error_rows"`
+	SkippedRows          int              `json:"skipped_rows"`
+	FeaturesCreated      int              `json:"features_created"`
+	FeaturesReused       int              `json:"features_reused"`
+	TotalSubtasksCreated int              `json:"total_subtasks_created"`
+	TotalSubtasksFailed  int              `json:"total_subtasks_failed"`
+	StartTime            time.Time        `json:"start_time"`
+	EndTime              time.Time        `json:"end_time"`
+	Duration             time.Duration    `json:"duration"`
+	Results              []*ProcessResult `json:"results"`
+	Errors               []string         `json:"errors"`
+	ValidationErrors     []string         `json:"validation_errors"`
+	DryRun               bool             `json:"dry_run"`
+	APIEstimate          *APIEstimate     `json:"api_estimate,omitempty"`
+	// Aborted indica que el procesamiento se detuvo antes de la ultima fila (ej: MAX_ISSUES_PER_RUN)
+	Aborted bool `json:"aborted,omitempty"`
+	// RolledBack son las keys eliminadas por ROLLBACK_ON_BATCH_FAILURE tras una fila fallida
+	RolledBack []string `json:"rolled_back,omitempty"`
+	// ResultsFile es la ruta del results.json escrito para este archivo (RESULTS_DIRECTORY)
+	ResultsFile string `json:"results_file,omitempty"`
+
+	// features guarda si cada Feature contada fue creada, para contarla una vez aunque la usen
+	// varias filas
+	features map[string]bool
 }
 
 func NewBatchResult(fileName string, totalRows int, dryRun bool) *BatchResult {
@@ -38,7 +53,45 @@ func (br *BatchResult) AddResult(result *ProcessResult) {
 		br.SuccessfulRows++
 	} else {
 		br.ErrorRows++
+		if result.ErrorCode == "" {
+			result.ErrorCode = ClassifyError(result.ErrorMessage)
+		}
+	}
+
+	br.TotalSubtasksCreated += len(result.GetSuccessfulSubtasks())
+	br.TotalSubtasksFailed += len(result.GetFailedSubtasks())
+
+	br.countFeature(result.FeatureKey, result.FeatureCreated)
+}
+
+// countFeature cuenta cada Feature una sola vez: como creada si alguna fila la creo (aunque otra
+// la haya reutilizado antes, con workers en paralelo) y si no como reutilizada
+func (br *BatchResult) countFeature(featureKey string, created bool) {
+	if featureKey == "" {
+		return
+	}
+	if br.features == nil {
+		br.features = make(map[string]bool)
+	}
+
+	wasCreated, seen := br.features[featureKey]
+	switch {
+	case !seen && created:
+		br.FeaturesCreated++
+	case !seen:
+		br.FeaturesReused++
+	case created && !wasCreated:
+		br.FeaturesReused--
+		br.FeaturesCreated++
+	default:
+		return
 	}
+	br.features[featureKey] = created || wasCreated
+}
+
+// AddSkipped cuenta una fila excluida del procesamiento por la columna skip
+func (br *BatchResult) AddSkipped() {
+	br.SkippedRows++
 }
 
 func (br *BatchResult) AddError(error string) {
@@ -95,6 +95,77 @@ func TestBatchResult_AddResult(t *testing.T) {
 	}
 }
 
+func TestBatchResult_AddResult_FeatureCounters(t *testing.T) {
+	result := NewBatchResult("test.csv", 4, false)
+
+	created := NewProcessResult(2)
+	created.Success = true
+	created.SetFeature("PROJ-10", true)
+	result.AddResult(created)
+
+	reused := NewProcessResult(3)
+	reused.Success = true
+	reused.SetFeature("PROJ-10", false)
+	result.AddResult(reused)
+
+	failedStory := NewProcessResult(4)
+	failedStory.SetFeature("PROJ-11", true)
+	result.AddResult(failedStory)
+
+	withoutFeature := NewProcessResult(5)
+	withoutFeature.Success = true
+	result.AddResult(withoutFeature)
+
+	existing := NewProcessResult(6)
+	existing.Success = true
+	existing.SetFeature("PROJ-12", false)
+	result.AddResult(existing)
+
+	// Con workers en paralelo una fila puede reutilizar la Feature antes de que se agregue la que la creo
+	reusedFirst := NewProcessResult(7)
+	reusedFirst.SetFeature("PROJ-13", false)
+	result.AddResult(reusedFirst)
+	createdLater := NewProcessResult(8)
+	createdLater.SetFeature("PROJ-13", true)
+	result.AddResult(createdLater)
+
+	// Cada Feature cuenta una vez: PROJ-10, PROJ-11 y PROJ-13 creadas, PROJ-12 reutilizada
+	if result.FeaturesCreated != 3 {
+		t.Errorf("FeaturesCreated = %v, want 3", result.FeaturesCreated)
+	}
+	if result.FeaturesReused != 1 {
+		t.Errorf("FeaturesReused = %v, want 1", result.FeaturesReused)
+	}
+}
+
+func TestBatchResult_AddResult_SubtaskTotals(t *testing.T) {
+	result := NewBatchResult("test.csv", 3, false)
+
+	allCreated := NewProcessResult(2)
+	allCreated.Success = true
+	allCreated.AddSubtaskResult("Sub 1", true, "PROJ-2", "", "")
+	allCreated.AddSubtaskResult("Sub 2", true, "PROJ-3", "", "")
+	result.AddResult(allCreated)
+
+	mixed := NewProcessResult(3)
+	mixed.Success = true
+	mixed.AddSubtaskResult("Sub 3", true, "PROJ-5", "", "")
+	mixed.AddSubtaskResult("Sub 4", false, "", "", "field required")
+	result.AddResult(mixed)
+
+	allFailed := NewProcessResult(4)
+	allFailed.Success = true
+	allFailed.AddSubtaskResult("Sub 5", false, "", "", "timeout")
+	result.AddResult(allFailed)
+
+	if result.TotalSubtasksCreated != 3 {
+		t.Errorf("TotalSubtasksCreated = %v, want 3", result.TotalSubtasksCreated)
+	}
+	if result.TotalSubtasksFailed != 2 {
+		t.Errorf("TotalSubtasksFailed = %v, want 2", result.TotalSubtasksFailed)
+	}
+}
+
 func TestBatchResult_AddError(t *testing.T) {
 	batchResult := NewBatchResult("test.csv", 10, false)
 
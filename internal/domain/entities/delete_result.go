@@ -0,0 +1,15 @@
+package entities
+
+// DeleteResult es el resultado de eliminar un issue con el comando delete
+type DeleteResult struct {
+	IssueKey string `json:"issue_key"`
+	Deleted  bool   `json:"deleted"`
+	// AlreadyDeleted indica que Jira respondio 404: el issue no existe, por lo que no es un error
+	AlreadyDeleted bool   `json:"already_deleted,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// Failed indica si el issue sigue existiendo tras el intento
+func (dr *DeleteResult) Failed() bool {
+	return !dr.Deleted && !dr.AlreadyDeleted
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Codigos con los que se agrupan las filas fallidas en el resumen
+const (
+	ErrorCodeAuth        = "AUTH"
+	ErrorCodeValidation  = "VALIDATION"
+	ErrorCodeNotFound    = "NOT_FOUND"
+	ErrorCodeRateLimited = "RATE_LIMITED"
+	ErrorCodeServer      = "SERVER"
+	ErrorCodeNetwork     = "NETWORK"
+	ErrorCodeSubtasks    = "SUBTASKS"
+	ErrorCodeIssueLimit  = "ISSUE_LIMIT"
+	ErrorCodeUnknown     = "UNKNOWN"
+)
+
+var serverStatusPattern = regexp.MustCompile(`status 5\d\d`)
+
+// errorCodeRules se evaluan en orden; la primera con alguna coincidencia define el codigo
+var errorCodeRules = []struct {
+	code     string
+	patterns []string
+}{
+	{ErrorCodeAuth, []string{"status 401", "status 403", "unauthorized", "forbidden"}},
+	{ErrorCodeRateLimited, []string{"status 429", "rate limit"}},
+	{ErrorCodeNotFound, []string{"status 404", "not found", "no jira user matches", "no issue found"}},
+	{ErrorCodeNetwork, []string{"connection refused", "no such host", "timeout", "timed out", "proxy connection failed", "deadline exceeded", "connection reset"}},
+	{ErrorCodeSubtasks, []string{"subtasks failed"}},
+	{ErrorCodeValidation, []string{"jira error:", "status 400", "invalid", "ambiguous", "is not an issue key", "belongs to project"}},
+}
+
+// ClassifyError deduce el codigo de error de un mensaje de fila fallida
+func ClassifyError(message string) string {
+	lower := strings.ToLower(message)
+
+	for _, rule := range errorCodeRules {
+		for _, pattern := range rule.patterns {
+			if strings.Contains(lower, pattern) {
+				return rule.code
+			}
+		}
+	}
+
+	if serverStatusPattern.MatchString(lower) {
+		return ErrorCodeServer
+	}
+
+	return ErrorCodeUnknown
+}
+
+// ErrorCodeCount es la cantidad de filas fallidas con un mismo codigo
+type ErrorCodeCount struct {
+	Code  string `json:"code"`
+	Count int    `json:"count"`
+}
+
+// GroupFailuresByCode cuenta las filas fallidas por codigo, de la mas frecuente a la menos frecuente
+func GroupFailuresByCode(results []*ProcessResult) []ErrorCodeCount {
+	counts := make(map[string]int)
+	for _, result := range results {
+		if result.Success {
+			continue
+		}
+		code := result.ErrorCode
+		if code == "" {
+			code = ClassifyError(result.ErrorMessage)
+		}
+		counts[code]++
+	}
+
+	grouped := make([]ErrorCodeCount, 0, len(counts))
+	for code, count := range counts {
+		grouped = append(grouped, ErrorCodeCount{Code: code, Count: count})
+	}
+	sort.Slice(grouped, func(i, j int) bool {
+		if grouped[i].Count != grouped[j].Count {
+			return grouped[i].Count > grouped[j].Count
+		}
+		return grouped[i].Code < grouped[j].Code
+	})
+
+	return grouped
+}
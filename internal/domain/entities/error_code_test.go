@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"error creating issue: status 401, body: ", ErrorCodeAuth},
+		{"error creating issue: status 429, body: slow down", ErrorCodeRateLimited},
+		{"feature creation failed: Parent issue validation failed: parent issue 'PROJ-9' not found", ErrorCodeNotFound},
+		{"jira error: ; summary: Summary is required", ErrorCodeValidation},
+		{"invalid subtask_type: 'Tarea' is not a subtask type", ErrorCodeValidation},
+		{"error creating issue: status 503, body: unavailable", ErrorCodeServer},
+		{"error creating issue: dial tcp: lookup jira.example.com: no such host", ErrorCodeNetwork},
+		{"all 3 subtasks failed (SUBTASK_FAILURE_POLICY=fail)", ErrorCodeSubtasks},
+		{"something unexpected", ErrorCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyError(tt.message); got != tt.want {
+			t.Errorf("ClassifyError(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestGroupFailuresByCode(t *testing.T) {
+	batch := NewBatchResult("test.csv", 8, false)
+
+	messages := []string{
+		"jira error: ; summary: Summary is required",
+		"error creating issue: status 401, body: ",
+		"jira error: ; priority: invalid",
+		"error creating issue: status 429, body: ",
+		"error creating issue: status 403, body: ",
+		"invalid subtask_type: 'Tarea' is not a subtask type",
+	}
+	for i, message := range messages {
+		result := NewProcessResult(i + 2)
+		result.ErrorMessage = message
+		batch.AddResult(result)
+	}
+
+	success := NewProcessResult(10)
+	success.Success = true
+	batch.AddResult(success)
+
+	// Un codigo asignado explicitamente no se reclasifica
+	explicit := NewProcessResult(11)
+	explicit.ErrorCode = ErrorCodeNetwork
+	explicit.ErrorMessage = "jira error: something"
+	batch.AddResult(explicit)
+
+	if batch.Results[0].ErrorCode != ErrorCodeValidation {
+		t.Errorf("AddResult should set the error code, got %q", batch.Results[0].ErrorCode)
+	}
+
+	want := []ErrorCodeCount{
+		{Code: ErrorCodeValidation, Count: 3},
+		{Code: ErrorCodeAuth, Count: 2},
+		{Code: ErrorCodeNetwork, Count: 1},
+		{Code: ErrorCodeRateLimited, Count: 1},
+	}
+	if got := GroupFailuresByCode(batch.Results); !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupFailuresByCode() = %v, want %v", got, want)
+	}
+
+	if got := GroupFailuresByCode([]*ProcessResult{success}); len(got) != 0 {
+		t.Errorf("GroupFailuresByCode() without failures = %v, want empty", got)
+	}
+}
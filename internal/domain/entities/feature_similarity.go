@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultFeatureSimilarityThreshold es la proporcion de palabras en comun a partir de
+// la cual dos descripciones se consideran la misma Feature
+const DefaultFeatureSimilarityThreshold = 0.7
+
+var (
+	punctuationPattern = regexp.MustCompile(`[^\w\s]`)
+	whitespacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// NormalizeFeatureDescription pasa a minusculas, quita puntuacion y colapsa espacios
+func NormalizeFeatureDescription(description string) string {
+	desc := strings.TrimSpace(strings.ToLower(description))
+	desc = punctuationPattern.ReplaceAllString(desc, "")
+	return whitespacePattern.ReplaceAllString(desc, " ")
+}
+
+// FeatureDescriptionSimilarity devuelve la proporcion de palabras (de mas de 2 letras)
+// en comun entre dos descripciones ya normalizadas, sobre la mas larga
+func FeatureDescriptionSimilarity(desc1, desc2 string) float64 {
+	words1 := strings.Fields(desc1)
+	words2 := strings.Fields(desc2)
+
+	if len(words1) == 0 || len(words2) == 0 {
+		if desc1 == desc2 {
+			return 1
+		}
+		return 0
+	}
+
+	wordMap := make(map[string]bool)
+	for _, word := range words1 {
+		if len(word) > 2 {
+			wordMap[word] = true
+		}
+	}
+
+	commonWords := 0
+	for _, word := range words2 {
+		if len(word) > 2 && wordMap[word] {
+			commonWords++
+		}
+	}
+
+	totalWords := len(words1)
+	if len(words2) > totalWords {
+		totalWords = len(words2)
+	}
+
+	return float64(commonWords) / float64(totalWords)
+}
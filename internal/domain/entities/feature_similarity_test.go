@@ -0,0 +1,32 @@
+package entities
+
+import "testing"
+
+func TestNormalizeFeatureDescription(t *testing.T) {
+	got := NormalizeFeatureDescription("  Gestion   de Usuarios!! ")
+	if got != "gestion de usuarios" {
+		t.Errorf("NormalizeFeatureDescription() = %q, want %q", got, "gestion de usuarios")
+	}
+}
+
+func TestFeatureDescriptionSimilarity(t *testing.T) {
+	tests := []struct {
+		name  string
+		desc1 string
+		desc2 string
+		want  float64
+	}{
+		{"identical", "gestion de usuarios", "gestion de usuarios", 2.0 / 3.0},
+		{"different", "gestion de usuarios", "reportes financieros", 0},
+		{"both_empty", "", "", 1},
+		{"one_empty", "gestion", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FeatureDescriptionSimilarity(tt.desc1, tt.desc2); got != tt.want {
+				t.Errorf("FeatureDescriptionSimilarity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package entities
+
+import "strings"
+
+// ParseLabels separa la columna labels por coma o punto y coma; descarta espacios en los
+// extremos, etiquetas vacias y repetidas (Jira distingue mayusculas, "UI" y "ui" se conservan)
+func ParseLabels(raw string) []string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, label := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == ';' }) {
+		label = strings.TrimSpace(label)
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// NormalizeLabel reemplaza los espacios internos por guiones bajos ("tech debt" -> "tech_debt"),
+// porque Jira rechaza etiquetas con espacios
+func NormalizeLabel(label string) string {
+	return strings.Join(strings.Fields(label), "_")
+}
+
+// NormalizeLabels aplica NormalizeLabel a cada etiqueta y quita las que quedan repetidas
+func NormalizeLabels(labels []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, label := range labels {
+		label = NormalizeLabel(label)
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		normalized = append(normalized, label)
+	}
+	return normalized
+}
+
+// LabelsWithSpaces devuelve las etiquetas de la historia que Jira rechazaria por tener espacios
+func (us *UserStory) LabelsWithSpaces() []string {
+	var invalid []string
+	for _, label := range us.Labels {
+		if strings.ContainsAny(label, " \t") {
+			invalid = append(invalid, label)
+		}
+	}
+	return invalid
+}
@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"comma_separated", "backend,ui", []string{"backend", "ui"}},
+		{"semicolon_and_spaces", " backend ; tech debt ;", []string{"backend", "tech debt"}},
+		{"duplicates_removed", "ui,backend,ui", []string{"ui", "backend"}},
+		{"case_preserved", "UI,ui", []string{"UI", "ui"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseLabels(tt.raw); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLabels(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeLabels(t *testing.T) {
+	got := NormalizeLabels([]string{"tech debt", "tech_debt", "ui", " mobile  app "})
+	want := []string{"tech_debt", "ui", "mobile_app"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeLabels() = %v, want %v", got, want)
+	}
+}
+
+func TestUserStory_LabelsWithSpaces(t *testing.T) {
+	story := NewUserStory("Titulo", "Descripcion", "Criterio", "", "")
+	story.Labels = []string{"backend", "tech debt"}
+
+	if got := story.LabelsWithSpaces(); !reflect.DeepEqual(got, []string{"tech debt"}) {
+		t.Errorf("LabelsWithSpaces() = %v, want [tech debt]", got)
+	}
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LinkSeparator separa los enlaces de la columna enlaces (ej: "blocks:PROJ-10;relates:PROJ-11")
+const LinkSeparator = ";"
+
+// Link es un enlace de la historia con otro issue. Target es una key de Jira o el titulo de otra
+// fila del mismo archivo, que se resuelve a la key creada para esa fila
+type Link struct {
+	// Type es el nombre del tipo de link en Jira (ej: "Blocks")
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	// Inward indica que el verbo es el inverso del tipo (ej: "is blocked by"): el destino queda
+	// como inwardIssue y la historia como outwardIssue
+	Inward bool `json:"inward,omitempty"`
+}
+
+// linkVerbs traduce los verbos de la columna enlaces al tipo de link de Jira y su sentido. Se
+// comparan en minusculas, con "_" y "-" como espacios y sin el "is" inicial
+var linkVerbs = map[string]Link{
+	"blocks":        {Type: "Blocks"},
+	"blocked by":    {Type: "Blocks", Inward: true},
+	"relates":       {Type: "Relates"},
+	"relates to":    {Type: "Relates"},
+	"duplicates":    {Type: "Duplicate"},
+	"duplicated by": {Type: "Duplicate", Inward: true},
+	"clones":        {Type: "Cloners"},
+	"cloned by":     {Type: "Cloners", Inward: true},
+}
+
+// ParseLinks separa la columna enlaces por punto y coma (escapable con "\;"); cada enlace es
+// tipo:destino. Los verbos conocidos (blocks, is blocked by, relates, duplicates, clones...) se
+// traducen al tipo de Jira; cualquier otro se usa tal cual como nombre del tipo. Descarta los
+// enlaces repetidos y falla si alguno no tiene tipo o destino
+func ParseLinks(raw string) ([]Link, error) {
+	var links []Link
+	seen := make(map[Link]bool)
+	for _, entry := range SplitMultiValue(raw, LinkSeparator) {
+		verb, target, found := strings.Cut(entry, ":")
+		verb, target = strings.TrimSpace(verb), strings.TrimSpace(target)
+		if !found || verb == "" || target == "" {
+			return nil, fmt.Errorf("invalid link %q: expected type:target (e.g. blocks:PROJ-10)", entry)
+		}
+
+		link, ok := linkVerbs[normalizeLinkVerb(verb)]
+		if !ok {
+			link = Link{Type: verb}
+		}
+		link.Target = target
+
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+func normalizeLinkVerb(verb string) string {
+	verb = strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToLower(verb))
+	return strings.TrimPrefix(strings.Join(strings.Fields(verb), " "), "is ")
+}
+
+// TargetIsIssueKey indica si el destino es una key de Jira y no el titulo de otra fila
+func (l Link) TargetIsIssueKey() bool {
+	return issueKeyPattern.MatchString(l.Target)
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []Link
+	}{
+		{"empty", "", nil},
+		{
+			"keys",
+			"blocks:PROJ-10;relates:PROJ-11",
+			[]Link{{Type: "Blocks", Target: "PROJ-10"}, {Type: "Relates", Target: "PROJ-11"}},
+		},
+		{
+			"inward_verbs",
+			"is blocked by: PROJ-1 ; Duplicated_By:PROJ-2;is-cloned-by:PROJ-3",
+			[]Link{
+				{Type: "Blocks", Target: "PROJ-1", Inward: true},
+				{Type: "Duplicate", Target: "PROJ-2", Inward: true},
+				{Type: "Cloners", Target: "PROJ-3", Inward: true},
+			},
+		},
+		{
+			"row_title_with_colon_and_escaped_separator",
+			`blocks:Login: paso 1\; email`,
+			[]Link{{Type: "Blocks", Target: "Login: paso 1; email"}},
+		},
+		{"custom_type_kept", "Implements:PROJ-5", []Link{{Type: "Implements", Target: "PROJ-5"}}},
+		{"duplicates_removed", "blocks:PROJ-1;Blocks:PROJ-1;", []Link{{Type: "Blocks", Target: "PROJ-1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLinks(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseLinks(%q) error = %v", tt.raw, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLinks(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLinks_Invalid(t *testing.T) {
+	for _, raw := range []string{"PROJ-10", "blocks:", ":PROJ-10", "relates:PROJ-1;blocks"} {
+		if _, err := ParseLinks(raw); err == nil || !strings.Contains(err.Error(), "invalid link") {
+			t.Errorf("ParseLinks(%q) error = %v, want an invalid link error", raw, err)
+		}
+	}
+}
+
+func TestLink_TargetIsIssueKey(t *testing.T) {
+	if !(Link{Type: "Blocks", Target: "PROJ-10"}).TargetIsIssueKey() {
+		t.Errorf("PROJ-10 should be an issue key")
+	}
+	if (Link{Type: "Blocks", Target: "Login con Google"}).TargetIsIssueKey() {
+		t.Errorf("A row title should not be an issue key")
+	}
+}
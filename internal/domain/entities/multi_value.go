@@ -0,0 +1,50 @@
+package entities
+
+import "strings"
+
+// MultiValueEscape es el prefijo que permite incluir el separador como texto literal (ej: "\;")
+const MultiValueEscape = `\`
+
+// SplitMultiValue divide un valor con multiples elementos por el separador indicado,
+// respetando el separador escapado (ej: "\;") como parte literal del elemento.
+// Los elementos se devuelven sin espacios en los extremos y se descartan los vacios.
+func SplitMultiValue(raw, separator string) []string {
+	if raw == "" {
+		return nil
+	}
+	if separator == "" {
+		if trimmed := strings.TrimSpace(raw); trimmed != "" {
+			return []string{trimmed}
+		}
+		return nil
+	}
+
+	escaped := MultiValueEscape + separator
+
+	var parts []string
+	var current strings.Builder
+
+	flush := func() {
+		if trimmed := strings.TrimSpace(current.String()); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(raw); {
+		switch {
+		case strings.HasPrefix(raw[i:], escaped):
+			current.WriteString(separator)
+			i += len(escaped)
+		case strings.HasPrefix(raw[i:], separator):
+			flush()
+			i += len(separator)
+		default:
+			current.WriteByte(raw[i])
+			i++
+		}
+	}
+	flush()
+
+	return parts
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitMultiValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		separator string
+		want      []string
+	}{
+		{
+			name:      "simple split",
+			raw:       "a;b;c",
+			separator: ";",
+			want:      []string{"a", "b", "c"},
+		},
+		{
+			name:      "escaped separator kept as literal",
+			raw:       `Task with \; semicolon`,
+			separator: ";",
+			want:      []string{"Task with ; semicolon"},
+		},
+		{
+			name:      "escaped and unescaped separators",
+			raw:       `uno\;dos;tres`,
+			separator: ";",
+			want:      []string{"uno;dos", "tres"},
+		},
+		{
+			name:      "trims and drops empty entries",
+			raw:       " a ;; b ; ",
+			separator: ";",
+			want:      []string{"a", "b"},
+		},
+		{
+			name:      "backslash not before separator is preserved",
+			raw:       `C:\temp;otro`,
+			separator: ";",
+			want:      []string{`C:\temp`, "otro"},
+		},
+		{
+			name:      "multi-character separator",
+			raw:       `a||b\||c`,
+			separator: "||",
+			want:      []string{"a", "b||c"},
+		},
+		{
+			name:      "empty input",
+			raw:       "",
+			separator: ";",
+			want:      nil,
+		},
+		{
+			name:      "empty separator returns whole value",
+			raw:       " valor ",
+			separator: "",
+			want:      []string{"valor"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitMultiValue(tt.raw, tt.separator)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitMultiValue(%q, %q) = %q, want %q", tt.raw, tt.separator, got, tt.want)
+			}
+		})
+	}
+}
@@ -14,13 +14,22 @@ const (
 type ProcessResult struct {
 	Success         bool             `json:"success"`
 	IssueKey        string           `json:"issue_key,omitempty"`
+	Summary         string           `json:"summary,omitempty"`
 	IssueURL        string           `json:"issue_url,omitempty"`
 	ErrorMessage    string           `json:"error_message,omitempty"`
+	ErrorCode       string           `json:"error_code,omitempty"`
 	RowNumber       int              `json:"row_number,omitempty"`
 	Timestamp       time.Time        `json:"timestamp"`
 	Subtareas       []*SubtaskResult `json:"subtareas,omitempty"`
 	FeatureKey      string           `json:"feature_key,omitempty"`
+	FeatureCreated  bool             `json:"feature_created,omitempty"`
 	CreatedIssueKey string           `json:"created_issue_key,omitempty"`
+	Warnings        []string         `json:"warnings,omitempty"`
+	WasCreated      bool             `json:"was_created"`
+	// RowHash es el ContentHash de la historia; --resume lo usa para reconocer las filas ya creadas
+	RowHash string `json:"row_hash,omitempty"`
+	// Resumed indica que la fila se tomo de una ejecucion anterior (--resume) sin volver a crearla
+	Resumed bool `json:"resumed,omitempty"`
 }
 
 type SubtaskResult struct {
@@ -40,6 +49,17 @@ func NewProcessResult(rowNumber int) *ProcessResult {
 	}
 }
 
+// SetFeature registra la Feature usada como parent y si fue creada en esta ejecucion
+func (pr *ProcessResult) SetFeature(featureKey string, created bool) {
+	pr.FeatureKey = featureKey
+	pr.FeatureCreated = created
+}
+
+// AddWarning registra un problema que no impide considerar la fila exitosa
+func (pr *ProcessResult) AddWarning(warning string) {
+	pr.Warnings = append(pr.Warnings, warning)
+}
+
 func (pr *ProcessResult) AddSubtaskResult(description string, success bool, issueKey, issueURL, errorMsg string) {
 	status := StatusSuccess
 	if !success {
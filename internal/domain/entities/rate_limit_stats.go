@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// RateLimitStats resume cuantas veces Jira limito la tasa de requests durante una ejecucion
+type RateLimitStats struct {
+	ThrottledResponses int           `json:"throttled_responses"`
+	RetryAfterWaits    int           `json:"retry_after_waits"`
+	RetryAfterTotal    time.Duration `json:"retry_after_total"`
+}
+
+func (rs *RateLimitStats) RecordThrottle() {
+	rs.ThrottledResponses++
+}
+
+func (rs *RateLimitStats) RecordRetryAfter(wait time.Duration) {
+	rs.RetryAfterWaits++
+	rs.RetryAfterTotal += wait
+}
+
+func (rs *RateLimitStats) HasEvents() bool {
+	return rs.ThrottledResponses > 0 || rs.RetryAfterWaits > 0
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitStats(t *testing.T) {
+	stats := RateLimitStats{}
+
+	if stats.HasEvents() {
+		t.Errorf("HasEvents() = true, want false for empty stats")
+	}
+
+	stats.RecordThrottle()
+	stats.RecordThrottle()
+	stats.RecordRetryAfter(2 * time.Second)
+	stats.RecordRetryAfter(500 * time.Millisecond)
+
+	if !stats.HasEvents() {
+		t.Errorf("HasEvents() = false, want true")
+	}
+	if stats.ThrottledResponses != 2 {
+		t.Errorf("ThrottledResponses = %v, want 2", stats.ThrottledResponses)
+	}
+	if stats.RetryAfterWaits != 2 {
+		t.Errorf("RetryAfterWaits = %v, want 2", stats.RetryAfterWaits)
+	}
+	if stats.RetryAfterTotal != 2500*time.Millisecond {
+		t.Errorf("RetryAfterTotal = %v, want 2.5s", stats.RetryAfterTotal)
+	}
+}
@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// ContentHash identifica la fila por titulo y descripcion, sin depender de su posicion: sirve
+// para reconocerla en un results.json anterior aunque el archivo se haya editado
+func (us *UserStory) ContentHash() string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(us.Titulo) + "\n" + strings.TrimSpace(us.Descripcion)))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "testing"
+
+func TestUserStory_ContentHash(t *testing.T) {
+	base := NewUserStory("Login", "Ingresar con email", "Criterio", "", "")
+
+	same := NewUserStory(" Login ", "Ingresar con email\n", "Otro criterio", "Subtarea", "PROJ-1")
+	if base.ContentHash() != same.ContentHash() {
+		t.Error("ContentHash should only depend on the trimmed title and description")
+	}
+
+	edited := NewUserStory("Login", "Ingresar con SSO", "Criterio", "", "")
+	if base.ContentHash() == edited.ContentHash() {
+		t.Error("ContentHash should change when the description changes")
+	}
+
+	// El separador evita que mover texto entre titulo y descripcion de el mismo hash
+	shifted := NewUserStory("Login Ingresar", "con email", "Criterio", "", "")
+	if base.ContentHash() == shifted.ContentHash() {
+		t.Error("ContentHash should distinguish title from description")
+	}
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// RunSummary resume una ejecucion sobre un archivo para el historial local
+type RunSummary struct {
+	Date           time.Time     `json:"date"`
+	FileName       string        `json:"file_name"`
+	TotalRows      int           `json:"total_rows"`
+	SuccessfulRows int           `json:"successful_rows"`
+	ErrorRows      int           `json:"error_rows"`
+	SuccessRate    float64       `json:"success_rate"`
+	Duration       time.Duration `json:"duration"`
+	DryRun         bool          `json:"dry_run"`
+}
+
+func NewRunSummary(result *BatchResult) *RunSummary {
+	return &RunSummary{
+		Date:           result.StartTime,
+		FileName:       result.FileName,
+		TotalRows:      result.TotalRows,
+		SuccessfulRows: result.SuccessfulRows,
+		ErrorRows:      result.ErrorRows,
+		SuccessRate:    result.GetSuccessRate(),
+		Duration:       result.Duration,
+		DryRun:         result.DryRun,
+	}
+}
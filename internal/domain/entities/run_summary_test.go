@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRunSummary(t *testing.T) {
+	batch := NewBatchResult("stories.csv", 4, false)
+	for i := 0; i < 4; i++ {
+		result := NewProcessResult(i + 2)
+		result.Success = i != 3
+		batch.AddResult(result)
+	}
+	batch.Finish()
+	batch.Duration = 2 * time.Second
+
+	summary := NewRunSummary(batch)
+
+	if summary.FileName != "stories.csv" {
+		t.Errorf("Expected FileName stories.csv, got %s", summary.FileName)
+	}
+	if summary.TotalRows != 4 || summary.SuccessfulRows != 3 || summary.ErrorRows != 1 {
+		t.Errorf("Expected 4/3/1 rows, got %d/%d/%d", summary.TotalRows, summary.SuccessfulRows, summary.ErrorRows)
+	}
+	if summary.SuccessRate != 75.0 {
+		t.Errorf("Expected SuccessRate 75, got %.1f", summary.SuccessRate)
+	}
+	if summary.Duration != 2*time.Second {
+		t.Errorf("Expected Duration 2s, got %v", summary.Duration)
+	}
+	if !summary.Date.Equal(batch.StartTime) {
+		t.Errorf("Expected Date to be the batch start time")
+	}
+}
@@ -1,9 +1,12 @@
 package entities
 
 import (
+	"regexp"
 	"strings"
 )
 
+var issueKeyPattern = regexp.MustCompile(`^[A-Z]+-\d+$`)
+
 type UserStory struct {
 	Titulo             string   `json:"titulo" validate:"required,min=1,max=255"`
 	Descripcion        string   `json:"descripcion" validate:"required,min=1"`
@@ -11,6 +14,14 @@ type UserStory struct {
 	Subtareas          []string `json:"subtareas,omitempty"`
 	Parent             string   `json:"parent,omitempty"`
 	Row                int      `json:"row,omitempty"`
+	SubtaskType        string   `json:"subtask_type,omitempty"`
+	Skip               bool     `json:"skip,omitempty"`
+	Environment        string   `json:"environment,omitempty"`
+	SubtasksFile       string   `json:"subtasks_file,omitempty"`
+	Assignee           string   `json:"assignee,omitempty"`
+	Labels             []string `json:"labels,omitempty"`
+	Prioridad          string   `json:"prioridad,omitempty"`
+	Links              []Link   `json:"links,omitempty"`
 }
 
 func NewUserStory(titulo, descripcion, criterioAceptacion string, subtareasRaw, parent string) *UserStory {
@@ -21,28 +32,26 @@ func NewUserStory(titulo, descripcion, criterioAceptacion string, subtareasRaw,
 		Parent:             parent,
 	}
 
-	story.parseSubtareas(subtareasRaw)
+	story.Subtareas = ParseSubtareas(subtareasRaw, DefaultSubtaskDelimiter)
 	return story
 }
 
-func (us *UserStory) parseSubtareas(subtareasRaw string) {
-	if subtareasRaw == "" {
-		return
-	}
+// DefaultSubtaskDelimiter separa las subtareas de la columna subtareas cuando no se configura otro
+const DefaultSubtaskDelimiter = ";"
 
+// ParseSubtareas separa la columna subtareas primero por delimiter (escapable con "\", ej: "\;")
+// y despues cada parte por saltos de linea: ambos separan subtareas, asi una celda puede mezclar
+// los dos. Sin subtareas no vacias devuelve nil
+func ParseSubtareas(raw, delimiter string) []string {
 	var tasks []string
-
-	for _, part := range strings.Split(subtareasRaw, ";") {
+	for _, part := range SplitMultiValue(raw, delimiter) {
 		for _, task := range strings.Split(part, "\n") {
 			if trimmed := strings.TrimSpace(task); trimmed != "" {
 				tasks = append(tasks, trimmed)
 			}
 		}
 	}
-
-	if len(tasks) > 0 {
-		us.Subtareas = tasks
-	}
+	return tasks
 }
 
 func (us *UserStory) HasSubtareas() bool {
@@ -53,6 +62,38 @@ func (us *UserStory) HasParent() bool {
 	return us.Parent != ""
 }
 
+// ParentIsIssueKey indica si el parent es una key de Jira existente y no la descripción de una Feature
+func (us *UserStory) ParentIsIssueKey() bool {
+	return issueKeyPattern.MatchString(us.Parent)
+}
+
+// ParentResolution replica PARENT_RESOLUTION: como se interpreta un parent con o sin forma de key
+type ParentResolution int
+
+const (
+	ParentResolutionKeyFirst ParentResolution = iota
+	ParentResolutionFeatureFirst
+	ParentResolutionKeyOnly
+	ParentResolutionFeatureOnly
+)
+
+// ParentSearchesFeature indica si resolver el parent busca una Feature por descripcion, lo que
+// requiere el tipo Feature; key_only nunca busca y key_first solo cuando el parent no es una key
+func (us *UserStory) ParentSearchesFeature(resolution ParentResolution) bool {
+	if !us.HasParent() {
+		return false
+	}
+
+	switch resolution {
+	case ParentResolutionKeyOnly:
+		return false
+	case ParentResolutionKeyFirst:
+		return !us.ParentIsIssueKey()
+	default:
+		return true
+	}
+}
+
 func (us *UserStory) GetValidSubtareas() []string {
 	var valid []string
 	for _, subtarea := range us.Subtareas {
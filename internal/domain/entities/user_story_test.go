@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -49,6 +50,17 @@ func TestNewUserStory(t *testing.T) {
 			wantHasSubtareas:   true,
 			wantHasParent:      false,
 		},
+		{
+			name:               "escaped semicolon in subtask",
+			titulo:             "Story with escaped separator",
+			descripcion:        "Test description",
+			criterioAceptacion: "Test criteria",
+			subtareasRaw:       `Task with \; semicolon;Task 2`,
+			parent:             "",
+			wantSubtareas:      []string{"Task with ; semicolon", "Task 2"},
+			wantHasSubtareas:   true,
+			wantHasParent:      false,
+		},
 		{
 			name:               "mixed separators",
 			titulo:             "Mixed separators story",
@@ -115,6 +127,25 @@ func TestNewUserStory(t *testing.T) {
 	}
 }
 
+func TestUserStory_ParentIsIssueKey(t *testing.T) {
+	tests := []struct {
+		parent string
+		want   bool
+	}{
+		{"PROJ-123", true},
+		{"Gestión de Usuarios", false},
+		{"proj-123", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		story := NewUserStory("Title", "Description", "Criteria", "", tt.parent)
+		if got := story.ParentIsIssueKey(); got != tt.want {
+			t.Errorf("ParentIsIssueKey(%q) = %v, want %v", tt.parent, got, tt.want)
+		}
+	}
+}
+
 func TestUserStory_GetValidSubtareas(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -225,3 +256,26 @@ func TestUserStory_ParseSubtareas(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSubtareas_Delimiter(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		delimiter string
+		want      []string
+	}{
+		{"pipe keeps semicolons", "Configurar DNS; TTL bajo|Deploy", "|", []string{"Configurar DNS; TTL bajo", "Deploy"}},
+		{"pipe escaped", `Opcion A \| B|Deploy`, "|", []string{"Opcion A | B", "Deploy"}},
+		{"pipe and newlines", "Task 1|Task 2\nTask 3", "|", []string{"Task 1", "Task 2", "Task 3"}},
+		{"tab", "Task 1\tTask; 2\t\tTask 3", "\t", []string{"Task 1", "Task; 2", "Task 3"}},
+		{"empty", " | \n ", "|", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSubtareas(tt.raw, tt.delimiter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSubtareas(%q, %q) = %q, want %q", tt.raw, tt.delimiter, got, tt.want)
+			}
+		})
+	}
+}
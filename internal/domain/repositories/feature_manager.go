@@ -9,4 +9,7 @@ type FeatureManager interface {
 	CreateOrGetFeature(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error)
 	SearchExistingFeature(ctx context.Context, description, projectKey string) (string, error)
 	ValidateFeatureRequiredFields(ctx context.Context, projectKey string) ([]string, error)
+	// ForgetFeature descarta la key recordada de una Feature que ya no existe (ej: eliminada por un
+	// rollback), para que la proxima fila que la refiera la busque o cree de nuevo
+	ForgetFeature(featureKey string)
 }
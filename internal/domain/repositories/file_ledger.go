@@ -0,0 +1,8 @@
+package repositories
+
+import "context"
+
+type FileLedger interface {
+	IsProcessed(ctx context.Context, hash string) (bool, error)
+	MarkProcessed(ctx context.Context, hash, filePath string) error
+}
@@ -2,12 +2,38 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"historiadorgo/internal/domain/entities"
+	"strings"
 )
 
+// ErrInputDirectoryNotFound indica que el directorio de entrada no existe
+var ErrInputDirectoryNotFound = errors.New("input directory does not exist")
+
+// EncodingUTF8 es la codificacion que se lee sin transcodificar
+const EncodingUTF8 = "UTF-8"
+
+// IsRemoteSource indica si el archivo es una URL http(s); se descarga para leerlo y no se mueve a procesados
+func IsRemoteSource(filePath string) bool {
+	lower := strings.ToLower(filePath)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
 type FileRepository interface {
 	ReadFile(ctx context.Context, filePath string) ([]*entities.UserStory, error)
 	ValidateFile(ctx context.Context, filePath string) error
 	MoveToProcessed(ctx context.Context, filePath string) error
 	GetPendingFiles(ctx context.Context, inputDir string) ([]string, error)
+	ComputeHash(ctx context.Context, filePath string) (string, error)
+	// DetectEncoding informa la codificacion original del archivo (vacio si no aplica)
+	DetectEncoding(ctx context.Context, filePath string) (string, error)
+	// Exists indica si el archivo local existe
+	Exists(ctx context.Context, filePath string) (bool, error)
+}
+
+// StoryStreamer es opcional: entrega las historias del archivo a medida que se leen, en el mismo
+// orden que ReadFile, sin cargarlo completo en memoria. Si fn devuelve un error la lectura se
+// corta y ReadFileStream lo devuelve
+type StoryStreamer interface {
+	ReadFileStream(ctx context.Context, filePath string, fn func(story *entities.UserStory) error) error
 }
@@ -2,15 +2,40 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"historiadorgo/internal/domain/entities"
 )
 
+// ErrIssueLimitReached indica que la ejecucion ya creo MAX_ISSUES_PER_RUN issues y no debe crear mas
+var ErrIssueLimitReached = errors.New("issue limit reached")
+
+// ErrIssueNotFound indica que el issue pedido no existe (o el usuario no puede verlo)
+var ErrIssueNotFound = errors.New("issue not found")
+
+// ErrPermissionDenied indica que Jira rechazo la operacion por falta de permisos en el proyecto
+var ErrPermissionDenied = errors.New("permission denied")
+
 type JiraRepository interface {
 	TestConnection(ctx context.Context) error
 	ValidateProject(ctx context.Context, projectKey string) error
 	ValidateSubtaskIssueType(ctx context.Context, projectKey string) error
+	ValidateStoryIssueType(ctx context.Context, projectKey string) error
 	ValidateFeatureIssueType(ctx context.Context) error
 	ValidateParentIssue(ctx context.Context, issueKey string) error
-	CreateUserStory(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error)
+	CreateUserStory(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error)
 	GetIssueTypes(ctx context.Context) ([]map[string]interface{}, error)
+	GetPriorities(ctx context.Context) ([]string, error)
+	CreateIssueLink(ctx context.Context, inwardKey, outwardKey, linkType string) error
+	DeleteIssue(ctx context.Context, issueKey string) error
+}
+
+// BulkCreateLimit es la cantidad maxima de issues que Jira acepta en un request de creacion masiva
+const BulkCreateLimit = 50
+
+// BulkStoryCreator crea varias historias, y despues sus subtareas, con pocos requests en lugar de
+// uno por issue. Devuelve un resultado por historia en el mismo orden; una historia rechazada solo
+// falla su fila. Las que no se enviaron por MAX_ISSUES_PER_RUN quedan en nil y el error es
+// ErrIssueLimitReached
+type BulkStoryCreator interface {
+	CreateUserStories(ctx context.Context, stories []*entities.UserStory, projectKey string, rowNumbers []int) ([]*entities.ProcessResult, error)
 }
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"historiadorgo/internal/domain/entities"
+)
+
+// ResultsStore guarda el resultado completo de cada archivo procesado como artefacto de auditoria
+type ResultsStore interface {
+	// Save escribe el lote y devuelve la ruta del archivo generado
+	Save(ctx context.Context, result *entities.BatchResult) (string, error)
+	// Load lee un results.json escrito por Save
+	Load(ctx context.Context, path string) (*entities.BatchResult, error)
+}
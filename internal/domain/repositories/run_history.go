@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"historiadorgo/internal/domain/entities"
+)
+
+type RunHistory interface {
+	Append(ctx context.Context, summary *entities.RunSummary) error
+	List(ctx context.Context) ([]*entities.RunSummary, error)
+}
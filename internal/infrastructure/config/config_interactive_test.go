@@ -362,6 +362,32 @@ func TestParseNumber(t *testing.T) {
 	}
 }
 
+func TestSelectFiles(t *testing.T) {
+	files := []string{"entrada/a.csv", "entrada/b.xlsx", "entrada/c.csv"}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"subset", "1,3\n", []string{"entrada/a.csv", "entrada/c.csv"}},
+		{"subset with spaces and duplicates", " 2 , 2 \n", []string{"entrada/b.xlsx"}},
+		{"default selects all", "\n", files},
+		{"todos selects all", "todos\n", files},
+		{"invalid then valid", "7\n2\n", []string{"entrada/b.xlsx"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SelectFiles(bufio.NewReader(strings.NewReader(tt.input)), files)
+
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("SelectFiles() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetAvailableIssueTypes(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -423,7 +449,7 @@ func TestGetAvailableIssueTypes(t *testing.T) {
 			}))
 			defer server.Close()
 
-			issueTypes, err := getAvailableIssueTypes(server.URL, "test@example.com", "token", "TEST")
+			issueTypes, err := getAvailableIssueTypes(server.URL, Credentials{Email: "test@example.com", APIToken: "token"}, "TEST")
 
 			if tt.wantError {
 				if err == nil {
@@ -591,7 +617,7 @@ func TestDetectAcceptanceCriteriaField_Interactive(t *testing.T) {
 			ctx := context.Background()
 			client := &http.Client{}
 
-			result, err := detectAcceptanceCriteriaField(ctx, client, server.URL, "test@example.com", "token", "TEST", "Story")
+			result, err := detectAcceptanceCriteriaField(ctx, client, server.URL, Credentials{Email: "test@example.com", APIToken: "token"}, "TEST", "Story")
 
 			if tt.wantError {
 				if err == nil {
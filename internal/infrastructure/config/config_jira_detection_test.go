@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 )
@@ -96,7 +97,7 @@ func TestDetectAcceptanceCriteriaField(t *testing.T) {
 			client := &http.Client{Timeout: 5 * time.Second}
 			ctx := context.Background()
 
-			field, err := detectAcceptanceCriteriaField(ctx, client, server.URL, "test@example.com", "token", "TEST", "Story")
+			field, err := detectAcceptanceCriteriaField(ctx, client, server.URL, Credentials{Email: "test@example.com", APIToken: "token"}, "TEST", "Story")
 
 			if tt.expectedError {
 				if err == nil {
@@ -114,6 +115,70 @@ func TestDetectAcceptanceCriteriaField(t *testing.T) {
 	}
 }
 
+func TestDetectEpicLinkField(t *testing.T) {
+	tests := []struct {
+		name          string
+		responseBody  string
+		statusCode    int
+		expectedField string
+		expectedError bool
+	}{
+		{
+			name:       "by schema type",
+			statusCode: 200,
+			responseBody: `[
+				{"id": "customfield_10020", "name": "Epic Link (legacy)", "custom": true, "schema": {"custom": "com.atlassian.jira.plugin.system.customfieldtypes:textfield"}},
+				{"id": "customfield_10014", "name": "Vínculo de épica", "custom": true, "schema": {"custom": "com.pyxis.greenhopper.jira:gh-epic-link"}}
+			]`,
+			expectedField: "customfield_10014",
+		},
+		{
+			name:          "by name without schema",
+			statusCode:    200,
+			responseBody:  `[{"id": "summary", "name": "Summary"}, {"id": "customfield_10008", "name": "Epic Link"}]`,
+			expectedField: "customfield_10008",
+		},
+		{
+			name:          "team-managed only instance",
+			statusCode:    200,
+			responseBody:  `[{"id": "parent", "name": "Parent"}, {"id": "customfield_10147", "name": "Acceptance Criteria"}]`,
+			expectedError: true,
+		},
+		{
+			name:          "HTTP error",
+			statusCode:    401,
+			responseBody:  `{"error": "Unauthorized"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			client := &http.Client{Timeout: 5 * time.Second}
+			field, err := detectEpicLinkField(context.Background(), client, server.URL, Credentials{Email: "test@example.com", APIToken: "token"})
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got field %q", field)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if field != tt.expectedField {
+				t.Errorf("expected field %q, got %q", tt.expectedField, field)
+			}
+		})
+	}
+}
+
 func TestDetectFeatureRequiredFields(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -187,7 +252,7 @@ func TestDetectFeatureRequiredFields(t *testing.T) {
 			client := &http.Client{Timeout: 5 * time.Second}
 			ctx := context.Background()
 
-			fields, err := detectFeatureRequiredFields(ctx, client, server.URL, "test@example.com", "token", "TEST", "Feature")
+			fields, err := detectFeatureRequiredFields(ctx, client, server.URL, Credentials{Email: "test@example.com", APIToken: "token"}, "TEST", "Feature")
 
 			if tt.expectedError {
 				if err == nil {
@@ -222,6 +287,12 @@ func TestDetectJiraConfiguration(t *testing.T) {
 					"name": "Summary",
 					"description": "Summary field",
 					"custom": false
+				},
+				{
+					"id": "customfield_10014",
+					"name": "Epic Link",
+					"custom": true,
+					"schema": {"type": "any", "custom": "com.pyxis.greenhopper.jira:gh-epic-link"}
 				}
 			]`))
 		} else if r.URL.Query().Get("issuetypeNames") == "Feature" {
@@ -246,7 +317,7 @@ func TestDetectJiraConfiguration(t *testing.T) {
 	}))
 	defer server.Close()
 
-	config, err := DetectJiraConfiguration(server.URL, "test@example.com", "token", "TEST", "Story", "Feature")
+	config, err := DetectJiraConfiguration(server.URL, Credentials{Email: "test@example.com", APIToken: "token"}, "TEST", "Story", "Feature")
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -256,8 +327,47 @@ func TestDetectJiraConfiguration(t *testing.T) {
 		t.Errorf("expected acceptance criteria field %q, got %q", "customfield_10147", config.AcceptanceCriteriaField)
 	}
 
+	if config.EpicLinkField != "customfield_10014" {
+		t.Errorf("expected epic link field %q, got %q", "customfield_10014", config.EpicLinkField)
+	}
+
 	expectedFeatureFields := `{"customfield_11493":{"id":"54672"}}`
 	if config.FeatureRequiredFields != expectedFeatureFields {
 		t.Errorf("expected feature fields %q, got %q", expectedFeatureFields, config.FeatureRequiredFields)
 	}
 }
+
+func TestDetectJiraConfiguration_MetadataTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/3/field" {
+			w.Write([]byte(`[{"id": "customfield_10147", "name": "Acceptance Criteria", "custom": true}]`))
+			return
+		}
+		// createmeta lento
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("METADATA_TIMEOUT_SECONDS", "1")
+	defer os.Unsetenv("METADATA_TIMEOUT_SECONDS")
+
+	start := time.Now()
+	config, err := DetectJiraConfiguration(server.URL, Credentials{Email: "test@example.com", APIToken: "token"}, "TEST", "Story", "Feature")
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.AcceptanceCriteriaField != "customfield_10147" {
+		t.Errorf("expected acceptance criteria field to be detected, got %q", config.AcceptanceCriteriaField)
+	}
+	if config.FeatureRequiredFields != "" {
+		t.Errorf("expected no feature fields after createmeta timeout, got %q", config.FeatureRequiredFields)
+	}
+	if elapsed > 3*time.Second {
+		t.Errorf("expected createmeta to time out after ~1s, took %v", elapsed)
+	}
+}
@@ -21,6 +21,7 @@ func TestCreateInteractiveEnvFile_MockedInput(t *testing.T) {
 			name: "valid_complete_input_with_project",
 			input: strings.Join([]string{
 				"https://company.atlassian.net", // JIRA_URL
+				"",                              // AUTH_METHOD (default basic)
 				"user@company.com",              // JIRA_EMAIL
 				"token123",                      // JIRA_API_TOKEN
 				"MYPROJ",                        // PROJECT_KEY
@@ -41,6 +42,7 @@ func TestCreateInteractiveEnvFile_MockedInput(t *testing.T) {
 			name: "valid_minimal_input_no_project",
 			input: strings.Join([]string{
 				"https://company.atlassian.net", // JIRA_URL
+				"",                              // AUTH_METHOD (default basic)
 				"user@company.com",              // JIRA_EMAIL
 				"token123",                      // JIRA_API_TOKEN
 				"",                              // PROJECT_KEY (empty)
@@ -72,6 +74,7 @@ func TestCreateInteractiveEnvFile_MockedInput(t *testing.T) {
 			name: "missing_jira_email",
 			input: strings.Join([]string{
 				"https://company.atlassian.net", // JIRA_URL
+				"",                              // AUTH_METHOD (default basic)
 				"",                              // JIRA_EMAIL (empty - should error)
 				"token123",                      // JIRA_API_TOKEN
 				"",                              // End of input
@@ -83,6 +86,7 @@ func TestCreateInteractiveEnvFile_MockedInput(t *testing.T) {
 			name: "missing_jira_token",
 			input: strings.Join([]string{
 				"https://company.atlassian.net", // JIRA_URL
+				"",                              // AUTH_METHOD (default basic)
 				"user@company.com",              // JIRA_EMAIL
 				"",                              // JIRA_API_TOKEN (empty - should error)
 				"",                              // End of input
@@ -254,6 +258,7 @@ func TestCreateInteractiveEnvFile_FileOperations(t *testing.T) {
 
 		input := strings.Join([]string{
 			"https://company.atlassian.net",
+			"",
 			"user@company.com",
 			"token123",
 			"", // No project key
@@ -284,3 +289,115 @@ func TestCreateInteractiveEnvFile_FileOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateInteractiveEnvFile_BearerAuth(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	defer func() {
+		os.Stdin = oldStdin
+		r.Close()
+		w.Close()
+	}()
+
+	// Con bearer no se pide email: el token es la respuesta siguiente al metodo
+	input := strings.Join([]string{
+		"https://jira.company.com", // JIRA_URL
+		"bearer",                   // AUTH_METHOD
+		"pat123",                   // JIRA_PAT
+		"",                         // CA_CERT_PATH (certificado publico)
+		"",                         // TLS_INSECURE_SKIP_VERIFY (default n)
+		"",                         // PROJECT_KEY (empty)
+		"Story",                    // DEFAULT_ISSUE_TYPE
+		"Sub-task",                 // SUBTASK_ISSUE_TYPE
+		"Epic",                     // FEATURE_ISSUE_TYPE
+		"entrada",                  // INPUT_DIRECTORY
+		"logs",                     // LOGS_DIRECTORY
+		"procesados",               // PROCESSED_DIRECTORY
+		"n",                        // ROLLBACK_ON_SUBTASK_FAILURE
+		"",                         // ACCEPTANCE_CRITERIA_FIELD
+	}, "\n") + "\n"
+
+	go func() {
+		defer w.Close()
+		io.WriteString(w, input)
+	}()
+
+	if err := CreateInteractiveEnvFile(); err != nil {
+		t.Fatalf("CreateInteractiveEnvFile() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, ".env"))
+	if err != nil {
+		t.Fatalf("could not read .env file: %v", err)
+	}
+	envContent := string(content)
+
+	for _, line := range []string{"AUTH_METHOD=bearer\n", "JIRA_PAT=pat123\n", "DEFAULT_ISSUE_TYPE=Story\n"} {
+		if !strings.Contains(envContent, line) {
+			t.Errorf(".env missing %q:\n%s", line, envContent)
+		}
+	}
+	if strings.Contains(envContent, "JIRA_EMAIL=") || strings.Contains(envContent, "JIRA_API_TOKEN=") {
+		t.Errorf(".env should not include basic auth settings with bearer:\n%s", envContent)
+	}
+}
+
+func TestCreateInteractiveEnvFile_InsecureTLS(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+	t.Cleanup(func() { setupTransport = nil })
+
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	defer func() {
+		os.Stdin = oldStdin
+		r.Close()
+		w.Close()
+	}()
+
+	input := strings.Join([]string{
+		"https://jira.company.com", // JIRA_URL
+		"bearer",                   // AUTH_METHOD
+		"pat123",                   // JIRA_PAT
+		"",                         // CA_CERT_PATH (sin CA interna)
+		"y",                        // TLS_INSECURE_SKIP_VERIFY
+		"",                         // PROJECT_KEY (empty)
+		"Story",                    // DEFAULT_ISSUE_TYPE
+		"Sub-task",                 // SUBTASK_ISSUE_TYPE
+		"Epic",                     // FEATURE_ISSUE_TYPE
+		"entrada",                  // INPUT_DIRECTORY
+		"logs",                     // LOGS_DIRECTORY
+		"procesados",               // PROCESSED_DIRECTORY
+		"n",                        // ROLLBACK_ON_SUBTASK_FAILURE
+		"",                         // ACCEPTANCE_CRITERIA_FIELD
+	}, "\n") + "\n"
+
+	go func() {
+		defer w.Close()
+		io.WriteString(w, input)
+	}()
+
+	if err := CreateInteractiveEnvFile(); err != nil {
+		t.Fatalf("CreateInteractiveEnvFile() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, ".env"))
+	if err != nil {
+		t.Fatalf("could not read .env file: %v", err)
+	}
+	if !strings.Contains(string(content), "TLS_INSECURE_SKIP_VERIFY=true\n") {
+		t.Errorf(".env missing TLS_INSECURE_SKIP_VERIFY=true:\n%s", content)
+	}
+	if setupTransport == nil {
+		t.Error("Expected the setup requests to use the insecure TLS transport")
+	}
+}
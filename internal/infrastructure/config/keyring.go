@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Token sources accepted by JIRA_TOKEN_SOURCE
+const (
+	TokenSourceEnv     = "env"
+	TokenSourceKeyring = "keyring"
+)
+
+// DefaultKeyringService is the keyring service the API token is stored under
+const DefaultKeyringService = "historiador"
+
+// Keyring reads secrets stored in the operating system keychain
+type Keyring interface {
+	Get(service, account string) (string, error)
+}
+
+// tokenKeyring is the backend used for JIRA_TOKEN_SOURCE=keyring; tests replace it with a fake
+var tokenKeyring Keyring = commandKeyring{}
+
+// commandKeyring queries the OS keychain through its CLI: security on macOS, secret-tool on Linux
+type commandKeyring struct{}
+
+func (commandKeyring) Get(service, account string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", service, "username", account)
+	default:
+		return "", fmt.Errorf("keyring not supported on %s", runtime.GOOS)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("keyring lookup for service '%s' and account '%s' failed: %w", service, account, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// setupTransport is used by the interactive setup requests; it carries the CA or insecure mode
+// chosen during setup so issue type detection works against Jira with an internal CA
+var setupTransport http.RoundTripper
+
+// TLSConfig returns the TLS settings for requests to Jira: the system roots plus CA_CERT_PATH,
+// or no certificate verification with TLS_INSECURE_SKIP_VERIFY. It returns nil when neither is set
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	return buildTLSConfig(c.CACertPath, c.TLSInsecureSkipVerify)
+}
+
+func buildTLSConfig(caCertPath string, insecure bool) (*tls.Config, error) {
+	if caCertPath == "" && !insecure {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if insecure {
+		tlsConfig.InsecureSkipVerify = true
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA_CERT_PATH '%s': %w", caCertPath, err)
+	}
+
+	// The internal CA is added to the system roots so Atlassian Cloud keeps working
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("invalid CA_CERT_PATH '%s': no PEM certificates found", caCertPath)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
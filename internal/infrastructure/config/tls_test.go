@@ -0,0 +1,81 @@
+package config
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeServerCA guarda en PEM el certificado autofirmado de un servidor httptest TLS
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write CA: %v", err)
+	}
+	return path
+}
+
+func TestConfig_TLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caPath := writeServerCA(t, server)
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.pem")
+	os.WriteFile(invalidPath, []byte("not a certificate"), 0600)
+
+	tests := []struct {
+		name         string
+		config       *Config
+		wantNil      bool
+		wantInsecure bool
+		wantRoots    bool
+		wantErr      bool
+	}{
+		{name: "public_certificates", config: &Config{}, wantNil: true},
+		{name: "custom_ca", config: &Config{CACertPath: caPath}, wantRoots: true},
+		{name: "insecure", config: &Config{TLSInsecureSkipVerify: true}, wantInsecure: true},
+		{name: "missing_file", config: &Config{CACertPath: filepath.Join(t.TempDir(), "missing.pem")}, wantErr: true},
+		{name: "not_pem", config: &Config{CACertPath: invalidPath}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := tt.config.TLSConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TLSConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (tlsConfig == nil) != tt.wantNil {
+				t.Fatalf("TLSConfig() = %v, want nil: %v", tlsConfig, tt.wantNil)
+			}
+			if tlsConfig == nil {
+				return
+			}
+			if tlsConfig.InsecureSkipVerify != tt.wantInsecure {
+				t.Errorf("InsecureSkipVerify = %v, want %v", tlsConfig.InsecureSkipVerify, tt.wantInsecure)
+			}
+			if (tlsConfig.RootCAs != nil) != tt.wantRoots {
+				t.Errorf("RootCAs set = %v, want %v", tlsConfig.RootCAs != nil, tt.wantRoots)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_CACertPath(t *testing.T) {
+	config := &Config{
+		JiraURL:      "https://jira.company.com",
+		JiraEmail:    "test@example.com",
+		JiraAPIToken: "test-token",
+		CACertPath:   filepath.Join(t.TempDir(), "missing.pem"),
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("Validate() error = nil, want an error for an unreadable CA_CERT_PATH")
+	}
+}
@@ -0,0 +1,111 @@
+package filesystem
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// columnNames son las columnas que reconoce el lector, con el nombre de su tag en CSVRecord
+var columnNames = []string{
+	"titulo", "descripcion", "subtareas", "criterio_aceptacion", "parent", "subtask_type",
+	"skip", "environment", "subtasks_file", "asignado", "labels", "prioridad",
+	"enlaces",
+}
+
+// defaultColumnAliases son los encabezados aceptados, ademas del nombre, para algunas columnas
+var defaultColumnAliases = map[string][]string{
+	"asignado":  {"assignee"},
+	"enlaces":   {"links"},
+	"labels":    {"etiquetas"},
+	"prioridad": {"priority"},
+}
+
+// normalizeHeader compara encabezados sin distinguir mayusculas ni espacios en los extremos
+func normalizeHeader(header string) string {
+	return strings.ToLower(strings.TrimSpace(header))
+}
+
+// buildColumnNames arma el indice de encabezado normalizado a columna con los nombres, los alias
+// por defecto y los configurados, que se suman a los anteriores. Falla si un alias refiere a una
+// columna desconocida o si un encabezado quedaria asignado a dos columnas
+func buildColumnNames(aliases map[string][]string) (map[string]string, error) {
+	names := make(map[string]string)
+	add := func(column, header string) error {
+		key := normalizeHeader(header)
+		if key == "" {
+			return nil
+		}
+		if existing, ok := names[key]; ok && existing != column {
+			return fmt.Errorf("header %q is mapped to both %s and %s", header, existing, column)
+		}
+		names[key] = column
+		return nil
+	}
+
+	for _, column := range columnNames {
+		add(column, column)
+		for _, alias := range defaultColumnAliases[column] {
+			add(column, alias)
+		}
+	}
+
+	configured := make([]string, 0, len(aliases))
+	for column := range aliases {
+		configured = append(configured, column)
+	}
+	sort.Strings(configured)
+
+	for _, configuredColumn := range configured {
+		column := normalizeHeader(configuredColumn)
+		if !isColumnName(column) {
+			return nil, fmt.Errorf("unknown column %q (supported: %s)", configuredColumn, strings.Join(columnNames, ", "))
+		}
+		for _, alias := range aliases[configuredColumn] {
+			if err := add(column, alias); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return names, nil
+}
+
+func isColumnName(name string) bool {
+	for _, column := range columnNames {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetColumnAliases agrega encabezados aceptados por columna (COLUMN_ALIASES), ej: "titulo":
+// ["title", "summary"]; los nombres y alias por defecto se siguen reconociendo
+func (fp *FileProcessor) SetColumnAliases(aliases map[string][]string) error {
+	names, err := buildColumnNames(aliases)
+	if err != nil {
+		return err
+	}
+	fp.columnNames = names
+	return nil
+}
+
+// columnName devuelve la columna que corresponde a un encabezado del archivo
+func (fp *FileProcessor) columnName(header string) (string, bool) {
+	name, ok := fp.columnNames[normalizeHeader(header)]
+	return name, ok
+}
+
+// canonicalHeader traduce los encabezados reconocidos al nombre de su columna; los demas quedan igual
+func (fp *FileProcessor) canonicalHeader(header []string) []string {
+	canonical := make([]string, len(header))
+	for i, col := range header {
+		if name, ok := fp.columnName(col); ok {
+			canonical[i] = name
+			continue
+		}
+		canonical[i] = col
+	}
+	return canonical
+}
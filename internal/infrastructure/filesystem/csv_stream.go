@@ -0,0 +1,150 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
+
+	"github.com/gocarina/gocsv"
+)
+
+// errStreamStopped corta la lectura de un CSV cuando el callback devolvio un error
+var errStreamStopped = errors.New("csv stream stopped")
+
+// ReadFileStream pasa a fn las historias del archivo a medida que se leen, en el mismo orden y
+// con las mismas reglas que ReadFile. Los CSV locales se leen de a una fila sin cargarlos en
+// memoria; el resto de los formatos se leen completos y luego se recorren. Si fn devuelve un
+// error la lectura se corta y ReadFileStream lo devuelve
+func (fp *FileProcessor) ReadFileStream(ctx context.Context, filePath string, fn func(story *entities.UserStory) error) error {
+	if repositories.IsRemoteSource(filePath) || strings.ToLower(filepath.Ext(filePath)) != csvExtension {
+		stories, err := fp.ReadFile(ctx, filePath)
+		if err != nil {
+			return err
+		}
+		for _, story := range stories {
+			if err := fn(story); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	baseDir := filepath.Dir(filePath)
+	return fp.streamCSV(filePath, func(story *entities.UserStory) error {
+		if err := fp.attachSubtasksFiles([]*entities.UserStory{story}, baseDir); err != nil {
+			return err
+		}
+		return fn(story)
+	})
+}
+
+// streamCSV lee el CSV de a una fila y pasa a fn cada historia. Si hay que detectar la
+// codificacion el archivo se recorre una vez antes, pero nunca se guarda completo en memoria
+func (fp *FileProcessor) streamCSV(filePath string, fn func(story *entities.UserStory) error) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error opening CSV file: %w", err)
+	}
+	defer file.Close()
+
+	encoding, err := resolveReaderEncoding(file, fp.inputEncoding)
+	if err != nil {
+		return fmt.Errorf("error opening CSV file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error opening CSV file: %w", err)
+	}
+
+	reader := csv.NewReader(decodingReader(file, encoding))
+	if fp.commentChar != 0 {
+		reader.Comment = fp.commentChar
+	}
+	rows := &csvRowReader{reader: reader, header: fp.canonicalHeader}
+
+	records := make(chan *CSVRecord)
+	parsed := make(chan error, 1)
+	go func() {
+		parsed <- gocsv.UnmarshalDecoderToChan(rows, records)
+	}()
+
+	var fnErr error
+	row := 1
+	for record := range records {
+		row++
+		// Despues de un error se vacia el canal para que gocsv termine
+		if fnErr != nil {
+			continue
+		}
+		story, err := fp.storyFromRecord(record)
+		switch {
+		case err != nil:
+			fnErr = fmt.Errorf("validation error in row %d: %w", row, err)
+		case story != nil:
+			fnErr = fn(story)
+		}
+		if fnErr != nil {
+			rows.stopped.Store(true)
+		}
+	}
+
+	err = <-parsed
+	if fnErr != nil {
+		return fnErr
+	}
+	// gocsv solo devuelve io.EOF cuando no hay ni siquiera header
+	if err == io.EOF {
+		err = gocsv.ErrEmptyCSVFile
+	}
+	if err != nil {
+		return fmt.Errorf("error parsing CSV: %w", err)
+	}
+	return nil
+}
+
+// csvRowReader entrega a gocsv las filas de un csv.Reader de a una, con el header traducido
+// por canonicalHeader. Con stopped la proxima fila devuelve errStreamStopped
+type csvRowReader struct {
+	reader     *csv.Reader
+	header     func(header []string) []string
+	headerRead bool
+	stopped    atomic.Bool
+}
+
+func (r *csvRowReader) GetCSVRow() ([]string, error) {
+	if r.stopped.Load() {
+		return nil, errStreamStopped
+	}
+
+	row, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if !r.headerRead {
+		r.headerRead = true
+		row = r.header(row)
+	}
+	return row, nil
+}
+
+func (r *csvRowReader) GetCSVRows() ([][]string, error) {
+	var rows [][]string
+	for {
+		row, err := r.GetCSVRow()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
@@ -0,0 +1,141 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// writeLargeCSV genera un CSV con rows historias y, si tail no es vacio, lo agrega al final
+func writeLargeCSV(t *testing.T, path string, rows int, tail string) {
+	t.Helper()
+
+	var content strings.Builder
+	content.WriteString("titulo,descripcion,criterio_aceptacion,subtareas\n")
+	for i := 1; i <= rows; i++ {
+		fmt.Fprintf(&content, "Historia %d,Descripcion %d,Criterio %d,Tarea A;Tarea B\n", i, i, i)
+	}
+	content.WriteString(tail)
+
+	if err := os.WriteFile(path, []byte(content.String()), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+func TestFileProcessor_ReadFileStream_LargeFile(t *testing.T) {
+	const rows = 20000
+	filePath := filepath.Join(t.TempDir(), "grande.csv")
+	writeLargeCSV(t, filePath, rows, "")
+
+	fp := NewFileProcessor(t.TempDir())
+
+	count := 0
+	err := fp.ReadFileStream(context.Background(), filePath, func(story *entities.UserStory) error {
+		count++
+		if want := fmt.Sprintf("Historia %d", count); story.Titulo != want {
+			return fmt.Errorf("story %d = %q, want %q", count, story.Titulo, want)
+		}
+		if len(story.Subtareas) != 2 {
+			return fmt.Errorf("story %d has %d subtasks, want 2", count, len(story.Subtareas))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadFileStream() error = %v", err)
+	}
+	if count != rows {
+		t.Errorf("Callback called %d times, want %d", count, rows)
+	}
+}
+
+func TestFileProcessor_ReadFileStream_StopsReading(t *testing.T) {
+	// La fila mal formada del final solo se leeria si el archivo se cargara completo
+	filePath := filepath.Join(t.TempDir(), "grande.csv")
+	writeLargeCSV(t, filePath, 20000, "Rota,\"sin cerrar\n")
+
+	fp := NewFileProcessor(t.TempDir())
+	if _, err := fp.ReadFile(context.Background(), filePath); err == nil || !strings.Contains(err.Error(), "error parsing CSV") {
+		t.Fatalf("ReadFile() error = %v, want a parse error for the last row", err)
+	}
+
+	stop := errors.New("stop")
+	count := 0
+	err := fp.ReadFileStream(context.Background(), filePath, func(story *entities.UserStory) error {
+		count++
+		if count == 100 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("ReadFileStream() error = %v, want the callback error", err)
+	}
+	if count != 100 {
+		t.Errorf("Callback called %d times after stopping, want 100", count)
+	}
+}
+
+func TestFileProcessor_ReadFileStream_MatchesReadFile(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "tareas.csv"), []byte("subtarea\nDesde archivo\n"), 0644); err != nil {
+		t.Fatalf("Failed to write subtasks file: %v", err)
+	}
+
+	files := map[string]string{
+		"historias.csv": "titulo,descripcion,criterio_aceptacion,subtareas,skip,subtasks_file\n" +
+			"Login,Desc,Crit,Tarea 1,,tareas.csv\n" +
+			",Sin titulo,Crit,,,\n" +
+			"Borrador,,,,x,\n",
+		"historias.json": `[{"titulo": "Login", "descripcion": "Desc", "criterio_aceptacion": "Crit"}]`,
+	}
+
+	fp := NewFileProcessor(tempDir)
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, name)
+			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			want, err := fp.ReadFile(context.Background(), filePath)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+
+			var got []*entities.UserStory
+			err = fp.ReadFileStream(context.Background(), filePath, func(story *entities.UserStory) error {
+				got = append(got, story)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ReadFileStream() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ReadFileStream() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestFileProcessor_ReadFileStream_EmptyFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "vacio.csv")
+	if err := os.WriteFile(filePath, nil, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	fp := NewFileProcessor(t.TempDir())
+	err := fp.ReadFileStream(context.Background(), filePath, func(story *entities.UserStory) error {
+		t.Errorf("Unexpected story %+v", story)
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "error parsing CSV") {
+		t.Errorf("ReadFileStream() error = %v, want an empty file error", err)
+	}
+}
@@ -0,0 +1,281 @@
+package filesystem
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"historiadorgo/internal/domain/repositories"
+)
+
+// Codificaciones detectadas en archivos CSV
+const (
+	EncodingUTF8        = repositories.EncodingUTF8
+	EncodingUTF16LE     = "UTF-16LE"
+	EncodingUTF16BE     = "UTF-16BE"
+	EncodingLatin1      = "ISO-8859-1"
+	EncodingWindows1252 = "windows-1252"
+)
+
+// encodingNames son los valores aceptados por SetInputEncoding (sin distinguir mayusculas, "-" ni "_")
+var encodingNames = map[string]string{
+	"utf8":        EncodingUTF8,
+	"latin1":      EncodingLatin1,
+	"iso88591":    EncodingLatin1,
+	"windows1252": EncodingWindows1252,
+	"cp1252":      EncodingWindows1252,
+}
+
+// windows1252C1 son los caracteres de windows-1252 en 0x80-0x9F, donde ISO-8859-1 tiene
+// controles; los cinco bytes sin definir conservan su valor, como hace Windows
+var windows1252C1 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// DetectEncoding informa la codificacion de un CSV local; vacio para hojas de calculo y URLs,
+// que no se transcodifican
+func (fp *FileProcessor) DetectEncoding(ctx context.Context, filePath string) (string, error) {
+	if repositories.IsRemoteSource(filePath) || strings.ToLower(filepath.Ext(filePath)) != csvExtension {
+		return "", nil
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading file: %w", err)
+	}
+	defer file.Close()
+
+	encoding, err := resolveReaderEncoding(file, fp.inputEncoding)
+	if err != nil {
+		return "", fmt.Errorf("error reading file: %w", err)
+	}
+	return encoding, nil
+}
+
+// SetInputEncoding fija la codificacion de los archivos sin BOM (INPUT_ENCODING): utf-8, latin1
+// (iso-8859-1) o windows-1252 (cp1252). Vacio o "auto" la detecta
+func (fp *FileProcessor) SetInputEncoding(encoding string) error {
+	key := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(encoding)))
+	if key == "" || key == "auto" {
+		fp.inputEncoding = ""
+		return nil
+	}
+
+	name, ok := encodingNames[key]
+	if !ok {
+		return fmt.Errorf("unsupported encoding %q (supported: utf-8, latin1, windows-1252)", encoding)
+	}
+	fp.inputEncoding = name
+	return nil
+}
+
+// resolveEncoding devuelve la codificacion del contenido: un BOM siempre se respeta; sin BOM
+// manda override y, si esta vacio, la deteccion
+func resolveEncoding(data []byte, override string) string {
+	encoding, _ := resolveReaderEncoding(bytes.NewReader(data), override)
+	return encoding
+}
+
+// resolveReaderEncoding es resolveEncoding sobre un reader: la deteccion lo recorre una vez
+// sin guardar el contenido, asi sirve para archivos grandes
+func resolveReaderEncoding(r io.Reader, override string) (string, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(4)
+	if override != "" && !hasBOM(head) {
+		return override, nil
+	}
+	return detectEncoding(br)
+}
+
+func hasBOM(data []byte) bool {
+	return bytes.HasPrefix(data, utf8BOM) || bytes.HasPrefix(data, utf16LEBOM) || bytes.HasPrefix(data, utf16BEBOM)
+}
+
+// detectEncoding reconoce UTF-16 por BOM (o por bytes nulos alternados). Si el contenido no es
+// UTF-8 valido usa windows-1252 cuando hay bytes en 0x80-0x9F (comillas tipograficas, guiones o
+// el euro de Excel en Windows, que en Latin-1 serian controles) y Latin-1 si no
+func detectEncoding(br *bufio.Reader) (string, error) {
+	head, _ := br.Peek(4)
+	switch {
+	case bytes.HasPrefix(head, utf8BOM):
+		return EncodingUTF8, nil
+	case bytes.HasPrefix(head, utf16LEBOM):
+		return EncodingUTF16LE, nil
+	case bytes.HasPrefix(head, utf16BEBOM):
+		return EncodingUTF16BE, nil
+	}
+
+	if len(head) == 4 {
+		if head[0] != 0 && head[1] == 0 && head[2] != 0 && head[3] == 0 {
+			return EncodingUTF16LE, nil
+		}
+		if head[0] == 0 && head[1] != 0 && head[2] == 0 && head[3] != 0 {
+			return EncodingUTF16BE, nil
+		}
+	}
+
+	valid, c1 := true, false
+	var encoded [utf8.UTFMax]byte
+	for {
+		r, size, err := br.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if r == utf8.RuneError && size == 1 {
+			valid = false
+			br.UnreadRune()
+			b, _ := br.ReadByte()
+			c1 = c1 || isC1(b)
+			continue
+		}
+		// Los bytes de continuacion de UTF-8 tambien cuentan si el archivo resulta no serlo
+		n := utf8.EncodeRune(encoded[:], r)
+		for _, b := range encoded[1:n] {
+			c1 = c1 || isC1(b)
+		}
+	}
+
+	switch {
+	case valid:
+		return EncodingUTF8, nil
+	case c1:
+		return EncodingWindows1252, nil
+	default:
+		return EncodingLatin1, nil
+	}
+}
+
+func isC1(b byte) bool {
+	return b >= 0x80 && b <= 0x9F
+}
+
+// decodeToUTF8 convierte el contenido a UTF-8 sin BOM segun la codificacion detectada o la
+// fijada en encoding (vacio detecta, ver resolveEncoding)
+func decodeToUTF8(data []byte, encoding string) []byte {
+	decoded, _ := io.ReadAll(decodingReader(bytes.NewReader(data), resolveEncoding(data, encoding)))
+	return decoded
+}
+
+// decodingReader devuelve el contenido de r convertido de encoding a UTF-8, sin BOM, a medida
+// que se lee
+func decodingReader(r io.Reader, encoding string) io.Reader {
+	br := bufio.NewReader(r)
+	switch encoding {
+	case EncodingUTF16LE:
+		skipPrefix(br, utf16LEBOM)
+		return &runeDecoder{next: utf16Runes(br, false)}
+	case EncodingUTF16BE:
+		skipPrefix(br, utf16BEBOM)
+		return &runeDecoder{next: utf16Runes(br, true)}
+	case EncodingLatin1, EncodingWindows1252:
+		windows1252 := encoding == EncodingWindows1252
+		return &runeDecoder{next: func() (rune, error) {
+			b, err := br.ReadByte()
+			if err != nil {
+				return 0, err
+			}
+			// En ISO-8859-1 cada byte es el code point del mismo valor; windows-1252 solo
+			// difiere en 0x80-0x9F
+			if windows1252 && isC1(b) {
+				return windows1252C1[b-0x80], nil
+			}
+			return rune(b), nil
+		}}
+	default:
+		skipPrefix(br, utf8BOM)
+		return br
+	}
+}
+
+func skipPrefix(br *bufio.Reader, prefix []byte) {
+	if head, _ := br.Peek(len(prefix)); bytes.Equal(head, prefix) {
+		br.Discard(len(prefix))
+	}
+}
+
+// utf16Runes devuelve los caracteres de un contenido UTF-16 de a uno; un surrogate sin par es
+// U+FFFD y un byte suelto al final se descarta, igual que utf16.Decode
+func utf16Runes(br *bufio.Reader, bigEndian bool) func() (rune, error) {
+	var unit [2]byte
+	readUnit := func() (rune, error) {
+		if _, err := io.ReadFull(br, unit[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return 0, err
+		}
+		if bigEndian {
+			return rune(unit[0])<<8 | rune(unit[1]), nil
+		}
+		return rune(unit[1])<<8 | rune(unit[0]), nil
+	}
+
+	held := rune(-1)
+	return func() (rune, error) {
+		r := held
+		held = -1
+		if r < 0 {
+			var err error
+			if r, err = readUnit(); err != nil {
+				return 0, err
+			}
+		}
+		if !utf16.IsSurrogate(r) {
+			return r, nil
+		}
+
+		next, err := readUnit()
+		if err != nil {
+			return utf8.RuneError, nil
+		}
+		if decoded := utf16.DecodeRune(r, next); decoded != utf8.RuneError {
+			return decoded, nil
+		}
+		// next no completa el par: se decodifica por separado en la proxima llamada
+		held = next
+		return utf8.RuneError, nil
+	}
+}
+
+// runeDecoder adapta una funcion que devuelve los caracteres de a uno a un io.Reader en UTF-8
+type runeDecoder struct {
+	next    func() (rune, error)
+	pending []byte
+	err     error
+}
+
+func (d *runeDecoder) Read(p []byte) (int, error) {
+	for len(d.pending) < len(p) && d.err == nil {
+		r, err := d.next()
+		if err != nil {
+			d.err = err
+			break
+		}
+		d.pending = utf8.AppendRune(d.pending, r)
+	}
+
+	if len(d.pending) == 0 {
+		return 0, d.err
+	}
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
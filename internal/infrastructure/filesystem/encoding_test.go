@@ -0,0 +1,177 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+)
+
+const encodingTestCSV = "titulo,descripcion,criterio_aceptacion\nGestión de sesión,Descripción con ñ,Criterio válido\n"
+
+func encodeUTF16LE(text string) []byte {
+	data := []byte{0xFF, 0xFE}
+	for _, unit := range utf16.Encode([]rune(text)) {
+		data = append(data, byte(unit), byte(unit>>8))
+	}
+	return data
+}
+
+func encodeLatin1(text string) []byte {
+	var data []byte
+	for _, r := range text {
+		data = append(data, byte(r))
+	}
+	return data
+}
+
+func TestFileProcessor_ReadFile_Encodings(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		encoding string
+	}{
+		{"utf8", []byte(encodingTestCSV), EncodingUTF8},
+		{"utf8_bom", append([]byte{0xEF, 0xBB, 0xBF}, encodingTestCSV...), EncodingUTF8},
+		{"utf16le_bom", encodeUTF16LE(encodingTestCSV), EncodingUTF16LE},
+		{"latin1", encodeLatin1(encodingTestCSV), EncodingLatin1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "historias.csv")
+			if err := os.WriteFile(filePath, tt.content, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			fp := NewFileProcessor(t.TempDir())
+			encoding, err := fp.DetectEncoding(context.Background(), filePath)
+			if err != nil {
+				t.Fatalf("DetectEncoding() error = %v", err)
+			}
+			if encoding != tt.encoding {
+				t.Errorf("DetectEncoding() = %q, want %q", encoding, tt.encoding)
+			}
+
+			stories, err := fp.ReadFile(context.Background(), filePath)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			if len(stories) != 1 {
+				t.Fatalf("Expected 1 story, got %d", len(stories))
+			}
+			if stories[0].Titulo != "Gestión de sesión" || stories[0].Descripcion != "Descripción con ñ" {
+				t.Errorf("Unexpected decoded story: %q / %q", stories[0].Titulo, stories[0].Descripcion)
+			}
+		})
+	}
+}
+
+// encodeWindows1252 codifica el texto de prueba: Latin-1 salvo los caracteres de windows1252C1
+func encodeWindows1252(text string) []byte {
+	var data []byte
+	for _, r := range text {
+		b := byte(r)
+		for i, c1 := range windows1252C1 {
+			if c1 == r {
+				b = byte(0x80 + i)
+			}
+		}
+		data = append(data, b)
+	}
+	return data
+}
+
+func TestFileProcessor_ReadFile_Windows1252(t *testing.T) {
+	const content = "titulo,descripcion,criterio_aceptacion\nExportación,“descripción” de 10 € – revisión,Criterio válido\n"
+
+	filePath := filepath.Join(t.TempDir(), "excel_windows.csv")
+	if err := os.WriteFile(filePath, encodeWindows1252(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fp := NewFileProcessor(t.TempDir())
+	encoding, err := fp.DetectEncoding(context.Background(), filePath)
+	if err != nil || encoding != EncodingWindows1252 {
+		t.Errorf("DetectEncoding() = %q, %v; want %q", encoding, err, EncodingWindows1252)
+	}
+
+	stories, err := fp.ReadFile(context.Background(), filePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	want := []rune("“descripción” de 10 € – revisión")
+	if got := []rune(stories[0].Descripcion); string(got) != string(want) {
+		t.Errorf("Descripcion runes = %U, want %U", got, want)
+	}
+	if stories[0].Titulo != "Exportación" {
+		t.Errorf("Titulo = %q, want Exportación", stories[0].Titulo)
+	}
+}
+
+func TestFileProcessor_SetInputEncoding(t *testing.T) {
+	// "Ã³" en windows-1252 son bytes que tambien forman una "ó" valida en UTF-8
+	const content = "titulo,descripcion,criterio_aceptacion\nPromociÃ³n,Descripcion,Criterio\n"
+	filePath := filepath.Join(t.TempDir(), "historias.csv")
+	if err := os.WriteFile(filePath, encodeWindows1252(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		encoding string
+		want     string
+		detected string
+	}{
+		{"", "Promoción", EncodingUTF8},
+		{"auto", "Promoción", EncodingUTF8},
+		{"CP1252", "PromociÃ³n", EncodingWindows1252},
+		{"latin1", "PromociÃ³n", EncodingLatin1},
+		{"utf-8", "Promoción", EncodingUTF8},
+	}
+
+	for _, tt := range tests {
+		fp := NewFileProcessor(t.TempDir())
+		if err := fp.SetInputEncoding(tt.encoding); err != nil {
+			t.Fatalf("SetInputEncoding(%q) error = %v", tt.encoding, err)
+		}
+
+		encoding, _ := fp.DetectEncoding(context.Background(), filePath)
+		if encoding != tt.detected {
+			t.Errorf("INPUT_ENCODING=%q: DetectEncoding() = %q, want %q", tt.encoding, encoding, tt.detected)
+		}
+		stories, err := fp.ReadFile(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if stories[0].Titulo != tt.want {
+			t.Errorf("INPUT_ENCODING=%q: Titulo = %q, want %q", tt.encoding, stories[0].Titulo, tt.want)
+		}
+	}
+
+	// Un BOM manda sobre INPUT_ENCODING
+	bomPath := filepath.Join(t.TempDir(), "bom.csv")
+	if err := os.WriteFile(bomPath, encodeUTF16LE(encodingTestCSV), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fp := NewFileProcessor(t.TempDir())
+	fp.SetInputEncoding("latin1")
+	if stories, err := fp.ReadFile(context.Background(), bomPath); err != nil || stories[0].Titulo != "Gestión de sesión" {
+		t.Errorf("Expected the UTF-16 BOM to win over INPUT_ENCODING, got %v", err)
+	}
+
+	if err := fp.SetInputEncoding("ebcdic"); err == nil {
+		t.Error("Expected an error for an unsupported encoding")
+	}
+}
+
+func TestFileProcessor_DetectEncoding_NotApplicable(t *testing.T) {
+	fp := NewFileProcessor(t.TempDir())
+
+	for _, path := range []string{"https://example.com/historias.csv", "historias.xlsx"} {
+		encoding, err := fp.DetectEncoding(context.Background(), path)
+		if err != nil || encoding != "" {
+			t.Errorf("DetectEncoding(%q) = %q, %v; want empty", path, encoding, err)
+		}
+	}
+}
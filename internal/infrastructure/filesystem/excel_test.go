@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/xuri/excelize/v2"
@@ -288,6 +290,89 @@ func TestFileProcessor_ReadExcel_NoDataRows(t *testing.T) {
 	}
 }
 
+// createTestExcelWorkbook genera un .xlsx con una hoja por elemento de sheets, en orden; la
+// primera fila de cada hoja es el header
+func createTestExcelWorkbook(filePath string, sheets []string, rowsBySheet map[string][][]string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for i, sheetName := range sheets {
+		if i == 0 {
+			f.SetSheetName("Sheet1", sheetName)
+		} else if _, err := f.NewSheet(sheetName); err != nil {
+			return err
+		}
+		for rowIdx, row := range rowsBySheet[sheetName] {
+			for colIdx, value := range row {
+				cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+1)
+				f.SetCellValue(sheetName, cell, value)
+			}
+		}
+	}
+
+	return f.SaveAs(filePath)
+}
+
+func TestFileProcessor_ReadExcel_SheetSelection(t *testing.T) {
+	tempDir := t.TempDir()
+	excelPath := filepath.Join(tempDir, "backlog.xlsx")
+
+	header := []string{"titulo", "descripcion", "criterio_aceptacion"}
+	err := createTestExcelWorkbook(excelPath, []string{"Notas", "Sprint 1", "Vacia", "Sprint 2"}, map[string][][]string{
+		"Notas":    {{"Este libro tiene el backlog por sprint"}},
+		"Sprint 1": {header, {"Login", "Desc login", "Crit login"}, {"Logout", "Desc logout", "Crit logout"}},
+		"Sprint 2": {{"Titulo", "Descripcion", "Criterio_Aceptacion"}, {"Perfil", "Desc perfil", "Crit perfil"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		sheet  string
+		titles []string
+	}{
+		{"by_name", "Sprint 2", []string{"Perfil"}},
+		{"by_name_ignoring_case", "sprint 1", []string{"Login", "Logout"}},
+		{"by_index", "2", []string{"Login", "Logout"}},
+		{"all_sheets", AllSheets, []string{"Login", "Logout", "Perfil"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := NewFileProcessor(tempDir)
+			fp.SetSheet(tt.sheet)
+
+			stories, err := fp.ReadFile(context.Background(), excelPath)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+
+			var titles []string
+			for _, story := range stories {
+				titles = append(titles, story.Titulo)
+			}
+			if !reflect.DeepEqual(titles, tt.titles) {
+				t.Errorf("Titles = %v, want %v", titles, tt.titles)
+			}
+		})
+	}
+
+	// Sin hoja elegida se lee la primera, que aca no tiene filas de datos
+	fp := NewFileProcessor(tempDir)
+	if _, err := fp.ReadFile(context.Background(), excelPath); err == nil || !strings.Contains(err.Error(), "must have at least a header row") {
+		t.Errorf("Expected the first sheet to be read by default, got %v", err)
+	}
+
+	for _, sheet := range []string{"Sprint 3", "5", "0"} {
+		fp.SetSheet(sheet)
+		_, err := fp.ReadFile(context.Background(), excelPath)
+		if err == nil || !strings.Contains(err.Error(), "available sheets: Notas, Sprint 1, Vacia, Sprint 2") {
+			t.Errorf("Sheet %q: expected an error listing the sheets, got %v", sheet, err)
+		}
+	}
+}
+
 // Helper function para verificar si una cadena contiene otra
 func containsString(haystack, needle string) bool {
 	return len(needle) == 0 || (len(haystack) >= len(needle) &&
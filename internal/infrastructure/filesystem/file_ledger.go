@@ -0,0 +1,94 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type FileLedger struct {
+	path string
+	mu   sync.Mutex
+}
+
+type LedgerEntry struct {
+	FileName    string    `json:"file_name"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
+
+func NewFileLedger(path string) *FileLedger {
+	return &FileLedger{
+		path: path,
+	}
+}
+
+func (fl *FileLedger) IsProcessed(ctx context.Context, hash string) (bool, error) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	entries, err := fl.load()
+	if err != nil {
+		return false, err
+	}
+
+	_, exists := entries[hash]
+	return exists, nil
+}
+
+func (fl *FileLedger) MarkProcessed(ctx context.Context, hash, filePath string) error {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	entries, err := fl.load()
+	if err != nil {
+		return err
+	}
+
+	entries[hash] = LedgerEntry{
+		FileName:    filepath.Base(filePath),
+		ProcessedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding state file: %w", err)
+	}
+
+	if dir := filepath.Dir(fl.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("error creating state directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(fl.path, data, 0644); err != nil {
+		return fmt.Errorf("error writing state file: %w", err)
+	}
+
+	return nil
+}
+
+func (fl *FileLedger) load() (map[string]LedgerEntry, error) {
+	entries := make(map[string]LedgerEntry)
+
+	data, err := os.ReadFile(fl.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading state file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return entries, nil
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("error parsing state file %s: %w", fl.path, err)
+	}
+
+	return entries, nil
+}
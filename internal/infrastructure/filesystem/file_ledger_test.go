@@ -0,0 +1,90 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileLedger_MarkAndCheck(t *testing.T) {
+	ctx := context.Background()
+	statePath := filepath.Join(t.TempDir(), "state", "ledger.json")
+	ledger := NewFileLedger(statePath)
+
+	processed, err := ledger.IsProcessed(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Expected no error on missing state file, got: %v", err)
+	}
+	if processed {
+		t.Error("Expected hash to be unknown on empty ledger")
+	}
+
+	if err := ledger.MarkProcessed(ctx, "abc", "/tmp/entrada/stories.csv"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Una nueva instancia debe leer el estado persistido
+	reloaded := NewFileLedger(statePath)
+	processed, err = reloaded.IsProcessed(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !processed {
+		t.Error("Expected hash to be recorded after MarkProcessed")
+	}
+
+	processed, _ = reloaded.IsProcessed(ctx, "def")
+	if processed {
+		t.Error("Expected different hash to be unknown")
+	}
+}
+
+func TestFileLedger_CorruptedStateFile(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "ledger.json")
+	if err := os.WriteFile(statePath, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	ledger := NewFileLedger(statePath)
+	if _, err := ledger.IsProcessed(context.Background(), "abc"); err == nil {
+		t.Error("Expected error for corrupted state file")
+	}
+}
+
+func TestFileProcessor_ComputeHash(t *testing.T) {
+	tempDir := t.TempDir()
+	fp := NewFileProcessor(tempDir)
+	ctx := context.Background()
+
+	original := filepath.Join(tempDir, "a.csv")
+	copyPath := filepath.Join(tempDir, "b.csv")
+	modified := filepath.Join(tempDir, "c.csv")
+
+	content := "titulo,descripcion,criterio_aceptacion\nStory,Desc,Crit\n"
+	os.WriteFile(original, []byte(content), 0644)
+	os.WriteFile(copyPath, []byte(content), 0644)
+	os.WriteFile(modified, []byte(content+"Otra,Desc,Crit\n"), 0644)
+
+	hashOriginal, err := fp.ComputeHash(ctx, original)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(hashOriginal) != 64 {
+		t.Errorf("Expected SHA-256 hex digest, got %q", hashOriginal)
+	}
+
+	hashCopy, _ := fp.ComputeHash(ctx, copyPath)
+	if hashOriginal != hashCopy {
+		t.Error("Expected identical content to produce the same hash")
+	}
+
+	hashModified, _ := fp.ComputeHash(ctx, modified)
+	if hashOriginal == hashModified {
+		t.Error("Expected modified content to produce a different hash")
+	}
+
+	if _, err := fp.ComputeHash(ctx, filepath.Join(tempDir, "missing.csv")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -2,15 +2,23 @@ package filesystem
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/gocarina/gocsv"
 	"github.com/xuri/excelize/v2"
 )
 
@@ -18,49 +26,183 @@ const (
 	csvExtension  = ".csv"
 	xlsxExtension = ".xlsx"
 	xlsExtension  = ".xls"
+	odsExtension  = ".ods"
+	jsonExtension = ".json"
+	yamlExtension = ".yaml"
+	ymlExtension  = ".yml"
 )
 
+// AllSheets (EXCEL_SHEET=*) lee todas las hojas de una planilla y concatena sus historias
+const AllSheets = "*"
+
+// DefaultRequiredFields son las columnas obligatorias cuando no se configura REQUIRED_FIELDS
+var DefaultRequiredFields = []string{"titulo", "descripcion", "criterio_aceptacion"}
+
+// requiredFieldNames relaciona cada columna obligable con el campo de UserStory que valida
+var requiredFieldNames = map[string]string{
+	"titulo":              "Titulo",
+	"descripcion":         "Descripcion",
+	"criterio_aceptacion": "CriterioAceptacion",
+}
+
 type FileProcessor struct {
-	validator    *validator.Validate
-	processedDir string
+	validator      *validator.Validate
+	processedDir   string
+	commentChar    rune
+	requiredFields map[string]bool
+	httpClient     *http.Client
+	// summaryLength > 0 completa el titulo vacio con el inicio de la descripcion
+	summaryLength int
+	// subtaskDelimiter separa las subtareas de la columna subtareas (ademas de los saltos de linea)
+	subtaskDelimiter string
+	// columnNames relaciona cada encabezado aceptado (normalizado) con su columna
+	columnNames map[string]string
+	// sheet elige la hoja de Excel u ODS (nombre, numero desde 1 o AllSheets); vacio es la primera
+	sheet string
+	// inputEncoding es la codificacion de los archivos sin BOM; vacio la detecta
+	inputEncoding string
 }
 
+// maxSummaryLength es el largo maximo que Jira admite en el summary
+const maxSummaryLength = 255
+
+// downloadTimeout limita la descarga de archivos indicados por URL
+const downloadTimeout = 60 * time.Second
+
 type CSVRecord struct {
 	Titulo             string `csv:"titulo"`
 	Descripcion        string `csv:"descripcion"`
 	Subtareas          string `csv:"subtareas"`
 	CriterioAceptacion string `csv:"criterio_aceptacion"`
 	Parent             string `csv:"parent"`
+	SubtaskType        string `csv:"subtask_type"`
+	Skip               string `csv:"skip"`
+	Environment        string `csv:"environment"`
+	SubtasksFile       string `csv:"subtasks_file"`
+	Assignee           string `csv:"asignado,assignee"`
+	Labels             string `csv:"labels,etiquetas"`
+	Prioridad          string `csv:"prioridad,priority"`
+	Enlaces            string `csv:"enlaces,links"`
+}
+
+// skipValues son los valores de la columna skip que excluyen una fila del procesamiento
+var skipValues = map[string]bool{
+	"1": true, "true": true, "yes": true, "si": true, "sí": true, "x": true,
 }
 
 func NewFileProcessor(processedDir string) *FileProcessor {
-	return &FileProcessor{
-		validator:    validator.New(),
-		processedDir: processedDir,
+	fp := &FileProcessor{
+		validator:        validator.New(),
+		processedDir:     processedDir,
+		httpClient:       &http.Client{Timeout: downloadTimeout},
+		subtaskDelimiter: entities.DefaultSubtaskDelimiter,
+	}
+	fp.SetRequiredFields(DefaultRequiredFields)
+	fp.columnNames, _ = buildColumnNames(nil)
+	return fp
+}
+
+// SetRequiredFields define que columnas deben tener valor para que una fila se procese
+func (fp *FileProcessor) SetRequiredFields(fields []string) {
+	if len(fields) == 0 {
+		fields = DefaultRequiredFields
+	}
+
+	fp.requiredFields = make(map[string]bool)
+	for _, field := range fields {
+		fp.requiredFields[strings.ToLower(strings.TrimSpace(field))] = true
+	}
+}
+
+// SetDeriveSummaryFromDescription hace que las filas sin titulo usen los primeros
+// maxLength caracteres de la descripcion como titulo. Cero desactiva la derivacion.
+func (fp *FileProcessor) SetDeriveSummaryFromDescription(maxLength int) {
+	if maxLength > maxSummaryLength {
+		maxLength = maxSummaryLength
 	}
+	fp.summaryLength = maxLength
+}
+
+// SetSubtaskDelimiter configura el separador de la columna subtareas; vacio vuelve al default ";"
+func (fp *FileProcessor) SetSubtaskDelimiter(delimiter string) {
+	if delimiter == "" {
+		delimiter = entities.DefaultSubtaskDelimiter
+	}
+	fp.subtaskDelimiter = delimiter
+}
+
+// SetSheet elige la hoja que se lee de Excel y ODS: su nombre, su numero (desde 1) o AllSheets.
+// Vacio lee la primera hoja
+func (fp *FileProcessor) SetSheet(sheet string) {
+	fp.sheet = strings.TrimSpace(sheet)
+}
+
+// SetCommentChar configura el caracter que marca lineas de comentario en CSV.
+// Un valor vacio desactiva la omision de comentarios.
+func (fp *FileProcessor) SetCommentChar(commentChar string) {
+	if commentChar == "" {
+		fp.commentChar = 0
+		return
+	}
+	fp.commentChar, _ = utf8.DecodeRuneInString(commentChar)
 }
 
 func (fp *FileProcessor) ReadFile(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
+	// Los archivos de subtareas se resuelven relativos al archivo de entrada; una URL no tiene directorio local
+	baseDir := filepath.Dir(filePath)
+	if repositories.IsRemoteSource(filePath) {
+		baseDir = ""
+		localPath, err := fp.download(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(localPath)
+		filePath = localPath
+	}
+
+	var (
+		stories []*entities.UserStory
+		err     error
+	)
+
 	ext := strings.ToLower(filepath.Ext(filePath))
 
 	switch ext {
 	case csvExtension:
-		return fp.readCSV(filePath)
+		stories, err = fp.readCSV(filePath)
 	case xlsxExtension, xlsExtension:
-		return fp.readExcel(filePath)
+		stories, err = fp.readExcel(filePath)
+	case odsExtension:
+		stories, err = fp.readODS(filePath)
+	case jsonExtension:
+		stories, err = fp.readJSON(filePath)
+	case yamlExtension, ymlExtension:
+		stories, err = fp.readYAML(filePath)
 	default:
 		return nil, fmt.Errorf("unsupported file format: %s", ext)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fp.attachSubtasksFiles(stories, baseDir); err != nil {
+		return nil, err
+	}
+
+	return stories, nil
 }
 
 func (fp *FileProcessor) ValidateFile(ctx context.Context, filePath string) error {
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return fmt.Errorf("file does not exist: %s", filePath)
+	if !repositories.IsRemoteSource(filePath) {
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", filePath)
+		}
 	}
 
-	ext := strings.ToLower(filepath.Ext(filePath))
-	if ext != csvExtension && ext != xlsxExtension && ext != xlsExtension {
-		return fmt.Errorf("unsupported file format: %s. Supported formats: %s, %s, %s", ext, csvExtension, xlsxExtension, xlsExtension)
+	ext := sourceExtension(filePath)
+	if !isSupportedExtension(ext) {
+		return fmt.Errorf("unsupported file format: %s. Supported formats: %s, %s, %s, %s, %s, %s, %s",
+			ext, csvExtension, xlsxExtension, xlsExtension, odsExtension, jsonExtension, yamlExtension, ymlExtension)
 	}
 
 	stories, err := fp.ReadFile(ctx, filePath)
@@ -73,7 +215,10 @@ func (fp *FileProcessor) ValidateFile(ctx context.Context, filePath string) erro
 	}
 
 	for i, story := range stories {
-		if err := fp.validator.Struct(story); err != nil {
+		if story.Skip {
+			continue
+		}
+		if err := fp.validateStory(story); err != nil {
 			return fmt.Errorf("validation error in row %d: %w", i+2, err)
 		}
 	}
@@ -81,6 +226,52 @@ func (fp *FileProcessor) ValidateFile(ctx context.Context, filePath string) erro
 	return nil
 }
 
+// download guarda el archivo de una URL en un temporal con su misma extension y devuelve la ruta
+func (fp *FileProcessor) download(ctx context.Context, fileURL string) (string, error) {
+	ext := sourceExtension(fileURL)
+	if !isSupportedExtension(ext) {
+		return "", fmt.Errorf("unsupported file format: %s", ext)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid file URL: %w", err)
+	}
+
+	resp, err := fp.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error downloading file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error downloading file: status %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp("", "historiador-*"+ext)
+	if err != nil {
+		return "", fmt.Errorf("error creating temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("error downloading file: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// sourceExtension devuelve la extension del archivo, tomando solo el path si es una URL
+func sourceExtension(filePath string) string {
+	if repositories.IsRemoteSource(filePath) {
+		if parsed, err := url.Parse(filePath); err == nil {
+			filePath = parsed.Path
+		}
+	}
+	return strings.ToLower(filepath.Ext(filePath))
+}
+
 func (fp *FileProcessor) MoveToProcessed(ctx context.Context, filePath string) error {
 	if err := os.MkdirAll(fp.processedDir, 0755); err != nil {
 		return fmt.Errorf("error creating processed directory: %w", err)
@@ -92,7 +283,22 @@ func (fp *FileProcessor) MoveToProcessed(ctx context.Context, filePath string) e
 	return os.Rename(filePath, destPath)
 }
 
+func (fp *FileProcessor) Exists(ctx context.Context, filePath string) (bool, error) {
+	_, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking file: %w", err)
+	}
+	return true, nil
+}
+
 func (fp *FileProcessor) GetPendingFiles(ctx context.Context, inputDir string) ([]string, error) {
+	if _, err := os.Stat(inputDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrInputDirectoryNotFound, inputDir)
+	}
+
 	var files []string
 
 	err := filepath.Walk(inputDir, func(path string, info os.FileInfo, err error) error {
@@ -102,7 +308,7 @@ func (fp *FileProcessor) GetPendingFiles(ctx context.Context, inputDir string) (
 
 		if !info.IsDir() {
 			ext := strings.ToLower(filepath.Ext(path))
-			if ext == ".csv" || ext == ".xlsx" || ext == ".xls" {
+			if isSupportedExtension(ext) {
 				files = append(files, path)
 			}
 		}
@@ -113,37 +319,68 @@ func (fp *FileProcessor) GetPendingFiles(ctx context.Context, inputDir string) (
 	return files, err
 }
 
-func (fp *FileProcessor) readCSV(filePath string) ([]*entities.UserStory, error) {
+func (fp *FileProcessor) ComputeHash(ctx context.Context, filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("error opening CSV file: %w", err)
+		return "", fmt.Errorf("error opening file for hashing: %w", err)
 	}
 	defer file.Close()
 
-	var records []*CSVRecord
-	if err := gocsv.UnmarshalFile(file, &records); err != nil {
-		return nil, fmt.Errorf("error parsing CSV: %w", err)
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("error hashing file: %w", err)
 	}
 
-	var stories []*entities.UserStory
-	for _, record := range records {
-		if record.Titulo == "" || record.Descripcion == "" || record.CriterioAceptacion == "" {
-			continue
-		}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
 
-		story := entities.NewUserStory(
-			record.Titulo,
-			record.Descripcion,
-			record.CriterioAceptacion,
-			record.Subtareas,
-			record.Parent,
-		)
+func (fp *FileProcessor) readCSV(filePath string) ([]*entities.UserStory, error) {
+	var stories []*entities.UserStory
+	err := fp.streamCSV(filePath, func(story *entities.UserStory) error {
 		stories = append(stories, story)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return stories, nil
 }
 
+// storyFromRecord convierte una fila del CSV o de una hoja de calculo en historia; nil si le
+// faltan campos obligatorios y no esta marcada con skip. Falla si la columna enlaces no se puede
+// interpretar
+func (fp *FileProcessor) storyFromRecord(record *CSVRecord) (*entities.UserStory, error) {
+	fp.deriveSummary(record)
+	skip := isSkipped(record)
+	if !skip && !fp.hasRequiredFields(record) {
+		return nil, nil
+	}
+
+	links, err := entities.ParseLinks(record.Enlaces)
+	if err != nil && !skip {
+		return nil, err
+	}
+
+	story := entities.NewUserStory(
+		record.Titulo,
+		record.Descripcion,
+		record.CriterioAceptacion,
+		"",
+		record.Parent,
+	)
+	story.Subtareas = entities.ParseSubtareas(record.Subtareas, fp.subtaskDelimiter)
+	story.SubtaskType = strings.TrimSpace(record.SubtaskType)
+	story.Environment = strings.TrimSpace(record.Environment)
+	story.SubtasksFile = strings.TrimSpace(record.SubtasksFile)
+	story.Assignee = strings.TrimSpace(record.Assignee)
+	story.Labels = entities.ParseLabels(record.Labels)
+	story.Prioridad = strings.TrimSpace(record.Prioridad)
+	story.Links = links
+	story.Skip = skip
+	return story, nil
+}
+
 func (fp *FileProcessor) readExcel(filePath string) ([]*entities.UserStory, error) {
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
@@ -151,16 +388,80 @@ func (fp *FileProcessor) readExcel(filePath string) ([]*entities.UserStory, erro
 	}
 	defer f.Close()
 
-	sheetName := f.GetSheetName(0)
-	rows, err := f.GetRows(sheetName)
+	return fp.storiesFromSheets("Excel", f.GetSheetList(), func(sheetName string) ([][]string, error) {
+		rows, err := f.GetRows(sheetName)
+		if err != nil {
+			return nil, fmt.Errorf("error reading Excel rows: %w", err)
+		}
+		return rows, nil
+	})
+}
+
+// selectSheets devuelve las hojas a leer segun SetSheet. Un nombre se busca sin distinguir
+// mayusculas y tiene prioridad sobre un numero, por si una hoja se llama "2024"
+func (fp *FileProcessor) selectSheets(available []string) ([]string, error) {
+	if len(available) == 0 {
+		return nil, fmt.Errorf("file has no sheets")
+	}
+
+	switch fp.sheet {
+	case "":
+		return available[:1], nil
+	case AllSheets:
+		return available, nil
+	}
+
+	for _, name := range available {
+		if strings.EqualFold(name, fp.sheet) {
+			return []string{name}, nil
+		}
+	}
+	if index, err := strconv.Atoi(fp.sheet); err == nil && index >= 1 && index <= len(available) {
+		return []string{available[index-1]}, nil
+	}
+
+	return nil, fmt.Errorf("sheet %q not found (available sheets: %s)", fp.sheet, strings.Join(available, ", "))
+}
+
+// storiesFromSheets lee las hojas elegidas con rowsOf y concatena sus historias; cada hoja tiene
+// su propio header. Con todas las hojas se omiten las que no tienen filas de datos
+func (fp *FileProcessor) storiesFromSheets(format string, available []string, rowsOf func(sheetName string) ([][]string, error)) ([]*entities.UserStory, error) {
+	sheets, err := fp.selectSheets(available)
 	if err != nil {
-		return nil, fmt.Errorf("error reading Excel rows: %w", err)
+		return nil, err
 	}
 
-	if len(rows) < 2 {
-		return nil, fmt.Errorf("Excel file must have at least a header row and one data row")
+	var stories []*entities.UserStory
+	withData := false
+	for _, sheetName := range sheets {
+		rows, err := rowsOf(sheetName)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) < 2 {
+			continue
+		}
+		withData = true
+
+		sheetStories, err := fp.storiesFromRows(rows)
+		if err != nil {
+			if len(sheets) > 1 {
+				return nil, fmt.Errorf("sheet %q: %w", sheetName, err)
+			}
+			return nil, err
+		}
+		stories = append(stories, sheetStories...)
+	}
+
+	if !withData {
+		return nil, fmt.Errorf("%s file must have at least a header row and one data row", format)
 	}
 
+	return stories, nil
+}
+
+// storiesFromRows convierte las filas de una hoja de calculo (header incluido) en historias
+func (fp *FileProcessor) storiesFromRows(rows [][]string) ([]*entities.UserStory, error) {
 	header := rows[0]
 	columnMap := fp.mapColumns(header)
 
@@ -170,21 +471,18 @@ func (fp *FileProcessor) readExcel(filePath string) ([]*entities.UserStory, erro
 			continue
 		}
 
-		record := fp.parseExcelRow(row, columnMap)
-		if record.Titulo == "" || record.Descripcion == "" || record.CriterioAceptacion == "" {
+		story, err := fp.storyFromRecord(fp.parseExcelRow(row, columnMap))
+		if err != nil {
+			return nil, fmt.Errorf("validation error in row %d: %w", i+2, err)
+		}
+		if story == nil {
 			continue
 		}
 
-		story := entities.NewUserStory(
-			record.Titulo,
-			record.Descripcion,
-			record.CriterioAceptacion,
-			record.Subtareas,
-			record.Parent,
-		)
-
-		if err := fp.validator.Struct(story); err != nil {
-			return nil, fmt.Errorf("validation error in row %d: %w", i+2, err)
+		if !story.Skip {
+			if err := fp.validateStory(story); err != nil {
+				return nil, fmt.Errorf("validation error in row %d: %w", i+2, err)
+			}
 		}
 
 		stories = append(stories, story)
@@ -193,21 +491,74 @@ func (fp *FileProcessor) readExcel(filePath string) ([]*entities.UserStory, erro
 	return stories, nil
 }
 
+func isSupportedExtension(ext string) bool {
+	switch ext {
+	case csvExtension, xlsxExtension, xlsExtension, odsExtension, jsonExtension, yamlExtension, ymlExtension:
+		return true
+	}
+	return false
+}
+
+// isSkipped indica si la columna skip de la fila tiene un valor verdadero (yes, true, 1, si, x)
+func isSkipped(record *CSVRecord) bool {
+	return skipValues[strings.ToLower(strings.TrimSpace(record.Skip))]
+}
+
+// deriveSummary completa un titulo vacio con la primera linea de la descripcion, recortada a summaryLength
+func (fp *FileProcessor) deriveSummary(record *CSVRecord) {
+	if fp.summaryLength <= 0 || strings.TrimSpace(record.Titulo) != "" {
+		return
+	}
+
+	description := strings.TrimSpace(record.Descripcion)
+	if line, _, found := strings.Cut(description, "\n"); found {
+		description = strings.TrimSpace(line)
+	}
+
+	if runes := []rune(description); len(runes) > fp.summaryLength {
+		description = strings.TrimSpace(string(runes[:fp.summaryLength]))
+	}
+	record.Titulo = description
+}
+
+func (fp *FileProcessor) hasRequiredFields(record *CSVRecord) bool {
+	values := map[string]string{
+		"titulo":              record.Titulo,
+		"descripcion":         record.Descripcion,
+		"criterio_aceptacion": record.CriterioAceptacion,
+	}
+
+	for field, value := range values {
+		if fp.requiredFields[field] && strings.TrimSpace(value) == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateStory aplica las reglas de UserStory omitiendo los campos no obligatorios
+func (fp *FileProcessor) validateStory(story *entities.UserStory) error {
+	var optional []string
+	for column, fieldName := range requiredFieldNames {
+		if !fp.requiredFields[column] {
+			optional = append(optional, fieldName)
+		}
+	}
+
+	if len(optional) == 0 {
+		return fp.validator.Struct(story)
+	}
+
+	return fp.validator.StructExcept(story, optional...)
+}
+
 func (fp *FileProcessor) mapColumns(header []string) map[string]int {
 	columnMap := make(map[string]int)
 
 	for i, col := range header {
-		switch strings.ToLower(strings.TrimSpace(col)) {
-		case "titulo":
-			columnMap["titulo"] = i
-		case "descripcion":
-			columnMap["descripcion"] = i
-		case "subtareas":
-			columnMap["subtareas"] = i
-		case "criterio_aceptacion":
-			columnMap["criterio_aceptacion"] = i
-		case "parent":
-			columnMap["parent"] = i
+		if name, ok := fp.columnName(col); ok {
+			columnMap[name] = i
 		}
 	}
 
@@ -232,6 +583,30 @@ func (fp *FileProcessor) parseExcelRow(row []string, columnMap map[string]int) *
 	if idx, exists := columnMap["parent"]; exists && idx < len(row) {
 		record.Parent = strings.TrimSpace(row[idx])
 	}
+	if idx, exists := columnMap["subtask_type"]; exists && idx < len(row) {
+		record.SubtaskType = strings.TrimSpace(row[idx])
+	}
+	if idx, exists := columnMap["skip"]; exists && idx < len(row) {
+		record.Skip = strings.TrimSpace(row[idx])
+	}
+	if idx, exists := columnMap["environment"]; exists && idx < len(row) {
+		record.Environment = strings.TrimSpace(row[idx])
+	}
+	if idx, exists := columnMap["subtasks_file"]; exists && idx < len(row) {
+		record.SubtasksFile = strings.TrimSpace(row[idx])
+	}
+	if idx, exists := columnMap["asignado"]; exists && idx < len(row) {
+		record.Assignee = strings.TrimSpace(row[idx])
+	}
+	if idx, exists := columnMap["labels"]; exists && idx < len(row) {
+		record.Labels = strings.TrimSpace(row[idx])
+	}
+	if idx, exists := columnMap["prioridad"]; exists && idx < len(row) {
+		record.Prioridad = strings.TrimSpace(row[idx])
+	}
+	if idx, exists := columnMap["enlaces"]; exists && idx < len(row) {
+		record.Enlaces = strings.TrimSpace(row[idx])
+	}
 
 	return record
 }
@@ -2,12 +2,17 @@ package filesystem
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
 )
 
 func TestNewFileProcessor(t *testing.T) {
@@ -319,6 +324,10 @@ func TestFileProcessor_GetPendingFiles(t *testing.T) {
 		{"test1.csv", true},
 		{"test2.xlsx", true},
 		{"test3.xls", true},
+		{"test3b.ods", true},
+		{"test3c.json", true},
+		{"test3d.yaml", true},
+		{"test3e.yml", true},
 		{"test4.txt", false},
 		{"test5.pdf", false},
 		{"subdir/test6.csv", true},
@@ -356,7 +365,7 @@ func TestFileProcessor_GetPendingFiles(t *testing.T) {
 	// Verificar que solo se encontraron archivos válidos
 	for _, foundFile := range pendingFiles {
 		ext := strings.ToLower(filepath.Ext(foundFile))
-		if ext != ".csv" && ext != ".xlsx" && ext != ".xls" {
+		if !isSupportedExtension(ext) {
 			t.Errorf("Found unexpected file with extension %s: %s", ext, foundFile)
 		}
 	}
@@ -417,6 +426,28 @@ func TestFileProcessor_GetPendingFiles_ErrorCases(t *testing.T) {
 	}
 }
 
+func TestFileProcessor_GetPendingFiles_MissingVsEmptyDirectory(t *testing.T) {
+	fp := NewFileProcessor(t.TempDir())
+	ctx := context.Background()
+
+	missingDir := filepath.Join(t.TempDir(), "no-existe")
+	_, err := fp.GetPendingFiles(ctx, missingDir)
+	if !errors.Is(err, repositories.ErrInputDirectoryNotFound) {
+		t.Fatalf("Expected ErrInputDirectoryNotFound, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), missingDir) {
+		t.Errorf("Expected error to mention the directory, got: %v", err)
+	}
+
+	files, err := fp.GetPendingFiles(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error for empty directory, got: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected no files, got %d", len(files))
+	}
+}
+
 func TestFileProcessor_mapColumns(t *testing.T) {
 	fp := NewFileProcessor("/test")
 
@@ -702,6 +733,613 @@ Test Story 2,Test Description 2,,Task 3,PROJ-125`
 	}
 }
 
+func TestFileProcessor_readCSV_CommentLines(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `# Instrucciones: complete una historia por fila
+titulo,descripcion,criterio_aceptacion,subtareas,parent
+# Las subtareas se separan con punto y coma
+Story 1,Description 1,Criteria 1,Task 1;Task 2,
+Story 2,Description 2,Criteria 2,,
+# Fin de la plantilla`
+	filePath := filepath.Join(tempDir, "template.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		commentChar   string
+		expectedCount int
+		expectedErr   string
+	}{
+		{
+			name:          "hash_comments_skipped",
+			commentChar:   "#",
+			expectedCount: 2,
+		},
+		{
+			name:        "comments_disabled",
+			commentChar: "",
+			expectedErr: "error parsing CSV",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := NewFileProcessor(tempDir)
+			fp.SetCommentChar(tt.commentChar)
+
+			stories, err := fp.readCSV(filePath)
+
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("Expected error containing '%s', got: %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(stories) != tt.expectedCount {
+				t.Fatalf("Expected %d stories, got %d", tt.expectedCount, len(stories))
+			}
+			for _, story := range stories {
+				if strings.HasPrefix(story.Titulo, "#") {
+					t.Errorf("Comment line parsed as story: %q", story.Titulo)
+				}
+			}
+		})
+	}
+}
+
+func TestFileProcessor_SkipColumn(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `titulo,descripcion,criterio_aceptacion,skip
+Story 1,Description 1,Criteria 1,
+Story 2,Description 2,Criteria 2,yes
+Borrador,,,TRUE
+Story 4,Description 4,Criteria 4,no`
+	filePath := filepath.Join(tempDir, "skip.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	fp := NewFileProcessor(tempDir)
+
+	assertSkips := func(t *testing.T, stories []*entities.UserStory) {
+		t.Helper()
+		want := map[string]bool{"Story 1": false, "Story 2": true, "Borrador": true, "Story 4": false}
+		if len(stories) != len(want) {
+			t.Fatalf("Expected %d stories, got %d", len(want), len(stories))
+		}
+		for _, story := range stories {
+			if story.Skip != want[story.Titulo] {
+				t.Errorf("Story %q: Skip = %v, want %v", story.Titulo, story.Skip, want[story.Titulo])
+			}
+		}
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		stories, err := fp.readCSV(filePath)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		assertSkips(t, stories)
+
+		// Las filas saltadas no se validan aunque esten incompletas
+		if err := fp.ValidateFile(context.Background(), filePath); err != nil {
+			t.Errorf("Expected skipped draft row to pass validation, got: %v", err)
+		}
+	})
+
+	t.Run("spreadsheet_rows", func(t *testing.T) {
+		rows := [][]string{
+			{"titulo", "descripcion", "criterio_aceptacion", "skip"},
+			{"Story 1", "Description 1", "Criteria 1"},
+			{"Story 2", "Description 2", "Criteria 2", "yes"},
+			{"Borrador", "", "", "1"},
+			{"Story 4", "Description 4", "Criteria 4", "no"},
+		}
+
+		stories, err := fp.storiesFromRows(rows)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		assertSkips(t, stories)
+	})
+}
+
+func TestFileProcessor_SubtaskTypeColumn(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `titulo,descripcion,criterio_aceptacion,subtareas,subtask_type
+Story 1,Description 1,Criteria 1,Task 1;Task 2, Technical Task
+Story 2,Description 2,Criteria 2,Task 3,`
+	filePath := filepath.Join(tempDir, "subtask_type.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	stories, err := NewFileProcessor(tempDir).readCSV(filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stories) != 2 {
+		t.Fatalf("Expected 2 stories, got %d", len(stories))
+	}
+	if stories[0].SubtaskType != "Technical Task" {
+		t.Errorf("SubtaskType = %q, want Technical Task", stories[0].SubtaskType)
+	}
+	if stories[1].SubtaskType != "" {
+		t.Errorf("SubtaskType = %q, want empty to use SUBTASK_ISSUE_TYPE", stories[1].SubtaskType)
+	}
+}
+
+func TestFileProcessor_SubtaskDelimiter(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name      string
+		delimiter string
+		subtareas string
+	}{
+		{"pipe", "|", "\"Configurar DNS; TTL bajo|Deploy\nSmoke test\""},
+		{"tab", "\t", "\"Configurar DNS; TTL bajo\tDeploy\nSmoke test\""},
+	}
+
+	want := []string{"Configurar DNS; TTL bajo", "Deploy", "Smoke test"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := NewFileProcessor(tempDir)
+			fp.SetSubtaskDelimiter(tt.delimiter)
+
+			content := "titulo,descripcion,criterio_aceptacion,subtareas\nStory 1,Description 1,Criteria 1," + tt.subtareas
+			filePath := filepath.Join(tempDir, tt.name+".csv")
+			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			stories, err := fp.readCSV(filePath)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !reflect.DeepEqual(stories[0].Subtareas, want) {
+				t.Errorf("CSV Subtareas = %q, want %q", stories[0].Subtareas, want)
+			}
+
+			// Excel y ODS pasan por storiesFromRows
+			rows := [][]string{
+				{"titulo", "descripcion", "criterio_aceptacion", "subtareas"},
+				{"Story 1", "Description 1", "Criteria 1", strings.Join(want[:2], tt.delimiter) + "\n" + want[2]},
+			}
+			stories, err = fp.storiesFromRows(rows)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !reflect.DeepEqual(stories[0].Subtareas, want) {
+				t.Errorf("Sheet Subtareas = %q, want %q", stories[0].Subtareas, want)
+			}
+		})
+	}
+}
+
+func TestFileProcessor_ColumnAliases(t *testing.T) {
+	tempDir := t.TempDir()
+
+	fp := NewFileProcessor(tempDir)
+	if err := fp.SetColumnAliases(map[string][]string{
+		"titulo":              {"Title", "Summary"},
+		"descripcion":         {"Description"},
+		"criterio_aceptacion": {"Acceptance Criteria"},
+	}); err != nil {
+		t.Fatalf("SetColumnAliases() error = %v", err)
+	}
+
+	header := []string{" TITLE ", "description", "Acceptance Criteria", "Assignee", "titulo"}
+	want := map[string]int{"titulo": 4, "descripcion": 1, "criterio_aceptacion": 2, "asignado": 3}
+	if got := fp.mapColumns(header); !reflect.DeepEqual(got, want) {
+		t.Errorf("mapColumns() = %v, want %v", got, want)
+	}
+
+	content := "Title,Description,Acceptance Criteria,Priority\nLogin,Como usuario quiero ingresar,Ingresa con email,High"
+	filePath := filepath.Join(tempDir, "english.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	stories, err := fp.readCSV(filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stories) != 1 {
+		t.Fatalf("Expected 1 story, got %d", len(stories))
+	}
+	story := stories[0]
+	if story.Titulo != "Login" || story.Descripcion != "Como usuario quiero ingresar" || story.CriterioAceptacion != "Ingresa con email" || story.Prioridad != "High" {
+		t.Errorf("Story = %+v, want the English columns mapped", story)
+	}
+}
+
+func TestFileProcessor_SetColumnAliases_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases map[string][]string
+		wantErr string
+	}{
+		{"unknown column", map[string][]string{"title": {"Titulo"}}, `unknown column "title"`},
+		{"header used by another column", map[string][]string{"descripcion": {"Titulo"}}, "mapped to both titulo and descripcion"},
+		{"same alias for two columns", map[string][]string{"descripcion": {"Text"}, "titulo": {"text"}}, "mapped to both descripcion and titulo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := NewFileProcessor(t.TempDir())
+			err := fp.SetColumnAliases(tt.aliases)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SetColumnAliases() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			// Los encabezados por defecto siguen funcionando
+			if _, ok := fp.mapColumns([]string{"titulo"})["titulo"]; !ok {
+				t.Error("Expected the default headers to keep working after a rejected configuration")
+			}
+		})
+	}
+}
+
+func TestFileProcessor_EnvironmentColumn(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `titulo,descripcion,criterio_aceptacion,environment
+Bug 1,Description 1,Criteria 1, Firefox 121 / macOS
+Bug 2,Description 2,Criteria 2,`
+	filePath := filepath.Join(tempDir, "environment.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	processor := NewFileProcessor(tempDir)
+	stories, err := processor.readCSV(filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stories) != 2 || stories[0].Environment != "Firefox 121 / macOS" || stories[1].Environment != "" {
+		t.Errorf("Unexpected environments: %+v", stories)
+	}
+
+	excelStories, err := processor.storiesFromRows([][]string{
+		{"titulo", "descripcion", "criterio_aceptacion", "environment"},
+		{"Bug 1", "Description 1", "Criteria 1", "Staging"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if excelStories[0].Environment != "Staging" {
+		t.Errorf("Environment = %q, want Staging", excelStories[0].Environment)
+	}
+}
+
+func TestFileProcessor_AssigneeColumn(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for _, column := range []string{"asignado", "assignee"} {
+		content := "titulo,descripcion,criterio_aceptacion," + column + "\nStory 1,Description 1,Criteria 1, ana@empresa.com \nStory 2,Description 2,Criteria 2,"
+		filePath := filepath.Join(tempDir, column+".csv")
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+
+		processor := NewFileProcessor(tempDir)
+		stories, err := processor.readCSV(filePath)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(stories) != 2 || stories[0].Assignee != "ana@empresa.com" || stories[1].Assignee != "" {
+			t.Errorf("Column %s: unexpected assignees: %+v", column, stories)
+		}
+
+		excelStories, err := processor.storiesFromRows([][]string{
+			{"titulo", "descripcion", "criterio_aceptacion", column},
+			{"Story 1", "Description 1", "Criteria 1", "Ana Perez"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if excelStories[0].Assignee != "Ana Perez" {
+			t.Errorf("Column %s: Assignee = %q, want Ana Perez", column, excelStories[0].Assignee)
+		}
+	}
+}
+
+func TestFileProcessor_LabelsColumn(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for _, column := range []string{"labels", "etiquetas"} {
+		content := "titulo,descripcion,criterio_aceptacion," + column + "\nStory 1,Description 1,Criteria 1,\"backend, tech debt;backend\"\nStory 2,Description 2,Criteria 2,"
+		filePath := filepath.Join(tempDir, column+".csv")
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+
+		processor := NewFileProcessor(tempDir)
+		stories, err := processor.readCSV(filePath)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(stories) != 2 || strings.Join(stories[0].Labels, "|") != "backend|tech debt" || len(stories[1].Labels) != 0 {
+			t.Errorf("Column %s: unexpected labels: %+v", column, stories)
+		}
+
+		excelStories, err := processor.storiesFromRows([][]string{
+			{"titulo", "descripcion", "criterio_aceptacion", column},
+			{"Story 1", "Description 1", "Criteria 1", "ui;mobile"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if strings.Join(excelStories[0].Labels, "|") != "ui|mobile" {
+			t.Errorf("Column %s: Labels = %v, want [ui mobile]", column, excelStories[0].Labels)
+		}
+	}
+}
+
+func TestFileProcessor_PriorityColumn(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for _, column := range []string{"prioridad", "priority"} {
+		content := "titulo,descripcion,criterio_aceptacion," + column + "\nStory 1,Description 1,Criteria 1, High \nStory 2,Description 2,Criteria 2,"
+		filePath := filepath.Join(tempDir, column+".csv")
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+
+		processor := NewFileProcessor(tempDir)
+		stories, err := processor.readCSV(filePath)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(stories) != 2 || stories[0].Prioridad != "High" || stories[1].Prioridad != "" {
+			t.Errorf("Column %s: unexpected priorities: %+v", column, stories)
+		}
+
+		excelStories, err := processor.storiesFromRows([][]string{
+			{"titulo", "descripcion", "criterio_aceptacion", column},
+			{"Story 1", "Description 1", "Criteria 1", "Low"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if excelStories[0].Prioridad != "Low" {
+			t.Errorf("Column %s: Prioridad = %q, want Low", column, excelStories[0].Prioridad)
+		}
+	}
+}
+
+func TestFileProcessor_LinksColumn(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for _, column := range []string{"enlaces", "links"} {
+		content := "titulo,descripcion,criterio_aceptacion," + column + "\nStory 1,Description 1,Criteria 1,blocks:PROJ-10;relates:Story 2\nStory 2,Description 2,Criteria 2,"
+		filePath := filepath.Join(tempDir, column+".csv")
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+
+		processor := NewFileProcessor(tempDir)
+		stories, err := processor.readCSV(filePath)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		want := []entities.Link{{Type: "Blocks", Target: "PROJ-10"}, {Type: "Relates", Target: "Story 2"}}
+		if len(stories) != 2 || !reflect.DeepEqual(stories[0].Links, want) || len(stories[1].Links) != 0 {
+			t.Errorf("Column %s: unexpected links: %+v", column, stories)
+		}
+
+		excelStories, err := processor.storiesFromRows([][]string{
+			{"titulo", "descripcion", "criterio_aceptacion", column},
+			{"Story 1", "Description 1", "Criteria 1", "is blocked by:PROJ-3"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if want := []entities.Link{{Type: "Blocks", Target: "PROJ-3", Inward: true}}; !reflect.DeepEqual(excelStories[0].Links, want) {
+			t.Errorf("Column %s: Links = %+v, want %+v", column, excelStories[0].Links, want)
+		}
+	}
+}
+
+func TestFileProcessor_LinksColumn_Invalid(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Una fila con skip no se valida
+	content := "titulo,descripcion,criterio_aceptacion,skip,enlaces\nStory 1,Description 1,Criteria 1,x,PROJ-1\nStory 2,Description 2,Criteria 2,,PROJ-10\n"
+	filePath := filepath.Join(tempDir, "enlaces.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	processor := NewFileProcessor(tempDir)
+	if _, err := processor.readCSV(filePath); err == nil || !strings.Contains(err.Error(), "validation error in row 3: invalid link") {
+		t.Errorf("readCSV() error = %v, want an invalid link in row 3", err)
+	}
+
+	_, err := processor.storiesFromRows([][]string{
+		{"titulo", "descripcion", "criterio_aceptacion", "enlaces"},
+		{"Story 1", "Description 1", "Criteria 1", "blocks:"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "validation error in row 2: invalid link") {
+		t.Errorf("storiesFromRows() error = %v, want an invalid link in row 2", err)
+	}
+}
+
+func TestFileProcessor_ReadFile_URL(t *testing.T) {
+	content := `titulo,descripcion,criterio_aceptacion,subtareas
+Story 1,Description 1,Criteria 1,Task 1;Task 2
+Story 2,Description 2,Criteria 2,`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/artifacts/stories.csv" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	processor := NewFileProcessor(t.TempDir())
+	ctx := context.Background()
+
+	fileURL := server.URL + "/artifacts/stories.csv?token=abc"
+	if err := processor.ValidateFile(ctx, fileURL); err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+
+	stories, err := processor.ReadFile(ctx, fileURL)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if len(stories) != 2 {
+		t.Fatalf("Expected 2 stories, got %d", len(stories))
+	}
+	if stories[0].Titulo != "Story 1" || len(stories[0].Subtareas) != 2 {
+		t.Errorf("Unexpected first story: %+v", stories[0])
+	}
+
+	if _, err := processor.ReadFile(ctx, server.URL+"/missing.csv"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected status 404 error, got %v", err)
+	}
+	if _, err := processor.ReadFile(ctx, server.URL+"/stories.txt"); err == nil || !strings.Contains(err.Error(), "unsupported file format") {
+		t.Errorf("Expected unsupported format error, got %v", err)
+	}
+}
+
+func TestFileProcessor_DeriveSummaryFromDescription(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `titulo,descripcion,criterio_aceptacion
+,Permitir que el usuario recupere su contraseña por email,Criteria 1
+Titulo propio,Descripcion con titulo,Criteria 2
+,,Criteria 3`
+	filePath := filepath.Join(tempDir, "derive.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	processor := NewFileProcessor(tempDir)
+	stories, err := processor.readCSV(filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stories) != 1 || stories[0].Titulo != "Titulo propio" {
+		t.Fatalf("Expected rows without titulo to be dropped by default, got %d stories", len(stories))
+	}
+
+	processor.SetDeriveSummaryFromDescription(30)
+	stories, err = processor.readCSV(filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stories) != 2 {
+		t.Fatalf("Expected 2 stories (row without titulo nor descripcion dropped), got %d", len(stories))
+	}
+	if stories[0].Titulo != "Permitir que el usuario recupe" {
+		t.Errorf("Derived Titulo = %q, want first 30 characters of descripcion", stories[0].Titulo)
+	}
+	if stories[0].Descripcion != "Permitir que el usuario recupere su contraseña por email" {
+		t.Errorf("Descripcion should be unchanged, got %q", stories[0].Descripcion)
+	}
+	if stories[1].Titulo != "Titulo propio" {
+		t.Errorf("Titulo = %q, want the original title untouched", stories[1].Titulo)
+	}
+
+	rows := [][]string{
+		{"titulo", "descripcion", "criterio_aceptacion"},
+		{"", "Primera linea\nSegunda linea", "Criteria"},
+	}
+	excelStories, err := processor.storiesFromRows(rows)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(excelStories) != 1 || excelStories[0].Titulo != "Primera linea" {
+		t.Errorf("Expected the first description line as Titulo, got %+v", excelStories)
+	}
+}
+
+func TestFileProcessor_SetCommentChar_Custom(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `titulo,descripcion,criterio_aceptacion
+// nota interna
+Story 1,Description 1,Criteria 1`
+	filePath := filepath.Join(tempDir, "custom.csv")
+	os.WriteFile(filePath, []byte(content), 0644)
+
+	fp := NewFileProcessor(tempDir)
+	fp.SetCommentChar("/")
+
+	stories, err := fp.readCSV(filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stories) != 1 || stories[0].Titulo != "Story 1" {
+		t.Errorf("Expected only 'Story 1' to be parsed, got %d stories", len(stories))
+	}
+}
+
+func TestFileProcessor_SetRequiredFields_OnlyTitulo(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `titulo,descripcion,criterio_aceptacion
+Story 1,Description 1,Criteria 1
+Story 2,,
+,Description 3,Criteria 3`
+	filePath := filepath.Join(tempDir, "only_titulo.csv")
+	os.WriteFile(filePath, []byte(content), 0644)
+
+	// Con los campos por defecto la fila sin descripcion ni criterio se descarta
+	defaultFP := NewFileProcessor(tempDir)
+	stories, err := defaultFP.ReadFile(context.Background(), filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stories) != 1 {
+		t.Fatalf("Expected 1 story with default required fields, got %d", len(stories))
+	}
+
+	fp := NewFileProcessor(tempDir)
+	fp.SetRequiredFields([]string{"titulo"})
+
+	stories, err = fp.ReadFile(context.Background(), filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stories) != 2 {
+		t.Fatalf("Expected 2 stories when only titulo is required, got %d", len(stories))
+	}
+	if stories[1].Titulo != "Story 2" || stories[1].Descripcion != "" {
+		t.Errorf("Expected 'Story 2' with empty description, got %+v", stories[1])
+	}
+
+	if err := fp.ValidateFile(context.Background(), filePath); err != nil {
+		t.Errorf("Expected validation to accept rows with only titulo, got: %v", err)
+	}
+	if err := defaultFP.ValidateFile(context.Background(), filePath); err != nil {
+		t.Errorf("Expected default validation to skip incomplete rows, got: %v", err)
+	}
+}
+
+func TestFileProcessor_SetRequiredFields_Empty(t *testing.T) {
+	fp := NewFileProcessor(t.TempDir())
+	fp.SetRequiredFields(nil)
+
+	for _, field := range DefaultRequiredFields {
+		if !fp.requiredFields[field] {
+			t.Errorf("Expected %s to be required by default", field)
+		}
+	}
+}
+
 func TestFileProcessor_readExcel_ErrorPaths(t *testing.T) {
 	tempDir := t.TempDir()
 	fp := NewFileProcessor(tempDir)
@@ -0,0 +1,196 @@
+package filesystem
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+const odsContentFile = "content.xml"
+
+// maxODSRepeat limita las celdas/filas repetidas que se expanden; LibreOffice suele
+// rellenar el final de la hoja con miles de celdas vacias repetidas
+const maxODSRepeat = 1000
+
+type odsDocument struct {
+	Tables []odsTable `xml:"body>spreadsheet>table"`
+}
+
+type odsTable struct {
+	Name string   `xml:"name,attr"`
+	Rows []odsRow `xml:"table-row"`
+}
+
+type odsRow struct {
+	Repeated int       `xml:"number-rows-repeated,attr"`
+	Cells    []odsCell `xml:",any"`
+}
+
+type odsCell struct {
+	XMLName    xml.Name
+	Repeated   int       `xml:"number-columns-repeated,attr"`
+	Paragraphs []odsText `xml:"p"`
+}
+
+type odsText struct {
+	Content string `xml:",innerxml"`
+}
+
+func (fp *FileProcessor) readODS(filePath string) ([]*entities.UserStory, error) {
+	tables, err := readODSTables(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(tables))
+	byName := make(map[string]odsTable, len(tables))
+	for i, table := range tables {
+		names[i] = table.Name
+		byName[table.Name] = table
+	}
+
+	return fp.storiesFromSheets("ODS", names, func(sheetName string) ([][]string, error) {
+		return odsTableRows(byName[sheetName]), nil
+	})
+}
+
+// readODSRows devuelve las filas de la primera hoja de un archivo OpenDocument
+func readODSRows(filePath string) ([][]string, error) {
+	tables, err := readODSTables(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return odsTableRows(tables[0]), nil
+}
+
+// readODSTables devuelve las hojas de un archivo OpenDocument, en orden
+func readODSTables(filePath string) ([]odsTable, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening ODS file: %w", err)
+	}
+	defer archive.Close()
+
+	var content io.ReadCloser
+	for _, file := range archive.File {
+		if file.Name == odsContentFile {
+			content, err = file.Open()
+			if err != nil {
+				return nil, fmt.Errorf("error opening ODS content: %w", err)
+			}
+			break
+		}
+	}
+	if content == nil {
+		return nil, fmt.Errorf("error reading ODS file: %s not found", odsContentFile)
+	}
+	defer content.Close()
+
+	var doc odsDocument
+	if err := xml.NewDecoder(content).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error parsing ODS content: %w", err)
+	}
+
+	if len(doc.Tables) == 0 {
+		return nil, fmt.Errorf("ODS file has no sheets")
+	}
+
+	return doc.Tables, nil
+}
+
+// odsTableRows expande las filas repetidas de una hoja y descarta las vacias del final
+func odsTableRows(table odsTable) [][]string {
+	var rows [][]string
+	for _, row := range table.Rows {
+		values := odsRowValues(row)
+		for i := 0; i < repeatCount(row.Repeated); i++ {
+			rows = append(rows, values)
+		}
+	}
+
+	// Descartar filas vacias del final de la hoja
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+
+	return rows
+}
+
+func odsRowValues(row odsRow) []string {
+	var values []string
+	for _, cell := range row.Cells {
+		if cell.XMLName.Local != "table-cell" && cell.XMLName.Local != "covered-table-cell" {
+			continue
+		}
+
+		value := cell.text()
+		for i := 0; i < repeatCount(cell.Repeated); i++ {
+			values = append(values, value)
+		}
+	}
+
+	// Igual que excelize, no incluir celdas vacias al final de la fila
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+
+	return values
+}
+
+// text une los parrafos de la celda con salto de linea, como lo muestra la hoja
+func (c odsCell) text() string {
+	parts := make([]string, 0, len(c.Paragraphs))
+	for _, p := range c.Paragraphs {
+		parts = append(parts, odsInnerText(p.Content))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// odsInnerText extrae el texto plano de un parrafo, resolviendo espacios y tabs codificados
+func odsInnerText(inner string) string {
+	decoder := xml.NewDecoder(strings.NewReader("<p>" + inner + "</p>"))
+
+	var sb strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "s":
+				count := 1
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "c" {
+						fmt.Sscanf(attr.Value, "%d", &count)
+					}
+				}
+				sb.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				sb.WriteString("\t")
+			case "line-break":
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func repeatCount(repeated int) int {
+	if repeated < 1 {
+		return 1
+	}
+	if repeated > maxODSRepeat {
+		return maxODSRepeat
+	}
+	return repeated
+}
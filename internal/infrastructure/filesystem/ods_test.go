@@ -0,0 +1,193 @@
+package filesystem
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// createTestODSFile genera un .ods minimo con una hoja; cada "\n" en un valor se escribe
+// como un parrafo separado, igual que LibreOffice
+func createTestODSFile(filePath string, rows [][]string) error {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	sb.WriteString(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">`)
+	sb.WriteString(`<office:body><office:spreadsheet><table:table table:name="Hoja1">`)
+	for _, row := range rows {
+		sb.WriteString(`<table:table-row>`)
+		for _, value := range row {
+			if value == "" {
+				sb.WriteString(`<table:table-cell/>`)
+				continue
+			}
+			sb.WriteString(`<table:table-cell office:value-type="string">`)
+			for _, line := range strings.Split(value, "\n") {
+				sb.WriteString(`<text:p>`)
+				xml.EscapeText(&sb, []byte(line))
+				sb.WriteString(`</text:p>`)
+			}
+			sb.WriteString(`</table:table-cell>`)
+		}
+		// Relleno de celdas vacias que agregan las hojas de calculo reales
+		sb.WriteString(`<table:table-cell table:number-columns-repeated="1020"/>`)
+		sb.WriteString(`</table:table-row>`)
+	}
+	sb.WriteString(`<table:table-row table:number-rows-repeated="1048570"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>`)
+	sb.WriteString(`</table:table></office:spreadsheet></office:body></office:document-content>`)
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	mimetype, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	mimetype.Write([]byte("application/vnd.oasis.opendocument.spreadsheet"))
+
+	content, err := zw.Create(odsContentFile)
+	if err != nil {
+		return err
+	}
+	content.Write([]byte(sb.String()))
+
+	return zw.Close()
+}
+
+func TestFileProcessor_ReadODS_MatchesCSV(t *testing.T) {
+	tempDir := t.TempDir()
+	fp := NewFileProcessor(tempDir)
+
+	csvContent := `titulo,descripcion,criterio_aceptacion,subtareas,parent
+Story 1,Description 1,Criteria 1;Criteria 2,Task 1;Task 2,PROJ-123
+Story 2,Description 2,Criteria 2,,
+Story 3,"Description, with comma",Criteria 3,"Task A
+Task B",Feature Description`
+	csvPath := filepath.Join(tempDir, "stories.csv")
+	if err := os.WriteFile(csvPath, []byte(csvContent), 0644); err != nil {
+		t.Fatalf("Failed to create CSV file: %v", err)
+	}
+
+	odsPath := filepath.Join(tempDir, "stories.ods")
+	err := createTestODSFile(odsPath, [][]string{
+		{"titulo", "descripcion", "criterio_aceptacion", "subtareas", "parent"},
+		{"Story 1", "Description 1", "Criteria 1;Criteria 2", "Task 1;Task 2", "PROJ-123"},
+		{"Story 2", "Description 2", "Criteria 2", "", ""},
+		{"Story 3", "Description, with comma", "Criteria 3", "Task A\nTask B", "Feature Description"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create ODS file: %v", err)
+	}
+
+	csvStories, err := fp.ReadFile(context.Background(), csvPath)
+	if err != nil {
+		t.Fatalf("Expected no error reading CSV, got: %v", err)
+	}
+	odsStories, err := fp.ReadFile(context.Background(), odsPath)
+	if err != nil {
+		t.Fatalf("Expected no error reading ODS, got: %v", err)
+	}
+
+	if len(odsStories) != len(csvStories) {
+		t.Fatalf("Expected %d stories, got %d", len(csvStories), len(odsStories))
+	}
+
+	for i := range csvStories {
+		want, got := csvStories[i], odsStories[i]
+		if got.Titulo != want.Titulo || got.Descripcion != want.Descripcion ||
+			got.CriterioAceptacion != want.CriterioAceptacion || got.Parent != want.Parent {
+			t.Errorf("Story %d mismatch: want %+v, got %+v", i, want, got)
+		}
+		if strings.Join(got.Subtareas, "|") != strings.Join(want.Subtareas, "|") {
+			t.Errorf("Story %d subtasks mismatch: want %v, got %v", i, want.Subtareas, got.Subtareas)
+		}
+	}
+
+	if err := fp.ValidateFile(context.Background(), odsPath); err != nil {
+		t.Errorf("Expected ODS file to validate, got: %v", err)
+	}
+}
+
+func TestFileProcessor_ReadODS_ErrorPaths(t *testing.T) {
+	tempDir := t.TempDir()
+	fp := NewFileProcessor(tempDir)
+
+	corruptPath := filepath.Join(tempDir, "corrupt.ods")
+	os.WriteFile(corruptPath, []byte("not a zip"), 0644)
+
+	headerOnlyPath := filepath.Join(tempDir, "header_only.ods")
+	if err := createTestODSFile(headerOnlyPath, [][]string{{"titulo", "descripcion", "criterio_aceptacion"}}); err != nil {
+		t.Fatalf("Failed to create ODS file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		expectedErr string
+	}{
+		{"corrupt_file", corruptPath, "error opening ODS file"},
+		{"header_only", headerOnlyPath, "at least a header row and one data row"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fp.ReadFile(context.Background(), tt.path)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestFileProcessor_ReadODS_SheetSelection(t *testing.T) {
+	tempDir := t.TempDir()
+	odsPath := filepath.Join(tempDir, "backlog.ods")
+	err := createTestODSFile(odsPath, [][]string{
+		{"titulo", "descripcion", "criterio_aceptacion"},
+		{"Login", "Desc login", "Crit login"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create ODS file: %v", err)
+	}
+
+	fp := NewFileProcessor(tempDir)
+	for _, sheet := range []string{"hoja1", "1", AllSheets} {
+		fp.SetSheet(sheet)
+		stories, err := fp.ReadFile(context.Background(), odsPath)
+		if err != nil || len(stories) != 1 {
+			t.Errorf("Sheet %q: expected 1 story, got %d (%v)", sheet, len(stories), err)
+		}
+	}
+
+	fp.SetSheet("Backlog")
+	if _, err := fp.ReadFile(context.Background(), odsPath); err == nil || !strings.Contains(err.Error(), `sheet "Backlog" not found (available sheets: Hoja1)`) {
+		t.Errorf("Expected an error listing the sheets, got %v", err)
+	}
+}
+
+func TestOdsInnerText(t *testing.T) {
+	tests := []struct {
+		inner    string
+		expected string
+	}{
+		{"plain", "plain"},
+		{`a<text:s text:c="3"/>b`, "a   b"},
+		{`a<text:s/>b`, "a b"},
+		{`a<text:tab/>b`, "a\tb"},
+		{`<text:span>bold</text:span> text`, "bold text"},
+		{"&amp; &lt;tag&gt;", "& <tag>"},
+	}
+
+	for _, tt := range tests {
+		if result := odsInnerText(tt.inner); result != tt.expected {
+			t.Errorf("odsInnerText(%q) = %q, want %q", tt.inner, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// resultsTimestampLayout ordena los archivos de resultados por fecha de ejecucion
+const resultsTimestampLayout = "20060102-150405"
+
+// ResultsStore escribe un results.json por archivo procesado con el BatchResult completo
+type ResultsStore struct {
+	dir string
+}
+
+func NewResultsStore(dir string) *ResultsStore {
+	return &ResultsStore{
+		dir: dir,
+	}
+}
+
+// Save escribe el lote en <archivo>_<timestamp>.results.json; en dry-run el nombre lleva
+// _dry-run para no confundirlo con una ejecucion real. Un lote con ResultsFile (--resume)
+// reescribe ese archivo
+func (rs *ResultsStore) Save(ctx context.Context, result *entities.BatchResult) (string, error) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding results file: %w", err)
+	}
+
+	path := result.ResultsFile
+	if path == "" {
+		path = filepath.Join(rs.dir, resultsFileName(result))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("error creating results directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("error writing results file: %w", err)
+	}
+
+	return path, nil
+}
+
+// Load lee un results.json; ResultsFile queda con path para que Save lo reescriba
+func (rs *ResultsStore) Load(ctx context.Context, path string) (*entities.BatchResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading results file: %w", err)
+	}
+
+	var result entities.BatchResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("error parsing results file %s: %w", path, err)
+	}
+	result.ResultsFile = path
+
+	return &result, nil
+}
+
+func resultsFileName(result *entities.BatchResult) string {
+	stem := strings.TrimSuffix(result.FileName, filepath.Ext(result.FileName))
+	if stem == "" {
+		stem = "resultados"
+	}
+
+	name := fmt.Sprintf("%s_%s", stem, result.StartTime.Format(resultsTimestampLayout))
+	if result.DryRun {
+		name += "_dry-run"
+	}
+	return name + ".results.json"
+}
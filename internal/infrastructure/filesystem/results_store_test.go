@@ -0,0 +1,115 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+func TestResultsStore_Save(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "logs", "results")
+	store := NewResultsStore(dir)
+
+	result := entities.NewBatchResult("sprint.csv", 2, false)
+	result.StartTime = time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	created := entities.NewProcessResult(2)
+	created.Success = true
+	created.IssueKey = "PROJ-1"
+	created.AddSubtaskResult("Subtarea", true, "PROJ-2", "", "")
+	result.AddResult(created)
+	failed := entities.NewProcessResult(3)
+	failed.ErrorMessage = "field required"
+	result.AddResult(failed)
+	result.Finish()
+
+	path, err := store.Save(ctx, result)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if want := filepath.Join(dir, "sprint_20240305-143000.results.json"); path != want {
+		t.Errorf("Save() path = %s, want %s", path, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read results file: %v", err)
+	}
+	var saved entities.BatchResult
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("Results file is not valid JSON: %v", err)
+	}
+	if saved.DryRun || len(saved.Results) != 2 || saved.Results[0].IssueKey != "PROJ-1" {
+		t.Errorf("Unexpected saved result: %+v", saved)
+	}
+	if saved.Results[0].Subtareas[0].IssueKey != "PROJ-2" || saved.Results[1].ErrorMessage != "field required" {
+		t.Errorf("Expected subtask keys and failures in the results file, got: %s", data)
+	}
+
+	dryRun := entities.NewBatchResult("sprint.csv", 0, true)
+	dryRun.StartTime = result.StartTime
+	path, err = store.Save(ctx, dryRun)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if !strings.HasSuffix(path, "sprint_20240305-143000_dry-run.results.json") {
+		t.Errorf("Dry-run results file should be flagged in its name, got %s", path)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), `"dry_run": true`) {
+		t.Errorf("Dry-run results file should be flagged in its content, got: %s", data)
+	}
+}
+
+func TestResultsStore_Load(t *testing.T) {
+	ctx := context.Background()
+	store := NewResultsStore(t.TempDir())
+
+	result := entities.NewBatchResult("sprint.csv", 1, false)
+	result.AddResult(&entities.ProcessResult{Success: true, IssueKey: "PROJ-1", RowNumber: 2, RowHash: "4f2a"})
+	path, err := store.Save(ctx, result)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load(ctx, path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.ResultsFile != path || loaded.FileName != "sprint.csv" || loaded.Results[0].RowHash != "4f2a" {
+		t.Errorf("Unexpected loaded result: %+v", loaded)
+	}
+
+	// Un lote cargado se reescribe en el mismo archivo
+	loaded.AddResult(&entities.ProcessResult{Success: true, IssueKey: "PROJ-2", RowNumber: 3})
+	rewritten, err := store.Save(ctx, loaded)
+	if err != nil || rewritten != path {
+		t.Fatalf("Save() = %s, %v; want %s", rewritten, err, path)
+	}
+	if loaded, _ = store.Load(ctx, path); len(loaded.Results) != 2 {
+		t.Errorf("Expected the rewritten file to have 2 results, got %d", len(loaded.Results))
+	}
+
+	if _, err := store.Load(ctx, filepath.Join(t.TempDir(), "missing.json")); err == nil || !strings.Contains(err.Error(), "error reading results file") {
+		t.Errorf("Expected a read error, got %v", err)
+	}
+}
+
+func TestResultsStore_Save_UnwritableDirectory(t *testing.T) {
+	// Un archivo en lugar del directorio impide crearlo
+	blocker := filepath.Join(t.TempDir(), "results")
+	if err := os.WriteFile(blocker, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to create blocker file: %v", err)
+	}
+
+	_, err := NewResultsStore(blocker).Save(context.Background(), entities.NewBatchResult("sprint.csv", 0, false))
+	if err == nil || !strings.Contains(err.Error(), "error creating results directory") {
+		t.Errorf("Expected a directory error, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package filesystem
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// RunHistory guarda un resumen por ejecucion en un archivo local, una linea JSON por entrada
+type RunHistory struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewRunHistory(path string) *RunHistory {
+	return &RunHistory{
+		path: path,
+	}
+}
+
+func (rh *RunHistory) Append(ctx context.Context, summary *entities.RunSummary) error {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("error encoding history entry: %w", err)
+	}
+
+	if dir := filepath.Dir(rh.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("error creating history directory: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(rh.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening history file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("error writing history file: %w", err)
+	}
+
+	return nil
+}
+
+// List devuelve las ejecuciones registradas en el orden en que se agregaron
+func (rh *RunHistory) List(ctx context.Context) ([]*entities.RunSummary, error) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	file, err := os.Open(rh.path)
+	if os.IsNotExist(err) {
+		return []*entities.RunSummary{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading history file: %w", err)
+	}
+	defer file.Close()
+
+	summaries := make([]*entities.RunSummary, 0)
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var summary entities.RunSummary
+		if err := json.Unmarshal(line, &summary); err != nil {
+			return nil, fmt.Errorf("error parsing history file %s line %d: %w", rh.path, lineNumber, err)
+		}
+		summaries = append(summaries, &summary)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading history file: %w", err)
+	}
+
+	return summaries, nil
+}
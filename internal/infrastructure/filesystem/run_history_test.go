@@ -0,0 +1,83 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+func TestRunHistory_AppendAndList(t *testing.T) {
+	ctx := context.Background()
+	historyPath := filepath.Join(t.TempDir(), "state", "history.jsonl")
+	history := NewRunHistory(historyPath)
+
+	summaries, err := history.List(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error on missing history file, got: %v", err)
+	}
+	if len(summaries) != 0 {
+		t.Errorf("Expected empty history, got %d entries", len(summaries))
+	}
+
+	first := &entities.RunSummary{
+		Date:           time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		FileName:       "sprint1.csv",
+		TotalRows:      10,
+		SuccessfulRows: 10,
+		SuccessRate:    100,
+		Duration:       3 * time.Second,
+	}
+	second := &entities.RunSummary{
+		Date:           time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+		FileName:       "sprint2.xlsx",
+		TotalRows:      4,
+		SuccessfulRows: 3,
+		ErrorRows:      1,
+		SuccessRate:    75,
+		Duration:       1500 * time.Millisecond,
+		DryRun:         true,
+	}
+
+	if err := history.Append(ctx, first); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	// Una nueva instancia debe seguir agregando sobre el mismo archivo
+	if err := NewRunHistory(historyPath).Append(ctx, second); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	summaries, err = NewRunHistory(historyPath).List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(summaries))
+	}
+
+	if summaries[0].FileName != "sprint1.csv" || summaries[1].FileName != "sprint2.xlsx" {
+		t.Errorf("Expected entries in append order, got %s, %s", summaries[0].FileName, summaries[1].FileName)
+	}
+	if summaries[1].SuccessRate != 75 || summaries[1].Duration != 1500*time.Millisecond || !summaries[1].DryRun {
+		t.Errorf("Expected second entry to round-trip, got %+v", summaries[1])
+	}
+	if !summaries[0].Date.Equal(first.Date) {
+		t.Errorf("Expected date %v, got %v", first.Date, summaries[0].Date)
+	}
+}
+
+func TestRunHistory_CorruptFile(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+	if err := os.WriteFile(historyPath, []byte("{not json}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write history file: %v", err)
+	}
+
+	_, err := NewRunHistory(historyPath).List(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected parse error with line number, got: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"historiadorgo/internal/domain/entities"
+
+	"gopkg.in/yaml.v3"
+)
+
+// structuredStory es un elemento del array de historias de un archivo JSON o YAML. Usa los
+// nombres de las columnas del CSV, pero subtareas, labels y enlaces son listas; los campos
+// desconocidos se ignoran igual que las columnas desconocidas
+type structuredStory struct {
+	Titulo             string   `json:"titulo" yaml:"titulo"`
+	Descripcion        string   `json:"descripcion" yaml:"descripcion"`
+	CriterioAceptacion string   `json:"criterio_aceptacion" yaml:"criterio_aceptacion"`
+	Subtareas          []string `json:"subtareas" yaml:"subtareas"`
+	Parent             string   `json:"parent" yaml:"parent"`
+	SubtaskType        string   `json:"subtask_type" yaml:"subtask_type"`
+	Skip               bool     `json:"skip" yaml:"skip"`
+	Environment        string   `json:"environment" yaml:"environment"`
+	SubtasksFile       string   `json:"subtasks_file" yaml:"subtasks_file"`
+	Assignee           string   `json:"asignado" yaml:"asignado"`
+	Labels             []string `json:"labels" yaml:"labels"`
+	Prioridad          string   `json:"prioridad" yaml:"prioridad"`
+	Enlaces            []string `json:"enlaces" yaml:"enlaces"`
+}
+
+func (fp *FileProcessor) readJSON(filePath string) ([]*entities.UserStory, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening JSON file: %w", err)
+	}
+
+	// Cada elemento se decodifica aparte para que el error indique su posicion
+	var elements []json.RawMessage
+	if err := json.Unmarshal(decodeToUTF8(data, fp.inputEncoding), &elements); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: expected an array of stories: %w", err)
+	}
+
+	items := make([]structuredStory, len(elements))
+	for i, element := range elements {
+		if err := json.Unmarshal(element, &items[i]); err != nil {
+			return nil, fmt.Errorf("error parsing JSON at index %d: %w", i, err)
+		}
+	}
+
+	return fp.storiesFromItems(items)
+}
+
+func (fp *FileProcessor) readYAML(filePath string) ([]*entities.UserStory, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening YAML file: %w", err)
+	}
+
+	var nodes []yaml.Node
+	if err := yaml.Unmarshal(decodeToUTF8(data, fp.inputEncoding), &nodes); err != nil {
+		return nil, fmt.Errorf("error parsing YAML: expected a list of stories: %w", err)
+	}
+
+	items := make([]structuredStory, len(nodes))
+	for i := range nodes {
+		if err := nodes[i].Decode(&items[i]); err != nil {
+			return nil, fmt.Errorf("error parsing YAML at index %d: %w", i, err)
+		}
+	}
+
+	return fp.storiesFromItems(items)
+}
+
+// storiesFromItems convierte los elementos de un archivo JSON o YAML en historias. A diferencia
+// de las filas de un CSV, un elemento sin los campos obligatorios es un error con su posicion
+func (fp *FileProcessor) storiesFromItems(items []structuredStory) ([]*entities.UserStory, error) {
+	stories := make([]*entities.UserStory, 0, len(items))
+	for i, item := range items {
+		record := &CSVRecord{
+			Titulo:             strings.TrimSpace(item.Titulo),
+			Descripcion:        strings.TrimSpace(item.Descripcion),
+			CriterioAceptacion: strings.TrimSpace(item.CriterioAceptacion),
+		}
+		fp.deriveSummary(record)
+
+		story := entities.NewUserStory(
+			record.Titulo,
+			record.Descripcion,
+			record.CriterioAceptacion,
+			"",
+			strings.TrimSpace(item.Parent),
+		)
+		for _, subtask := range item.Subtareas {
+			if trimmed := strings.TrimSpace(subtask); trimmed != "" {
+				story.Subtareas = append(story.Subtareas, trimmed)
+			}
+		}
+		story.SubtaskType = strings.TrimSpace(item.SubtaskType)
+		story.Environment = strings.TrimSpace(item.Environment)
+		story.SubtasksFile = strings.TrimSpace(item.SubtasksFile)
+		story.Assignee = strings.TrimSpace(item.Assignee)
+		story.Labels = entities.ParseLabels(strings.Join(item.Labels, ","))
+		story.Prioridad = strings.TrimSpace(item.Prioridad)
+		story.Skip = item.Skip
+
+		if !story.Skip {
+			if err := fp.validateStory(story); err != nil {
+				return nil, fmt.Errorf("validation error at index %d: %w", i, err)
+			}
+			links, err := entities.ParseLinks(joinLinks(item.Enlaces))
+			if err != nil {
+				return nil, fmt.Errorf("validation error at index %d: %w", i, err)
+			}
+			story.Links = links
+		}
+
+		stories = append(stories, story)
+	}
+
+	return stories, nil
+}
+
+// joinLinks une los enlaces de la lista escapando el separador, asi un titulo con ";" sigue
+// siendo un solo enlace
+func joinLinks(links []string) string {
+	escaped := make([]string, len(links))
+	for i, link := range links {
+		escaped[i] = strings.ReplaceAll(link, entities.LinkSeparator, entities.MultiValueEscape+entities.LinkSeparator)
+	}
+	return strings.Join(escaped, entities.LinkSeparator)
+}
@@ -0,0 +1,171 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// expectedStructuredStories son las historias de los archivos JSON y YAML de prueba
+func expectedStructuredStories() []*entities.UserStory {
+	login := entities.NewUserStory("Login", "Como usuario quiero ingresar", "Ingresa con email; Valida password", "", "PROJ-10")
+	login.Subtareas = []string{"Formulario", "API; con punto y coma"}
+	login.Labels = []string{"auth", "web"}
+	login.Prioridad = "High"
+	login.Links = []entities.Link{{Type: "Blocks", Target: "Logout"}, {Type: "Relates", Target: "Perfil; datos"}}
+
+	logout := entities.NewUserStory("Logout", "Como usuario quiero salir", "Cierra la sesion", "", "Gestion de usuarios")
+
+	borrador := entities.NewUserStory("Borrador", "", "", "", "")
+	borrador.Skip = true
+
+	return []*entities.UserStory{login, logout, borrador}
+}
+
+func TestFileProcessor_ReadStructuredFiles(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"stories.json": `[
+  {
+    "titulo": "Login",
+    "descripcion": "Como usuario quiero ingresar",
+    "criterio_aceptacion": "Ingresa con email; Valida password",
+    "subtareas": ["Formulario", " API; con punto y coma ", ""],
+    "parent": "PROJ-10",
+    "labels": ["auth", "web"],
+    "prioridad": "High",
+    "enlaces": ["blocks:Logout", "relates:Perfil; datos"]
+  },
+  {
+    "titulo": "Logout",
+    "descripcion": "Como usuario quiero salir",
+    "criterio_aceptacion": "Cierra la sesion",
+    "parent": "Gestion de usuarios",
+    "comentario": "los campos desconocidos se ignoran"
+  },
+  {"titulo": "Borrador", "skip": true}
+]`,
+		"stories.yaml": `- titulo: Login
+  descripcion: Como usuario quiero ingresar
+  criterio_aceptacion: Ingresa con email; Valida password
+  subtareas:
+    - Formulario
+    - "API; con punto y coma"
+  parent: PROJ-10
+  labels: [auth, web]
+  prioridad: High
+  enlaces:
+    - blocks:Logout
+    - "relates:Perfil; datos"
+- titulo: Logout
+  descripcion: Como usuario quiero salir
+  criterio_aceptacion: Cierra la sesion
+  parent: Gestion de usuarios
+- titulo: Borrador
+  skip: true
+`,
+	}
+	files["stories.yml"] = files["stories.yaml"]
+
+	fp := NewFileProcessor(tempDir)
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, name)
+			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			stories, err := fp.ReadFile(context.Background(), filePath)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			if want := expectedStructuredStories(); !reflect.DeepEqual(stories, want) {
+				for i := range stories {
+					t.Logf("story %d = %+v", i, stories[i])
+				}
+				t.Errorf("ReadFile() did not return the expected stories")
+			}
+
+			if err := fp.ValidateFile(context.Background(), filePath); err != nil {
+				t.Errorf("ValidateFile() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFileProcessor_ReadStructuredFiles_Errors(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "json_not_an_array",
+			file:    "object.json",
+			content: `{"titulo": "Login"}`,
+			wantErr: "expected an array of stories",
+		},
+		{
+			name: "json_missing_required_field",
+			file: "missing.json",
+			content: `[
+  {"titulo": "Login", "descripcion": "Desc", "criterio_aceptacion": "Crit"},
+  {"titulo": "Logout", "descripcion": "Desc"}
+]`,
+			wantErr: "validation error at index 1",
+		},
+		{
+			name:    "json_subtareas_not_a_list",
+			file:    "subtareas.json",
+			content: `[{"titulo": "Login", "descripcion": "Desc", "criterio_aceptacion": "Crit", "subtareas": "A;B"}]`,
+			wantErr: "error parsing JSON at index 0",
+		},
+		{
+			name:    "json_invalid_link",
+			file:    "enlaces.json",
+			content: `[{"titulo": "Login", "descripcion": "Desc", "criterio_aceptacion": "Crit", "enlaces": ["PROJ-10"]}]`,
+			wantErr: "validation error at index 0: invalid link",
+		},
+		{
+			name:    "yaml_not_a_list",
+			file:    "mapping.yaml",
+			content: "titulo: Login\n",
+			wantErr: "expected a list of stories",
+		},
+		{
+			name: "yaml_missing_required_field",
+			file: "missing.yml",
+			content: `- titulo: Login
+  descripcion: Desc
+  criterio_aceptacion: Crit
+- titulo: Logout
+  criterio_aceptacion: Crit
+`,
+			wantErr: "validation error at index 1",
+		},
+	}
+
+	fp := NewFileProcessor(tempDir)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, tt.file)
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			_, err := fp.ReadFile(context.Background(), filePath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ReadFile() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package filesystem
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"historiadorgo/internal/domain/entities"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// subtaskColumns son los encabezados aceptados para la columna de subtareas del archivo
+// referenciado; si no hay ninguno se usa la primera columna
+var subtaskColumns = []string{"subtarea", "subtareas", "titulo"}
+
+// attachSubtasksFiles agrega a cada historia con columna subtasks_file las subtareas
+// del archivo referenciado, a continuacion de las de la columna subtareas
+func (fp *FileProcessor) attachSubtasksFiles(stories []*entities.UserStory, baseDir string) error {
+	for _, story := range stories {
+		if story.SubtasksFile == "" || story.Skip {
+			continue
+		}
+
+		path := story.SubtasksFile
+		if !filepath.IsAbs(path) {
+			if baseDir == "" {
+				return fmt.Errorf("subtasks_file %q of story %q cannot be resolved for a remote source", path, story.Titulo)
+			}
+			path = filepath.Join(baseDir, path)
+		}
+
+		subtasks, err := readSubtasksFile(path, fp.inputEncoding)
+		if err != nil {
+			return fmt.Errorf("error reading subtasks_file of story %q: %w", story.Titulo, err)
+		}
+
+		story.Subtareas = append(story.Subtareas, subtasks...)
+	}
+
+	return nil
+}
+
+// readSubtasksFile devuelve una subtarea por fila no vacia del archivo (CSV, Excel u ODS);
+// encoding se aplica a los CSV como en el archivo de entrada
+func readSubtasksFile(path, encoding string) ([]string, error) {
+	var (
+		rows [][]string
+		err  error
+	)
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case csvExtension:
+		rows, err = readCSVRows(path, encoding)
+	case xlsxExtension, xlsExtension:
+		rows, err = readExcelRows(path)
+	case odsExtension:
+		rows, err = readODSRows(path)
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s", ext)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	column := subtaskColumn(rows[0])
+
+	var subtasks []string
+	for _, row := range rows[1:] {
+		if column < len(row) {
+			if subtask := strings.TrimSpace(row[column]); subtask != "" {
+				subtasks = append(subtasks, subtask)
+			}
+		}
+	}
+
+	return subtasks, nil
+}
+
+// subtaskColumn devuelve el indice de la columna de subtareas segun el encabezado
+func subtaskColumn(header []string) int {
+	for _, name := range subtaskColumns {
+		for i, col := range header {
+			if strings.EqualFold(strings.TrimSpace(col), name) {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+func readCSVRows(path, encoding string) ([][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening CSV file: %w", err)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(decodeToUTF8(data, encoding)))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CSV: %w", err)
+	}
+
+	return rows, nil
+}
+
+func readExcelRows(path string) ([][]string, error) {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening Excel file: %w", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(f.GetSheetName(0))
+	if err != nil {
+		return nil, fmt.Errorf("error reading Excel rows: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,73 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileProcessor_ReadFile_SubtasksFile(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, "subtareas"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	subtasks := "subtarea,estimacion\nDiseñar pantalla,3\n,\nImplementar API,5\nEscribir tests,2\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "subtareas", "login.csv"), []byte(subtasks), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	content := `titulo,descripcion,criterio_aceptacion,subtareas,subtasks_file
+Login,Pantalla de login,Funciona,Revisar UX,subtareas/login.csv
+Logout,Cerrar sesion,Funciona,Task 1,`
+	filePath := filepath.Join(tempDir, "historias.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stories, err := NewFileProcessor(tempDir).ReadFile(context.Background(), filePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	want := []string{"Revisar UX", "Diseñar pantalla", "Implementar API", "Escribir tests"}
+	if strings.Join(stories[0].Subtareas, "|") != strings.Join(want, "|") {
+		t.Errorf("Subtareas = %v, want %v", stories[0].Subtareas, want)
+	}
+	if len(stories[1].Subtareas) != 1 {
+		t.Errorf("Expected story without subtasks_file to keep its subtasks, got %v", stories[1].Subtareas)
+	}
+}
+
+func TestFileProcessor_ReadFile_SubtasksFileErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "titulo,descripcion,criterio_aceptacion,subtasks_file\nLogin,Pantalla,Funciona,falta.csv\n"
+	filePath := filepath.Join(tempDir, "historias.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewFileProcessor(tempDir).ReadFile(context.Background(), filePath)
+	if err == nil || !strings.Contains(err.Error(), "subtasks_file") {
+		t.Errorf("Expected subtasks_file error for a missing file, got %v", err)
+	}
+}
+
+func TestSubtaskColumn(t *testing.T) {
+	tests := []struct {
+		header []string
+		want   int
+	}{
+		{[]string{"estimacion", "Subtarea"}, 1},
+		{[]string{"id", "titulo"}, 1},
+		{[]string{"nombre", "horas"}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := subtaskColumn(tt.header); got != tt.want {
+			t.Errorf("subtaskColumn(%v) = %d, want %d", tt.header, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package jira
 
 import (
+	"encoding/json"
 	"strings"
+
+	"historiadorgo/internal/domain/entities"
 )
 
 // ADFDocument representa un documento en formato Atlassian Document Format
@@ -17,6 +20,21 @@ type ADFContent struct {
 	Content []ADFText `json:"content,omitempty"`
 	Text    string    `json:"text,omitempty"`
 	Marks   []ADFMark `json:"marks,omitempty"`
+	// Items guarda los nodos hijos de bloques anidados (bulletList, listItem)
+	Items []ADFContent `json:"-"`
+}
+
+// MarshalJSON serializa Items como "content" en los bloques anidados
+func (c ADFContent) MarshalJSON() ([]byte, error) {
+	type plain ADFContent
+	if len(c.Items) == 0 {
+		return json.Marshal(plain(c))
+	}
+
+	return json.Marshal(struct {
+		Type    string       `json:"type"`
+		Content []ADFContent `json:"content"`
+	}{Type: c.Type, Content: c.Items})
 }
 
 // ADFText representa texto dentro del contenido ADF
@@ -24,6 +42,27 @@ type ADFText struct {
 	Type  string    `json:"type"`
 	Text  string    `json:"text"`
 	Marks []ADFMark `json:"marks,omitempty"`
+	// Attrs solo se usa en los nodos mention
+	Attrs *ADFMentionAttrs `json:"attrs,omitempty"`
+}
+
+// ADFMentionAttrs identifica al usuario mencionado en un nodo mention
+type ADFMentionAttrs struct {
+	ID   string `json:"id"`
+	Text string `json:"text,omitempty"`
+}
+
+// MarshalJSON serializa los nodos mention sin "text", que Jira no acepta en ese tipo de nodo
+func (t ADFText) MarshalJSON() ([]byte, error) {
+	type plain ADFText
+	if t.Type != "mention" {
+		return json.Marshal(plain(t))
+	}
+
+	return json.Marshal(struct {
+		Type  string           `json:"type"`
+		Attrs *ADFMentionAttrs `json:"attrs"`
+	}{Type: t.Type, Attrs: t.Attrs})
 }
 
 // ADFMark representa marcado de texto (bold, italic, etc.)
@@ -63,32 +102,83 @@ func (doc *ADFDocument) AddBulletList(items []string) {
 	}
 }
 
-// CreateAcceptanceCriteriaADF crea un documento ADF para criterios de aceptación
-func CreateAcceptanceCriteriaADF(criteriaText string) *ADFDocument {
-	doc := NewADFDocument()
+// AddNativeBulletList agrega una lista ADF real (bulletList con un listItem por elemento)
+func (doc *ADFDocument) AddNativeBulletList(items []string) {
+	list := ADFContent{Type: "bulletList"}
+	for _, item := range items {
+		text := strings.TrimSpace(item)
+		if text == "" {
+			continue
+		}
+		paragraph := ADFContent{
+			Type:    "paragraph",
+			Content: []ADFText{{Type: "text", Text: text}},
+		}
+		list.Items = append(list.Items, ADFContent{Type: "listItem", Items: []ADFContent{paragraph}})
+	}
 
-	if criteriaText == "" {
-		return doc
+	if len(list.Items) > 0 {
+		doc.Content = append(doc.Content, list)
 	}
+}
 
-	// Dividir criterios por ';' o por líneas
+// addCriteria agrega los criterios como párrafo, viñetas de texto o lista ADF según asList
+func (doc *ADFDocument) addCriteria(criteriaText string, asList bool) {
 	criteria := splitCriteria(criteriaText)
 
-	if len(criteria) == 1 {
+	switch {
+	case asList:
+		doc.AddNativeBulletList(criteria)
+	case len(criteria) == 1:
 		// Un solo criterio - agregar como párrafo simple
 		doc.AddParagraph(criteria[0])
-	} else {
+	default:
 		// Múltiples criterios - agregar como lista con bullets
 		doc.AddBulletList(criteria)
 	}
+}
+
+// CreateAcceptanceCriteriaADF crea un documento ADF para criterios de aceptación
+func CreateAcceptanceCriteriaADF(criteriaText string) *ADFDocument {
+	return CreateAcceptanceCriteriaListADF(criteriaText, false)
+}
+
+// CreateAcceptanceCriteriaListADF igual que CreateAcceptanceCriteriaADF; con asList cada
+// cláusula (separada por ';' o por líneas) es un elemento de una lista ADF
+func CreateAcceptanceCriteriaListADF(criteriaText string, asList bool) *ADFDocument {
+	doc := NewADFDocument()
+
+	if criteriaText == "" {
+		return doc
+	}
+
+	doc.addCriteria(criteriaText, asList)
 
 	return doc
 }
 
+// DefaultCriteriaHeading es el título de la sección de criterios cuando no se configura CRITERIA_HEADING
+const DefaultCriteriaHeading = "Criterios de Aceptación"
+
 // CreateDescriptionWithCriteriaADF crea un documento ADF para descripción con criterios incluidos
 func CreateDescriptionWithCriteriaADF(description, criteriaText string) *ADFDocument {
+	return CreateDescriptionWithCriteriaHeadingADF(description, criteriaText, DefaultCriteriaHeading)
+}
+
+// CreateDescriptionWithCriteriaHeadingADF igual que CreateDescriptionWithCriteriaADF pero con el título de la sección configurable
+func CreateDescriptionWithCriteriaHeadingADF(description, criteriaText, heading string) *ADFDocument {
+	return CreateDescriptionWithCriteriaListADF(description, criteriaText, heading, false)
+}
+
+// CreateDescriptionWithCriteriaListADF igual que CreateDescriptionWithCriteriaHeadingADF; con asList
+// los criterios se agregan como lista ADF en lugar de párrafos con viñetas
+func CreateDescriptionWithCriteriaListADF(description, criteriaText, heading string, asList bool) *ADFDocument {
 	doc := NewADFDocument()
 
+	if strings.TrimSpace(heading) == "" {
+		heading = DefaultCriteriaHeading
+	}
+
 	// Agregar descripción principal
 	if description != "" {
 		doc.AddParagraph(description)
@@ -98,15 +188,9 @@ func CreateDescriptionWithCriteriaADF(description, criteriaText string) *ADFDocu
 	if criteriaText != "" {
 		// Agregar separador
 		doc.AddParagraph("")
-		doc.AddParagraph("--- Criterios de Aceptación ---")
+		doc.AddParagraph("--- " + strings.TrimSpace(heading) + " ---")
 
-		criteria := splitCriteria(criteriaText)
-
-		if len(criteria) == 1 {
-			doc.AddParagraph(criteria[0])
-		} else {
-			doc.AddBulletList(criteria)
-		}
+		doc.addCriteria(criteriaText, asList)
 	}
 
 	return doc
@@ -127,14 +211,9 @@ func CreateDescriptionADF(description string) *ADFDocument {
 func splitCriteria(criteriaText string) []string {
 	var criteria []string
 
-	// Primero intentar dividir por ';'
+	// Primero intentar dividir por ';' (respetando "\;" como punto y coma literal)
 	if strings.Contains(criteriaText, ";") {
-		parts := strings.Split(criteriaText, ";")
-		for _, part := range parts {
-			if trimmed := strings.TrimSpace(part); trimmed != "" {
-				criteria = append(criteria, trimmed)
-			}
-		}
+		criteria = entities.SplitMultiValue(criteriaText, ";")
 	} else {
 		// Si no hay ';', dividir por líneas
 		lines := strings.Split(criteriaText, "\n")
@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -257,6 +258,85 @@ func TestCreateDescriptionWithCriteriaADF(t *testing.T) {
 	}
 }
 
+func TestCreateDescriptionWithCriteriaHeadingADF(t *testing.T) {
+	tests := []struct {
+		name           string
+		heading        string
+		expectedHeader string
+	}{
+		{"custom_heading", "Acceptance Criteria", "--- Acceptance Criteria ---"},
+		{"empty_heading_uses_default", "  ", "--- Criterios de Aceptación ---"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := CreateDescriptionWithCriteriaHeadingADF("Test description", "Criteria 1; Criteria 2", tt.heading)
+
+			found := false
+			for _, content := range doc.Content {
+				if len(content.Content) > 0 && content.Content[0].Text == tt.expectedHeader {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Expected to find header %q in ADF", tt.expectedHeader)
+			}
+		})
+	}
+}
+
+func TestCreateAcceptanceCriteriaListADF(t *testing.T) {
+	doc := CreateAcceptanceCriteriaListADF("Login works; Logout works ;  ; Error is shown", true)
+
+	if len(doc.Content) != 1 || doc.Content[0].Type != "bulletList" {
+		t.Fatalf("Expected a single bulletList node, got %+v", doc.Content)
+	}
+
+	items := doc.Content[0].Items
+	expected := []string{"Login works", "Logout works", "Error is shown"}
+	if len(items) != len(expected) {
+		t.Fatalf("Expected %d list items, got %d", len(expected), len(items))
+	}
+	for i, item := range items {
+		if item.Type != "listItem" {
+			t.Errorf("Item %d: expected type 'listItem', got %s", i, item.Type)
+		}
+		if len(item.Items) != 1 || item.Items[0].Type != "paragraph" || item.Items[0].Content[0].Text != expected[i] {
+			t.Errorf("Item %d: expected paragraph %q, got %+v", i, expected[i], item.Items)
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal ADF: %v", err)
+	}
+	want := `{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"Login works"}]}]}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Expected nested listItem JSON %s, got %s", want, data)
+	}
+
+	single := CreateAcceptanceCriteriaListADF("Only one clause", true)
+	if len(single.Content) != 1 || single.Content[0].Type != "bulletList" || len(single.Content[0].Items) != 1 {
+		t.Errorf("Expected a one-item bulletList for a single clause, got %+v", single.Content)
+	}
+}
+
+func TestCreateDescriptionWithCriteriaListADF(t *testing.T) {
+	doc := CreateDescriptionWithCriteriaListADF("Desc", "Criteria 1; Criteria 2", "", true)
+
+	last := doc.Content[len(doc.Content)-1]
+	if last.Type != "bulletList" || len(last.Items) != 2 {
+		t.Fatalf("Expected criteria as a 2-item bulletList after the heading, got %+v", last)
+	}
+
+	plain := CreateDescriptionWithCriteriaListADF("Desc", "Criteria 1; Criteria 2", "", false)
+	for _, content := range plain.Content {
+		if content.Type == "bulletList" {
+			t.Errorf("Expected text bullets when asList is false, got %+v", content)
+		}
+	}
+}
+
 func TestCreateDescriptionADF(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -350,6 +430,11 @@ func TestSplitCriteria(t *testing.T) {
 			input:    "   ",
 			expected: []string{""},
 		},
+		{
+			name:     "escaped_semicolon",
+			input:    `Usa a\; b como literal; Segundo criterio`,
+			expected: []string{"Usa a; b como literal", "Segundo criterio"},
+		},
 		{
 			name:     "semicolon_priority",
 			input:    "Item 1; Item 2\nItem 3",
@@ -0,0 +1,224 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
+)
+
+// bulkCreateResponse es la respuesta de POST /issue/bulk. Issues trae solo los creados, en el
+// orden del request; cada rechazo indica su posicion en FailedElementNumber. Jira responde 201 si
+// se crearon todos y 400 si fallo alguno, con el mismo formato
+type bulkCreateResponse struct {
+	Issues []JiraCreateResponse `json:"issues"`
+	Errors []bulkIssueError     `json:"errors"`
+}
+
+type bulkIssueError struct {
+	Status              int               `json:"status"`
+	ElementErrors       JiraErrorResponse `json:"elementErrors"`
+	FailedElementNumber int               `json:"failedElementNumber"`
+}
+
+// bulkSubtask es una subtarea pendiente junto a la historia (posicion en stories) a la que pertenece
+type bulkSubtask struct {
+	story       int
+	description string
+}
+
+// CreateUserStories crea las historias con POST /issue/bulk y despues sus subtareas de la misma
+// forma, con las keys obtenidas. Los chequeos previos (subtask_type, etiquetas, SKIP_EXISTING) se
+// hacen por fila como en CreateUserStory; un issue rechazado solo falla su fila o subtarea
+func (jc *JiraClient) CreateUserStories(ctx context.Context, stories []*entities.UserStory, projectKey string, rowNumbers []int) ([]*entities.ProcessResult, error) {
+	if projectKey == "" {
+		projectKey = jc.config.ProjectKey
+	}
+
+	results := make([]*entities.ProcessResult, len(stories))
+	var pending []int
+	var payloads []map[string]interface{}
+	for i, story := range stories {
+		results[i] = entities.NewProcessResult(rowNumbers[i])
+		if payload := jc.storyPayload(ctx, story, projectKey, results[i]); payload != nil {
+			pending = append(pending, i)
+			payloads = append(payloads, payload)
+		}
+	}
+
+	var limitErr error
+	parents := make([]*JiraCreateResponse, len(stories))
+	issues, errs := jc.createIssuesBulk(ctx, payloads)
+	for n, i := range pending {
+		switch {
+		case errors.Is(errs[n], repositories.ErrIssueLimitReached):
+			results[i] = nil
+			limitErr = errs[n]
+		case errs[n] != nil:
+			results[i].Success = false
+			results[i].ErrorMessage = errs[n].Error()
+		default:
+			jc.setCreated(results[i], issues[n])
+			parents[i] = issues[n]
+		}
+	}
+
+	jc.createSubtasksBulk(ctx, stories, parents, projectKey, results)
+
+	return results, limitErr
+}
+
+// createSubtasksBulk crea juntas las subtareas de todas las historias creadas y aplica a cada
+// historia SUBTASK_FAILURE_POLICY, igual que createSubtasks
+func (jc *JiraClient) createSubtasksBulk(ctx context.Context, stories []*entities.UserStory, parents []*JiraCreateResponse, projectKey string, results []*entities.ProcessResult) {
+	var subtasks []bulkSubtask
+	var payloads []map[string]interface{}
+	for i, story := range stories {
+		if parents[i] == nil || !story.HasSubtareas() {
+			continue
+		}
+		subtaskType := jc.subtaskType(story)
+		for _, description := range story.GetValidSubtareas() {
+			subtasks = append(subtasks, bulkSubtask{story: i, description: description})
+			payloads = append(payloads, jc.buildSubtaskPayload(description, parents[i], projectKey, subtaskType))
+		}
+	}
+
+	issues, errs := jc.createIssuesBulk(ctx, payloads)
+
+	created := make(map[int]int)
+	for n, subtask := range subtasks {
+		if jc.recordSubtask(results[subtask.story], parents[subtask.story].Key, subtask.description, issues[n], errs[n]) {
+			created[subtask.story]++
+		}
+	}
+
+	for i, story := range stories {
+		if parents[i] == nil || !story.HasSubtareas() {
+			continue
+		}
+		jc.logSubtasksSummary(parents[i].Key, created[i], len(story.GetValidSubtareas()))
+		jc.applySubtaskFailurePolicy(results[i])
+	}
+}
+
+// createIssuesBulk crea los issues de a repositories.BulkCreateLimit por request y devuelve, por
+// posicion, el issue creado o su error. Los issues que superan MAX_ISSUES_PER_RUN no se envian y
+// quedan con ErrIssueLimitReached
+func (jc *JiraClient) createIssuesBulk(ctx context.Context, payloads []map[string]interface{}) ([]*JiraCreateResponse, []error) {
+	issues := make([]*JiraCreateResponse, len(payloads))
+	errs := make([]error, len(payloads))
+
+	reserved := 0
+	for reserved < len(payloads) {
+		if err := jc.reserveIssue(); err != nil {
+			for i := reserved; i < len(payloads); i++ {
+				errs[i] = err
+			}
+			break
+		}
+		reserved++
+	}
+
+	for start := 0; start < reserved; start += repositories.BulkCreateLimit {
+		end := min(start+repositories.BulkCreateLimit, reserved)
+		jc.postIssuesBulk(ctx, payloads[start:end], issues[start:end], errs[start:end])
+	}
+
+	// Los lugares reservados por issues rechazados se devuelven al tope
+	for i := 0; i < reserved; i++ {
+		if errs[i] != nil {
+			jc.releaseIssue()
+		}
+	}
+
+	return issues, errs
+}
+
+// postIssuesBulk envia un request de creacion masiva y completa issues y errs por posicion; si el
+// request entero falla, todos sus issues quedan con ese error
+func (jc *JiraClient) postIssuesBulk(ctx context.Context, payloads []map[string]interface{}, issues []*JiraCreateResponse, errs []error) {
+	failAll := func(err error) {
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+
+	reqBody, err := json.Marshal(map[string]interface{}{"issueUpdates": payloads})
+	if err != nil {
+		failAll(fmt.Errorf("error marshaling payload: %w", err))
+		return
+	}
+
+	ctx, cancel := jc.withRequestTimeout(ctx)
+	defer cancel()
+
+	req, err := jc.newRequest(ctx, "POST", jc.apiPath("issue/bulk"), bytes.NewBuffer(reqBody))
+	if err != nil {
+		failAll(fmt.Errorf("error creating request: %w", err))
+		return
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		failAll(fmt.Errorf("error creating issues: %w", err))
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		failAll(fmt.Errorf("error reading response: %w", err))
+		return
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusBadRequest {
+		failAll(bulkRequestError(resp.StatusCode, body))
+		return
+	}
+
+	var bulkResp bulkCreateResponse
+	if err := json.Unmarshal(body, &bulkResp); err != nil {
+		failAll(fmt.Errorf("error parsing response: %w", err))
+		return
+	}
+	// Un 400 sin errores por elemento rechaza el request completo (ej: payload invalido)
+	if resp.StatusCode == http.StatusBadRequest && len(bulkResp.Errors) == 0 {
+		failAll(bulkRequestError(resp.StatusCode, body))
+		return
+	}
+
+	failed := make(map[int]error, len(bulkResp.Errors))
+	for _, elementErr := range bulkResp.Errors {
+		failed[elementErr.FailedElementNumber] = fmt.Errorf("jira error: %s", elementErr.ElementErrors.message())
+	}
+
+	next := 0
+	for i := range payloads {
+		if err, ok := failed[i]; ok {
+			errs[i] = err
+			continue
+		}
+		if next >= len(bulkResp.Issues) {
+			errs[i] = fmt.Errorf("error creating issue: bulk response has no result for element %d", i)
+			continue
+		}
+		issues[i] = &bulkResp.Issues[next]
+		next++
+	}
+}
+
+// bulkRequestError arma el error de un request de creacion masiva rechazado completo
+func bulkRequestError(status int, body []byte) error {
+	var errorResp JiraErrorResponse
+	if err := json.Unmarshal(body, &errorResp); err == nil && (len(errorResp.ErrorMessages) > 0 || len(errorResp.Errors) > 0) {
+		return fmt.Errorf("jira error: %s", errorResp.message())
+	}
+	return fmt.Errorf("error creating issues: status %d, body: %s", status, string(body))
+}
@@ -0,0 +1,194 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
+)
+
+// bulkServer simula POST /issue/bulk: rechaza los issues cuyo summary empieza con "Rechazada" y
+// crea el resto con keys correlativas. Guarda la cantidad de issues de cada request
+type bulkServer struct {
+	mu       sync.Mutex
+	next     int
+	requests []int
+}
+
+func (s *bulkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue/bulk" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var payload struct {
+		IssueUpdates []struct {
+			Fields map[string]interface{} `json:"fields"`
+		} `json:"issueUpdates"`
+	}
+	json.NewDecoder(r.Body).Decode(&payload)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, len(payload.IssueUpdates))
+
+	var resp bulkCreateResponse
+	for i, update := range payload.IssueUpdates {
+		if summary, _ := update.Fields["summary"].(string); strings.HasPrefix(summary, "Rechazada") {
+			resp.Errors = append(resp.Errors, bulkIssueError{
+				Status:              400,
+				ElementErrors:       JiraErrorResponse{ErrorMessages: []string{"Summary rejected"}},
+				FailedElementNumber: i,
+			})
+			continue
+		}
+		s.next++
+		resp.Issues = append(resp.Issues, JiraCreateResponse{ID: fmt.Sprint(10000 + s.next), Key: fmt.Sprintf("PROJ-%d", s.next)})
+	}
+
+	if len(resp.Errors) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestJiraClient_CreateUserStories_MixedResults(t *testing.T) {
+	bulk := &bulkServer{}
+	server := httptest.NewServer(bulk)
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	client := NewJiraClient(cfg)
+
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Login", "Desc", "Crit", "Tarea 1;Rechazada 2", ""),
+		entities.NewUserStory("Rechazada", "Desc", "Crit", "Tarea 3", ""),
+		entities.NewUserStory("Perfil", "Desc", "Crit", "", ""),
+	}
+
+	results, err := client.CreateUserStories(context.Background(), stories, "PROJ", []int{2, 3, 4})
+	if err != nil {
+		t.Fatalf("CreateUserStories() error = %v", err)
+	}
+
+	// Un request para las historias y otro para las subtareas de las creadas
+	if fmt.Sprint(bulk.requests) != "[3 2]" {
+		t.Errorf("Requests = %v, want [3 2]", bulk.requests)
+	}
+
+	login, rejected, perfil := results[0], results[1], results[2]
+	if !login.Success || login.IssueKey != "PROJ-1" || login.RowNumber != 2 || !login.WasCreated {
+		t.Errorf("Login = %+v, want PROJ-1 created in row 2", login)
+	}
+	if len(login.Subtareas) != 2 || !login.Subtareas[0].Success || login.Subtareas[0].IssueKey != "PROJ-3" || login.Subtareas[1].Success {
+		t.Errorf("Login subtasks = %+v, want Tarea 1 created and Rechazada 2 failed", login.Subtareas)
+	}
+	if rejected.Success || rejected.RowNumber != 3 || !strings.Contains(rejected.ErrorMessage, "Summary rejected") {
+		t.Errorf("Rechazada = %+v, want a failed row 3 with the Jira message", rejected)
+	}
+	if len(rejected.Subtareas) != 0 {
+		t.Errorf("Subtasks of a rejected story should not be sent, got %+v", rejected.Subtareas)
+	}
+	if !perfil.Success || perfil.IssueKey != "PROJ-2" || perfil.RowNumber != 4 {
+		t.Errorf("Perfil = %+v, want PROJ-2 in row 4", perfil)
+	}
+}
+
+func TestJiraClient_CreateUserStories_SplitsRequests(t *testing.T) {
+	bulk := &bulkServer{}
+	server := httptest.NewServer(bulk)
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	client := NewJiraClient(cfg)
+
+	var stories []*entities.UserStory
+	var rows []int
+	for i := 0; i < repositories.BulkCreateLimit+1; i++ {
+		stories = append(stories, entities.NewUserStory(fmt.Sprintf("Historia %d", i), "Desc", "Crit", "", ""))
+		rows = append(rows, i+2)
+	}
+
+	results, err := client.CreateUserStories(context.Background(), stories, "PROJ", rows)
+	if err != nil {
+		t.Fatalf("CreateUserStories() error = %v", err)
+	}
+
+	if fmt.Sprint(bulk.requests) != fmt.Sprintf("[%d 1]", repositories.BulkCreateLimit) {
+		t.Errorf("Requests = %v, want one full request and one with the remaining issue", bulk.requests)
+	}
+	last := results[len(results)-1]
+	if !last.Success || last.IssueKey != fmt.Sprintf("PROJ-%d", len(stories)) {
+		t.Errorf("Last result = %+v, want the key from the second request", last)
+	}
+}
+
+func TestJiraClient_CreateUserStories_MaxIssuesPerRun(t *testing.T) {
+	bulk := &bulkServer{}
+	server := httptest.NewServer(bulk)
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.MaxIssuesPerRun = 2
+	client := NewJiraClient(cfg)
+
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Rechazada", "Desc", "Crit", "", ""),
+		entities.NewUserStory("Login", "Desc", "Crit", "", ""),
+		entities.NewUserStory("Logout", "Desc", "Crit", "", ""),
+	}
+
+	results, err := client.CreateUserStories(context.Background(), stories, "PROJ", []int{2, 3, 4})
+	if !errors.Is(err, repositories.ErrIssueLimitReached) {
+		t.Fatalf("Expected ErrIssueLimitReached, got %v", err)
+	}
+	if results[0].Success || !results[1].Success || results[2] != nil {
+		t.Fatalf("Expected a rejected row, a created row and an unsent row, got %+v", results)
+	}
+
+	// El lugar del issue rechazado se devuelve al tope
+	result, err := client.CreateUserStories(context.Background(), stories[2:], "PROJ", []int{4})
+	if err != nil || !result[0].Success {
+		t.Errorf("Expected the rejected issue not to count towards the limit, got %v / %+v", err, result[0])
+	}
+}
+
+func TestJiraClient_CreateUserStories_RequestRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errorMessages": ["You do not have permission to create issues in this project."]}`))
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	client := NewJiraClient(cfg)
+
+	stories := []*entities.UserStory{
+		entities.NewUserStory("Login", "Desc", "Crit", "", ""),
+		entities.NewUserStory("Logout", "Desc", "Crit", "", ""),
+	}
+
+	results, err := client.CreateUserStories(context.Background(), stories, "PROJ", []int{2, 3})
+	if err != nil {
+		t.Fatalf("CreateUserStories() error = %v", err)
+	}
+	for i, result := range results {
+		if result.Success || !strings.Contains(result.ErrorMessage, "permission to create issues") {
+			t.Errorf("Result %d = %+v, want the request error on every row", i, result)
+		}
+	}
+}
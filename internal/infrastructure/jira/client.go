@@ -4,23 +4,88 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
 	"historiadorgo/internal/infrastructure/config"
+
+	"golang.org/x/net/http/httpproxy"
 )
 
 type JiraClient struct {
 	config     *config.Config
 	httpClient *http.Client
 	baseURL    string
+
+	statsMu   sync.Mutex
+	rateLimit entities.RateLimitStats
+
+	// issuesCreated cuenta los issues creados (historias, subtareas y Features) para MAX_ISSUES_PER_RUN
+	issuesMu      sync.Mutex
+	issuesCreated int
+
+	deploymentMu    sync.Mutex
+	deployment      string
+	deploymentKnown bool
+
+	// validSubtaskTypes cachea los tipos de la columna subtask_type ya validados
+	subtaskTypesMu    sync.Mutex
+	validSubtaskTypes map[string]bool
+
+	// storyTypes guarda el tipo elegido por AUTO_PICK_ISSUE_TYPE en cada proyecto sin DEFAULT_ISSUE_TYPE
+	storyTypesMu sync.Mutex
+	storyTypes   map[string]string
+
+	// retryableStatuses son los status que se reintentan hasta MAX_RETRIES veces
+	retryableStatuses map[int]bool
+	retryBaseDelay    time.Duration
+
+	subtaskLogger SubtaskLogger
+	requestLogger RequestLogger
+
+	// inflight es el semaforo global de MAX_CONCURRENT_REQUESTS; nil si no hay limite.
+	// Todas las llamadas pasan por do, asi que acota la concurrencia entre archivos e historias.
+	inflight chan struct{}
+
+	users userCache
+
+	// epics cachea si cada parent es un Epic, para usar EPIC_LINK_FIELD en proyectos clasicos
+	epics epicCache
 }
 
+// SubtaskLogger registra las subtareas creadas para cada historia
+type SubtaskLogger interface {
+	LogSubtaskCreated(parentKey, subtaskKey, description string)
+	LogSubtaskError(parentKey, description string, err error)
+	LogSubtasksSummary(parentKey string, created, total int)
+}
+
+// jiraDateTimeLayout es el formato que Jira acepta en los campos de fecha y hora
+const jiraDateTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// maxRetryDelay acota la espera exponencial entre reintentos cuando Jira no envia Retry-After
+const maxRetryDelay = 30 * time.Second
+
+// errRequestTimeout marca las llamadas que superaron HTTP_TIMEOUT_SECONDS, para distinguirlas
+// de un fallo de conexion
+var errRequestTimeout = errors.New("request timed out")
+
+// errProxyConnection marca los requests que no llegaron a conectarse con el proxy configurado
+var errProxyConnection = errors.New("proxy connection failed")
+
 type JiraIssue struct {
 	ID     string                 `json:"id"`
 	Key    string                 `json:"key"`
@@ -40,23 +105,110 @@ type JiraErrorResponse struct {
 	WarningMessages []string          `json:"warningMessages"`
 }
 
-func NewJiraClient(cfg *config.Config) *JiraClient {
-	return &JiraClient{
+// message une los mensajes generales y los errores por campo, ordenados por campo
+func (e JiraErrorResponse) message() string {
+	errorMsg := strings.Join(e.ErrorMessages, "; ")
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		errorMsg += fmt.Sprintf("; %s: %s", field, e.Errors[field])
+	}
+	return errorMsg
+}
+
+// ClientOption personaliza un JiraClient al crearlo
+type ClientOption func(*JiraClient)
+
+// WithTransport reemplaza el transport HTTP (ej: para agregar tracing, logging o mocks);
+// con nil se mantiene el transport por defecto
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(jc *JiraClient) {
+		if transport != nil {
+			jc.httpClient.Transport = transport
+		}
+	}
+}
+
+func NewJiraClient(cfg *config.Config, opts ...ClientOption) *JiraClient {
+	retryable := make(map[int]bool)
+	for _, status := range cfg.GetRetryableStatuses() {
+		retryable[status] = true
+	}
+
+	jc := &JiraClient{
 		config: cfg,
+		// Sin Timeout global: HTTP_TIMEOUT_SECONDS se aplica por intento en do, asi los plazos
+		// propios del llamador (ej: METADATA_TIMEOUT_SECONDS) pueden ser mayores
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Transport: newTransport(cfg),
 		},
-		baseURL: strings.TrimSuffix(cfg.JiraURL, "/"),
+		baseURL:           strings.TrimSuffix(cfg.JiraURL, "/"),
+		retryableStatuses: retryable,
+		retryBaseDelay:    cfg.GetRetryBaseDelay(),
+	}
+
+	if cfg.MaxConcurrentRequests > 0 {
+		jc.inflight = make(chan struct{}, cfg.MaxConcurrentRequests)
+	}
+
+	for _, opt := range opts {
+		opt(jc)
 	}
+
+	return jc
+}
+
+// newTransport aplica los limites de conexiones configurados sobre el transport por defecto
+func newTransport(cfg *config.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+
+	// CA interna (CA_CERT_PATH) o verificacion desactivada (TLS_INSECURE_SKIP_VERIFY); Validate ya
+	// comprobo que el certificado se puede leer
+	if tlsConfig, err := cfg.TLSConfig(); err == nil && tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	// Proxy http o socks5 de JIRA_HTTP(S)_PROXY o HTTP(S)_PROXY; los hosts de NO_PROXY van directo
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  cfg.HTTPProxy,
+		HTTPSProxy: cfg.HTTPSProxy,
+		NoProxy:    cfg.NoProxy,
+	}).ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+
+	return transport
+}
+
+// SetSubtaskLogger registra cada subtarea creada, o una linea por historia con SUBTASK_LOG_MODE=summary
+func (jc *JiraClient) SetSubtaskLogger(logger SubtaskLogger) {
+	jc.subtaskLogger = logger
 }
 
 func (jc *JiraClient) TestConnection(ctx context.Context) error {
-	req, err := jc.createRequest(ctx, "GET", "/rest/api/3/myself", nil)
+	ctx, cancel := jc.withRequestTimeout(ctx)
+	defer cancel()
+
+	req, err := jc.newRequest(ctx, "GET", jc.apiPath("myself"), nil)
 	if err != nil {
 		return fmt.Errorf("error creating request: %w", err)
 	}
 
-	resp, err := jc.httpClient.Do(req)
+	resp, err := jc.do(req)
+	if errors.Is(err, errRequestTimeout) || errors.Is(err, errProxyConnection) {
+		return err
+	}
 	if err != nil {
 		return fmt.Errorf("connection failed: %w", err)
 	}
@@ -70,13 +222,16 @@ func (jc *JiraClient) TestConnection(ctx context.Context) error {
 }
 
 func (jc *JiraClient) ValidateProject(ctx context.Context, projectKey string) error {
-	endpoint := fmt.Sprintf("/rest/api/3/project/%s", projectKey)
-	req, err := jc.createRequest(ctx, "GET", endpoint, nil)
+	ctx, cancel := jc.withRequestTimeout(ctx)
+	defer cancel()
+
+	endpoint := jc.apiPath("project/%s", projectKey)
+	req, err := jc.newRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("error creating request: %w", err)
 	}
 
-	resp, err := jc.httpClient.Do(req)
+	resp, err := jc.do(req)
 	if err != nil {
 		return fmt.Errorf("error validating project: %w", err)
 	}
@@ -94,20 +249,139 @@ func (jc *JiraClient) ValidateProject(ctx context.Context, projectKey string) er
 }
 
 func (jc *JiraClient) ValidateSubtaskIssueType(ctx context.Context, projectKey string) error {
+	return jc.validateSubtaskType(ctx, jc.config.SubtaskIssueType)
+}
+
+// validateSubtaskType verifica con GetIssueTypes que el tipo exista y permita crear subtareas
+func (jc *JiraClient) validateSubtaskType(ctx context.Context, subtaskType string) error {
 	issueTypes, err := jc.GetIssueTypes(ctx)
 	if err != nil {
 		return fmt.Errorf("error getting issue types: %w", err)
 	}
 
 	for _, issueType := range issueTypes {
-		if name, ok := issueType["name"].(string); ok && name == jc.config.SubtaskIssueType {
+		if name, ok := issueType["name"].(string); ok && name == subtaskType {
 			if subtask, ok := issueType["subtask"].(bool); ok && subtask {
 				return nil
 			}
 		}
 	}
 
-	return fmt.Errorf("subtask issue type '%s' not found", jc.config.SubtaskIssueType)
+	return fmt.Errorf("subtask issue type '%s' not found", subtaskType)
+}
+
+// validateRowSubtaskType valida una sola vez por ejecucion cada tipo usado en la columna subtask_type
+func (jc *JiraClient) validateRowSubtaskType(ctx context.Context, subtaskType string) error {
+	jc.subtaskTypesMu.Lock()
+	valid := jc.validSubtaskTypes[subtaskType]
+	jc.subtaskTypesMu.Unlock()
+	if valid {
+		return nil
+	}
+
+	if err := jc.validateSubtaskType(ctx, subtaskType); err != nil {
+		return err
+	}
+
+	jc.subtaskTypesMu.Lock()
+	if jc.validSubtaskTypes == nil {
+		jc.validSubtaskTypes = make(map[string]bool)
+	}
+	jc.validSubtaskTypes[subtaskType] = true
+	jc.subtaskTypesMu.Unlock()
+
+	return nil
+}
+
+// ValidateStoryIssueType verifica en createmeta que DEFAULT_ISSUE_TYPE se pueda crear en el proyecto.
+// Si no existe sugiere los tipos disponibles, o elige uno con AUTO_PICK_ISSUE_TYPE solo para ese
+// proyecto (con PROJECT_FROM_FILENAME cada proyecto puede tener otros tipos)
+func (jc *JiraClient) ValidateStoryIssueType(ctx context.Context, projectKey string) error {
+	available, err := jc.getCreatableIssueTypes(ctx, projectKey)
+	if err != nil {
+		return err
+	}
+
+	var candidates []string
+	for _, issueType := range available {
+		if issueType.Name == jc.config.DefaultIssueType {
+			return nil
+		}
+		if !issueType.Subtask && issueType.Name != jc.config.FeatureIssueType {
+			candidates = append(candidates, issueType.Name)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return fmt.Errorf("issue type '%s' is not available in project '%s' and the project has no other story issue types",
+			jc.config.DefaultIssueType, projectKey)
+	}
+
+	if jc.config.AutoPickIssueType {
+		jc.storyTypesMu.Lock()
+		if jc.storyTypes == nil {
+			jc.storyTypes = make(map[string]string)
+		}
+		jc.storyTypes[projectKey] = candidates[0]
+		jc.storyTypesMu.Unlock()
+		return nil
+	}
+
+	return fmt.Errorf("issue type '%s' is not available in project '%s' (available: %s)",
+		jc.config.DefaultIssueType, projectKey, strings.Join(candidates, ", "))
+}
+
+// storyIssueType es el tipo de las historias del proyecto: el elegido por AUTO_PICK_ISSUE_TYPE o
+// DEFAULT_ISSUE_TYPE
+func (jc *JiraClient) storyIssueType(projectKey string) string {
+	jc.storyTypesMu.Lock()
+	defer jc.storyTypesMu.Unlock()
+	if issueType, ok := jc.storyTypes[projectKey]; ok {
+		return issueType
+	}
+	return jc.config.DefaultIssueType
+}
+
+type createMetaIssueType struct {
+	Name    string `json:"name"`
+	Subtask bool   `json:"subtask"`
+}
+
+// getCreatableIssueTypes obtiene de createmeta los tipos de issue que se pueden crear en el proyecto
+func (jc *JiraClient) getCreatableIssueTypes(ctx context.Context, projectKey string) ([]createMetaIssueType, error) {
+	ctx, cancel := context.WithTimeout(ctx, jc.config.GetMetadataTimeout())
+	defer cancel()
+
+	endpoint := jc.apiPath("issue/createmeta?projectKeys=%s&expand=projects.issuetypes", projectKey)
+	req, err := jc.newRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting create meta: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting create meta: status %d", resp.StatusCode)
+	}
+
+	var createMeta struct {
+		Projects []struct {
+			IssueTypes []createMetaIssueType `json:"issuetypes"`
+		} `json:"projects"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&createMeta); err != nil {
+		return nil, fmt.Errorf("error decoding create meta: %w", err)
+	}
+
+	if len(createMeta.Projects) == 0 {
+		return nil, fmt.Errorf("no projects found in create meta")
+	}
+
+	return createMeta.Projects[0].IssueTypes, nil
 }
 
 func (jc *JiraClient) ValidateFeatureIssueType(ctx context.Context) error {
@@ -126,13 +400,16 @@ func (jc *JiraClient) ValidateFeatureIssueType(ctx context.Context) error {
 }
 
 func (jc *JiraClient) ValidateParentIssue(ctx context.Context, issueKey string) error {
-	endpoint := fmt.Sprintf("/rest/api/3/issue/%s", issueKey)
-	req, err := jc.createRequest(ctx, "GET", endpoint, nil)
+	ctx, cancel := jc.withRequestTimeout(ctx)
+	defer cancel()
+
+	endpoint := jc.apiPath("issue/%s", issueKey)
+	req, err := jc.newRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("error creating request: %w", err)
 	}
 
-	resp, err := jc.httpClient.Do(req)
+	resp, err := jc.do(req)
 	if err != nil {
 		return fmt.Errorf("error validating parent issue: %w", err)
 	}
@@ -149,36 +426,114 @@ func (jc *JiraClient) ValidateParentIssue(ctx context.Context, issueKey string)
 	return nil
 }
 
-func (jc *JiraClient) CreateUserStory(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+func (jc *JiraClient) CreateUserStory(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 	result := entities.NewProcessResult(rowNumber)
 
-	issuePayload := jc.buildIssuePayload(story, jc.config.ProjectKey)
+	if projectKey == "" {
+		projectKey = jc.config.ProjectKey
+	}
+
+	issuePayload := jc.storyPayload(ctx, story, projectKey, result)
+	if issuePayload == nil {
+		return result, nil
+	}
 
 	issue, err := jc.createIssue(ctx, issuePayload)
+	if errors.Is(err, repositories.ErrIssueLimitReached) {
+		return nil, err
+	}
 	if err != nil {
 		result.Success = false
 		result.ErrorMessage = err.Error()
 		return result, nil
 	}
 
+	jc.setCreated(result, issue)
+
+	if story.HasSubtareas() {
+		jc.createSubtasks(ctx, story, issue, projectKey, result)
+		jc.applySubtaskFailurePolicy(result)
+	}
+
+	return result, nil
+}
+
+// storyPayload hace los chequeos previos a crear la historia y arma su payload. Devuelve nil si
+// la fila ya quedo resuelta en result: invalida, o existente con SKIP_EXISTING
+func (jc *JiraClient) storyPayload(ctx context.Context, story *entities.UserStory, projectKey string, result *entities.ProcessResult) map[string]interface{} {
+	if story.SubtaskType != "" && story.HasSubtareas() {
+		if err := jc.validateRowSubtaskType(ctx, story.SubtaskType); err != nil {
+			result.Success = false
+			result.ErrorMessage = fmt.Sprintf("invalid subtask_type: %v", err)
+			return nil
+		}
+	}
+
+	if jc.config.LabelSpaces == config.LabelSpacesError {
+		if invalid := story.LabelsWithSpaces(); len(invalid) > 0 {
+			result.Success = false
+			result.ErrorMessage = fmt.Sprintf("invalid labels %q: Jira labels cannot contain spaces (LABEL_SPACES=error)", invalid)
+			return nil
+		}
+	}
+
+	if jc.config.SkipExisting {
+		existingKey, err := jc.findExistingStory(ctx, story, projectKey)
+		if err != nil {
+			result.Success = false
+			result.ErrorMessage = fmt.Sprintf("could not check for an existing issue: %v", err)
+			return nil
+		}
+		if existingKey != "" {
+			result.Success = true
+			result.IssueKey = existingKey
+			result.IssueURL = fmt.Sprintf("%s/browse/%s", jc.baseURL, existingKey)
+			result.AddWarning(fmt.Sprintf("%s already exists with the same summary; not created (SKIP_EXISTING)", existingKey))
+			return nil
+		}
+	}
+
+	issuePayload := jc.buildIssuePayload(story, projectKey)
+	jc.resolveMentions(ctx, issuePayload)
+	jc.setAssignee(ctx, story, issuePayload, result)
+	jc.setEpicLink(ctx, issuePayload, result)
+	return issuePayload
+}
+
+// setCreated marca la fila como creada con la key que devolvio Jira
+func (jc *JiraClient) setCreated(result *entities.ProcessResult, issue *JiraCreateResponse) {
 	result.Success = true
+	result.WasCreated = true
 	result.IssueKey = issue.Key
 	result.IssueURL = fmt.Sprintf("%s/browse/%s", jc.baseURL, issue.Key)
+}
 
-	if story.HasSubtareas() {
-		jc.createSubtasks(ctx, story, issue.Key, result)
+// applySubtaskFailurePolicy decide, segun SUBTASK_FAILURE_POLICY, si una historia cuyas subtareas
+// fallaron todas sigue exitosa (ignore), exitosa con aviso (warn) o se marca fallida (fail)
+func (jc *JiraClient) applySubtaskFailurePolicy(result *entities.ProcessResult) {
+	if !result.AllSubtasksFailed() {
+		return
 	}
 
-	return result, nil
+	switch jc.config.SubtaskFailurePolicy {
+	case config.SubtaskFailureWarn:
+		result.AddWarning(fmt.Sprintf("all %d subtasks failed", len(result.Subtareas)))
+	case config.SubtaskFailureFail:
+		result.Success = false
+		result.ErrorMessage = fmt.Sprintf("all %d subtasks failed (SUBTASK_FAILURE_POLICY=fail)", len(result.Subtareas))
+	}
 }
 
 func (jc *JiraClient) GetIssueTypes(ctx context.Context) ([]map[string]interface{}, error) {
-	req, err := jc.createRequest(ctx, "GET", "/rest/api/3/issuetype", nil)
+	ctx, cancel := jc.withRequestTimeout(ctx)
+	defer cancel()
+
+	req, err := jc.newRequest(ctx, "GET", jc.apiPath("issuetype"), nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	resp, err := jc.httpClient.Do(req)
+	resp, err := jc.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error getting issue types: %w", err)
 	}
@@ -196,18 +551,132 @@ func (jc *JiraClient) GetIssueTypes(ctx context.Context) ([]map[string]interface
 	return issueTypes, nil
 }
 
-func (jc *JiraClient) createIssue(ctx context.Context, payload map[string]interface{}) (*JiraCreateResponse, error) {
+// CreateIssueLink enlaza dos issues existentes con el tipo de link indicado (ej: "Relates")
+func (jc *JiraClient) CreateIssueLink(ctx context.Context, inwardKey, outwardKey, linkType string) error {
+	payload := map[string]interface{}{
+		"type":         map[string]interface{}{"name": linkType},
+		"inwardIssue":  map[string]interface{}{"key": inwardKey},
+		"outwardIssue": map[string]interface{}{"key": outwardKey},
+	}
+
+	reqBody, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error marshaling payload: %w", err)
+	}
+
+	req, err := jc.newRequest(ctx, "POST", jc.apiPath("issueLink"), bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		return fmt.Errorf("error creating issue link: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error creating issue link: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// DeleteIssue elimina un issue por key junto con sus subtareas. Un 404 devuelve ErrIssueNotFound
+// (el issue ya no existe) y un 403 ErrPermissionDenied
+func (jc *JiraClient) DeleteIssue(ctx context.Context, issueKey string) error {
+	req, err := jc.newRequest(ctx, "DELETE", jc.apiPath("issue/%s", issueKey)+"?deleteSubtasks=true", nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		return fmt.Errorf("error deleting issue %s: %w", issueKey, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s does not exist or was already deleted", repositories.ErrIssueNotFound, issueKey)
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: cannot delete %s (requires the Delete Issues project permission)", repositories.ErrPermissionDenied, issueKey)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var errorResp JiraErrorResponse
+	if err := json.Unmarshal(body, &errorResp); err == nil && len(errorResp.ErrorMessages) > 0 {
+		return fmt.Errorf("error deleting issue %s: status %d: %s", issueKey, resp.StatusCode, strings.Join(errorResp.ErrorMessages, "; "))
+	}
+	return fmt.Errorf("error deleting issue %s: status %d, body: %s", issueKey, resp.StatusCode, string(body))
+}
+
+// DeleteIssues elimina las keys en orden y devuelve un resultado por key; un fallo no detiene el resto
+func (jc *JiraClient) DeleteIssues(ctx context.Context, issueKeys []string) []*entities.DeleteResult {
+	results := make([]*entities.DeleteResult, 0, len(issueKeys))
+	for _, issueKey := range issueKeys {
+		result := &entities.DeleteResult{IssueKey: issueKey}
+		err := jc.DeleteIssue(ctx, issueKey)
+		switch {
+		case err == nil:
+			result.Deleted = true
+		case errors.Is(err, repositories.ErrIssueNotFound):
+			result.AlreadyDeleted = true
+		default:
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// reserveIssue reserva un lugar para crear un issue antes de enviarlo, de modo que
+// MAX_ISSUES_PER_RUN no se supere aunque haya creaciones concurrentes
+func (jc *JiraClient) reserveIssue() error {
+	jc.issuesMu.Lock()
+	defer jc.issuesMu.Unlock()
+
+	if limit := jc.config.MaxIssuesPerRun; limit > 0 && jc.issuesCreated >= limit {
+		return fmt.Errorf("%w: MAX_ISSUES_PER_RUN=%d issues already created in this run", repositories.ErrIssueLimitReached, limit)
+	}
+	jc.issuesCreated++
+	return nil
+}
+
+// releaseIssue devuelve el lugar reservado por un issue que no llego a crearse
+func (jc *JiraClient) releaseIssue() {
+	jc.issuesMu.Lock()
+	jc.issuesCreated--
+	jc.issuesMu.Unlock()
+}
+
+func (jc *JiraClient) createIssue(ctx context.Context, payload map[string]interface{}) (_ *JiraCreateResponse, err error) {
+	if err := jc.reserveIssue(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			jc.releaseIssue()
+		}
+	}()
+
 	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling payload: %w", err)
 	}
 
-	req, err := jc.createRequest(ctx, "POST", "/rest/api/3/issue", bytes.NewBuffer(reqBody))
+	ctx, cancel := jc.withRequestTimeout(ctx)
+	defer cancel()
+
+	req, err := jc.newRequest(ctx, "POST", jc.apiPath("issue"), bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	resp, err := jc.httpClient.Do(req)
+	resp, err := jc.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error creating issue: %w", err)
 	}
@@ -221,11 +690,7 @@ func (jc *JiraClient) createIssue(ctx context.Context, payload map[string]interf
 	if resp.StatusCode != http.StatusCreated {
 		var errorResp JiraErrorResponse
 		if err := json.Unmarshal(body, &errorResp); err == nil {
-			errorMsg := strings.Join(errorResp.ErrorMessages, "; ")
-			for field, msg := range errorResp.Errors {
-				errorMsg += fmt.Sprintf("; %s: %s", field, msg)
-			}
-			return nil, fmt.Errorf("jira error: %s", errorMsg)
+			return nil, fmt.Errorf("jira error: %s", errorResp.message())
 		}
 		return nil, fmt.Errorf("error creating issue: status %d, body: %s", resp.StatusCode, string(body))
 	}
@@ -238,42 +703,84 @@ func (jc *JiraClient) createIssue(ctx context.Context, payload map[string]interf
 	return &createResp, nil
 }
 
-func (jc *JiraClient) createSubtasks(ctx context.Context, story *entities.UserStory, parentKey string, result *entities.ProcessResult) {
+func (jc *JiraClient) createSubtasks(ctx context.Context, story *entities.UserStory, parent *JiraCreateResponse, projectKey string, result *entities.ProcessResult) {
 	validSubtasks := story.GetValidSubtareas()
+	subtaskType := jc.subtaskType(story)
 
+	created := 0
 	for _, subtaskDesc := range validSubtasks {
-		subtaskPayload := jc.buildSubtaskPayload(subtaskDesc, parentKey, jc.config.ProjectKey)
+		subtaskPayload := jc.buildSubtaskPayload(subtaskDesc, parent, projectKey, subtaskType)
 
 		subtask, err := jc.createIssue(ctx, subtaskPayload)
-		if err != nil {
-			result.AddSubtaskResult(subtaskDesc, false, "", "", err.Error())
-			continue
+		if jc.recordSubtask(result, parent.Key, subtaskDesc, subtask, err) {
+			created++
+		}
+	}
+
+	jc.logSubtasksSummary(parent.Key, created, len(validSubtasks))
+}
+
+// subtaskType es el tipo de las subtareas de la historia: su columna subtask_type o SUBTASK_ISSUE_TYPE
+func (jc *JiraClient) subtaskType(story *entities.UserStory) string {
+	if story.SubtaskType != "" {
+		return story.SubtaskType
+	}
+	return jc.config.SubtaskIssueType
+}
+
+// verboseSubtaskLog indica si se registra cada subtarea en lugar de una linea por historia
+func (jc *JiraClient) verboseSubtaskLog() bool {
+	return jc.subtaskLogger != nil && jc.config.SubtaskLogMode != config.SubtaskLogSummary
+}
+
+// recordSubtask agrega al resultado una subtarea creada o fallida y la registra en el log
+// verbose; devuelve si se creo
+func (jc *JiraClient) recordSubtask(result *entities.ProcessResult, parentKey, description string, subtask *JiraCreateResponse, err error) bool {
+	verbose := jc.verboseSubtaskLog()
+	if err != nil {
+		result.AddSubtaskResult(description, false, "", "", err.Error())
+		if verbose {
+			jc.subtaskLogger.LogSubtaskError(parentKey, description, err)
 		}
+		return false
+	}
 
-		subtaskURL := fmt.Sprintf("%s/browse/%s", jc.baseURL, subtask.Key)
-		result.AddSubtaskResult(subtaskDesc, true, subtask.Key, subtaskURL, "")
+	subtaskURL := fmt.Sprintf("%s/browse/%s", jc.baseURL, subtask.Key)
+	result.AddSubtaskResult(description, true, subtask.Key, subtaskURL, "")
+	if verbose {
+		jc.subtaskLogger.LogSubtaskCreated(parentKey, subtask.Key, description)
+	}
+	return true
+}
+
+// logSubtasksSummary registra la linea por historia de SUBTASK_LOG_MODE=summary
+func (jc *JiraClient) logSubtasksSummary(parentKey string, created, total int) {
+	if jc.subtaskLogger != nil && !jc.verboseSubtaskLog() && total > 0 {
+		jc.subtaskLogger.LogSubtasksSummary(parentKey, created, total)
 	}
 }
 
 func (jc *JiraClient) buildIssuePayload(story *entities.UserStory, projectKey string) map[string]interface{} {
+	description := jc.limitDescription(story.Descripcion)
+
 	fields := map[string]interface{}{
 		"project": map[string]interface{}{
 			"key": projectKey,
 		},
 		"summary": story.Titulo,
 		"issuetype": map[string]interface{}{
-			"name": jc.config.DefaultIssueType,
+			"name": jc.storyIssueType(projectKey),
 		},
 	}
 
-	// Usar ADF para descripción y criterios de aceptación
+	// Descripción y criterios de aceptación en ADF (API v3) o wiki markup (API v2)
 	if jc.config.AcceptanceCriteriaField != "" {
 		// Si hay campo personalizado para criterios, usar descripción simple y criterios en campo separado
-		fields["description"] = CreateDescriptionADF(story.Descripcion)
-		fields[jc.config.AcceptanceCriteriaField] = CreateAcceptanceCriteriaADF(story.CriterioAceptacion)
+		fields["description"] = jc.descriptionValue(description)
+		fields[jc.config.AcceptanceCriteriaField] = jc.criteriaValue(story.CriterioAceptacion)
 	} else {
 		// Si no hay campo personalizado, incluir criterios en la descripción
-		fields["description"] = CreateDescriptionWithCriteriaADF(story.Descripcion, story.CriterioAceptacion)
+		fields["description"] = jc.descriptionWithCriteriaValue(description, story.CriterioAceptacion)
 	}
 
 	if story.HasParent() && jc.isJiraKey(story.Parent) {
@@ -282,40 +789,374 @@ func (jc *JiraClient) buildIssuePayload(story *entities.UserStory, projectKey st
 		}
 	}
 
+	if len(story.Labels) > 0 {
+		fields["labels"] = entities.NormalizeLabels(story.Labels)
+	}
+
+	if priority := strings.TrimSpace(story.Prioridad); priority != "" {
+		fields["priority"] = map[string]interface{}{"name": priority}
+	}
+
+	if jc.config.ImportDateField != "" {
+		fields[jc.config.ImportDateField] = time.Now().Format(jiraDateTimeLayout)
+	}
+
+	if environment := strings.TrimSpace(story.Environment); environment != "" {
+		fields["environment"] = jc.environmentValue(environment)
+	}
+
 	return map[string]interface{}{
 		"fields": fields,
 	}
 }
 
-func (jc *JiraClient) buildSubtaskPayload(description, parentKey, projectKey string) map[string]interface{} {
+// environmentValue arma fields.environment como ADF o texto plano segun ENVIRONMENT_FORMAT;
+// la API v2 siempre recibe texto
+func (jc *JiraClient) environmentValue(environment string) interface{} {
+	if jc.config.EnvironmentFormat == config.EnvironmentFormatPlain || jc.wikiMarkup() {
+		return environment
+	}
+	return CreateDescriptionADF(environment)
+}
+
+// wikiMarkup indica si los campos de texto enriquecido van como wiki markup (JIRA_API_VERSION=2)
+// en lugar de ADF, que la API v2 rechaza
+func (jc *JiraClient) wikiMarkup() bool {
+	return jc.config.JiraAPIVersion == config.JiraAPIVersion2
+}
+
+// descriptionValue arma una descripción simple en el formato de la versión de API configurada
+func (jc *JiraClient) descriptionValue(description string) interface{} {
+	if jc.wikiMarkup() {
+		return CreateDescriptionWiki(description)
+	}
+	return CreateDescriptionADF(description)
+}
+
+// criteriaValue arma el campo de criterios de aceptación en el formato de la versión de API configurada
+func (jc *JiraClient) criteriaValue(criteria string) interface{} {
+	if jc.wikiMarkup() {
+		return CreateAcceptanceCriteriaWiki(criteria, jc.config.AcceptanceCriteriaAsList)
+	}
+	return CreateAcceptanceCriteriaListADF(criteria, jc.config.AcceptanceCriteriaAsList)
+}
+
+// descriptionWithCriteriaValue arma la descripción con la sección de criterios en el formato de la versión de API configurada
+func (jc *JiraClient) descriptionWithCriteriaValue(description, criteria string) interface{} {
+	if jc.wikiMarkup() {
+		return CreateDescriptionWithCriteriaWiki(description, criteria, jc.config.CriteriaHeading, jc.config.AcceptanceCriteriaAsList)
+	}
+	return CreateDescriptionWithCriteriaListADF(description, criteria, jc.config.CriteriaHeading, jc.config.AcceptanceCriteriaAsList)
+}
+
+// buildUpdatePayload arma la actualizacion de un issue existente con las columnas que tienen valor.
+// Las columnas vacias no tocan el campo en Jira salvo con UPDATE_CLEARS_EMPTY=true, que lo limpia;
+// el summary nunca se limpia porque Jira lo exige.
+func (jc *JiraClient) buildUpdatePayload(story *entities.UserStory) map[string]interface{} {
+	fields := map[string]interface{}{}
+	clearEmpty := jc.config.UpdateClearsEmpty
+
+	if titulo := strings.TrimSpace(story.Titulo); titulo != "" {
+		fields["summary"] = titulo
+	}
+
+	description := strings.TrimSpace(jc.limitDescription(story.Descripcion))
+	criteria := strings.TrimSpace(story.CriterioAceptacion)
+
+	if jc.config.AcceptanceCriteriaField != "" {
+		setOrClear(fields, "description", description != "", clearEmpty, func() interface{} {
+			return jc.descriptionValue(description)
+		})
+		setOrClear(fields, jc.config.AcceptanceCriteriaField, criteria != "", clearEmpty, func() interface{} {
+			return jc.criteriaValue(criteria)
+		})
+	} else {
+		setOrClear(fields, "description", description != "" || criteria != "", clearEmpty, func() interface{} {
+			return jc.descriptionWithCriteriaValue(description, criteria)
+		})
+	}
+
+	environment := strings.TrimSpace(story.Environment)
+	setOrClear(fields, "environment", environment != "", clearEmpty, func() interface{} {
+		return jc.environmentValue(environment)
+	})
+
+	return map[string]interface{}{
+		"fields": fields,
+	}
+}
+
+// setOrClear asigna el campo si hay valor, lo limpia (null) si clearEmpty esta activo, o lo omite
+func setOrClear(fields map[string]interface{}, field string, hasValue, clearEmpty bool, value func() interface{}) {
+	switch {
+	case hasValue:
+		fields[field] = value()
+	case clearEmpty:
+		fields[field] = nil
+	}
+}
+
+// limitDescription recorta la descripcion a MAX_DESCRIPTION_LENGTH cuando la politica es truncate
+func (jc *JiraClient) limitDescription(description string) string {
+	if jc.config.MaxDescriptionLength <= 0 || jc.config.DescriptionLengthPolicy == config.DescriptionPolicyWarn {
+		return description
+	}
+
+	return truncateText(description, jc.config.MaxDescriptionLength, jc.config.GetTruncationMarker())
+}
+
+// truncateText recorta por runas para no partir caracteres multibyte; el marcador cuenta dentro del maximo
+func truncateText(text string, max int, marker string) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+
+	markerRunes := []rune(marker)
+	if len(markerRunes) >= max {
+		return string(runes[:max])
+	}
+
+	return string(runes[:max-len(markerRunes)]) + marker
+}
+
+func (jc *JiraClient) buildSubtaskPayload(description string, parent *JiraCreateResponse, projectKey, issueType string) map[string]interface{} {
 	return map[string]interface{}{
 		"fields": map[string]interface{}{
 			"project": map[string]interface{}{
 				"key": projectKey,
 			},
 			"summary":     description,
-			"description": CreateDescriptionADF(description),
+			"description": jc.descriptionValue(description),
 			"issuetype": map[string]interface{}{
-				"name": jc.config.SubtaskIssueType,
-			},
-			"parent": map[string]interface{}{
-				"key": parentKey,
+				"name": issueType,
 			},
+			"parent": jc.subtaskParentRef(parent),
 		},
 	}
 }
 
-func (jc *JiraClient) createRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Request, error) {
-	fullURL := jc.baseURL + endpoint
+// subtaskParentRef referencia la historia por key o por id segun SUBTASK_PARENT_STYLE;
+// algunos proyectos rechazan la key y solo aceptan fields.parent.id
+func (jc *JiraClient) subtaskParentRef(parent *JiraCreateResponse) map[string]interface{} {
+	if jc.config.SubtaskParentStyle == config.SubtaskParentStyleID && parent.ID != "" {
+		return map[string]interface{}{"id": parent.ID}
+	}
+	return map[string]interface{}{"key": parent.Key}
+}
+
+// do ejecuta el request registrando las respuestas de throttling (429 / Retry-After)
+// y reintentando hasta MAX_RETRIES veces los status de RETRYABLE_STATUSES, con backoff
+// exponencial desde RETRY_BASE_DELAY_MS o la espera que indique Retry-After
+func (jc *JiraClient) do(req *http.Request) (*http.Response, error) {
+	if jc.requestLogger != nil {
+		jc.requestLogger.LogRequest(curlCommand(req))
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := jc.acquire(req.Context()); err != nil {
+			return nil, err
+		}
+
+		attemptCtx, cancel := jc.attemptContext(req.Context())
+		resp, err := jc.httpClient.Do(req.WithContext(attemptCtx))
+		if err != nil {
+			cancel()
+			jc.release()
+			return nil, jc.transportError(req.Context(), err)
+		}
+
+		wait, hasRetryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if resp.StatusCode == http.StatusTooManyRequests {
+			jc.statsMu.Lock()
+			jc.rateLimit.RecordThrottle()
+			if hasRetryAfter {
+				jc.rateLimit.RecordRetryAfter(wait)
+			}
+			jc.statsMu.Unlock()
+		}
+
+		if attempt >= jc.config.MaxRetries || !jc.retryableStatuses[resp.StatusCode] || !canReplay(req) {
+			// El plazo del intento y el cupo se liberan cuando el llamador cierra el body
+			release := cancel
+			if jc.inflight != nil {
+				release = func() {
+					cancel()
+					jc.release()
+				}
+			}
+			resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+			return resp, nil
+		}
+
+		if !hasRetryAfter {
+			wait = retryBackoff(jc.retryBaseDelay, attempt)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		cancel()
+		jc.release()
+
+		select {
+		case <-req.Context().Done():
+			return nil, jc.transportError(req.Context(), req.Context().Err())
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// requestTimeoutKey marca los contextos acotados por withRequestTimeout
+type requestTimeoutKey struct{}
+
+// withRequestTimeout acota una llamada completa, con sus reintentos, para que un endpoint colgado
+// no bloquee la ejecucion: HTTP_TIMEOUT_SECONDS por intento mas la espera maxima entre intentos
+func (jc *JiraClient) withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	retries := time.Duration(jc.config.MaxRetries)
+	ctx = context.WithValue(ctx, requestTimeoutKey{}, true)
+	return context.WithTimeout(ctx, jc.config.GetHTTPTimeout()*(retries+1)+maxRetryDelay*retries)
+}
+
+// attemptContext acota cada intento a HTTP_TIMEOUT_SECONDS, incluida la lectura del body. Los
+// contextos con un plazo propio del llamador (ej: METADATA_TIMEOUT_SECONDS) no se acotan, para
+// que ese plazo pueda ser mayor; los de withRequestTimeout si, porque cubren todos los intentos
+func (jc *JiraClient) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok && ctx.Value(requestTimeoutKey{}) == nil {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, jc.config.GetHTTPTimeout())
+}
+
+// transportError reemplaza los errores por HTTP_TIMEOUT_SECONDS por "request timed out after Ns"
+// y marca los fallos de conexion con el proxy. Los plazos propios del llamador
+// (ej: METADATA_TIMEOUT_SECONDS) y la cancelacion del usuario se devuelven tal cual
+func (jc *JiraClient) transportError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && ctx.Value(requestTimeoutKey{}) == nil {
+		return err
+	}
+
+	// net/http informa "proxyconnect" para proxies http y "socks connect" para socks5
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && (opErr.Op == "proxyconnect" || strings.HasPrefix(opErr.Op, "socks")) {
+		return fmt.Errorf("%w: %v", errProxyConnection, err)
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w after %s", errRequestTimeout, jc.config.GetHTTPTimeout())
+	}
+	return err
+}
+
+// retryBackoff devuelve la espera antes del reintento attempt (desde 0): base*2^attempt acotado a
+// maxRetryDelay, con jitter entre la mitad y el total para que los workers no reintenten a la vez
+func retryBackoff(base time.Duration, attempt int) time.Duration {
+	delay := maxRetryDelay
+	if attempt < 32 && base<<attempt > 0 && base<<attempt < maxRetryDelay {
+		delay = base << attempt
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// acquire toma un cupo de MAX_CONCURRENT_REQUESTS, esperando si estan todos ocupados
+func (jc *JiraClient) acquire(ctx context.Context) error {
+	if jc.inflight == nil {
+		return nil
+	}
+
+	select {
+	case jc.inflight <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release devuelve el cupo tomado por acquire
+func (jc *JiraClient) release() {
+	if jc.inflight != nil {
+		<-jc.inflight
+	}
+}
+
+// releasingBody libera el cupo de concurrencia una sola vez al cerrar el body de la respuesta
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// canReplay indica si el request puede reenviarse: sin body o con un body que se puede regenerar
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// RateLimitStats devuelve los eventos de rate limit acumulados por el cliente
+func (jc *JiraClient) RateLimitStats() entities.RateLimitStats {
+	jc.statsMu.Lock()
+	defer jc.statsMu.Unlock()
+	return jc.rateLimit
+}
+
+// parseRetryAfter interpreta el header Retry-After en segundos o como fecha HTTP
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// userAgent identifica a la herramienta en los logs de acceso de Jira
+const userAgent = "historiador-go"
+
+// apiPath arma la ruta de un endpoint de la API REST con la version de JIRA_API_VERSION;
+// Jira Server y Data Center antiguos solo exponen /rest/api/2
+func (jc *JiraClient) apiPath(format string, args ...interface{}) string {
+	return fmt.Sprintf("/rest/api/%s/", jc.config.GetJiraAPIVersion()) + fmt.Sprintf(format, args...)
+}
+
+// newRequest arma un request a la API de Jira con la URL base, autenticacion y headers comunes
+func (jc *JiraClient) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	fullURL := strings.TrimSuffix(jc.baseURL, "/") + "/" + strings.TrimPrefix(path, "/")
 
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.SetBasicAuth(jc.config.JiraEmail, jc.config.JiraAPIToken)
+	jc.config.Credentials().Apply(req)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
 
 	return req, nil
 }
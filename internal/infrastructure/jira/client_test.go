@@ -3,12 +3,23 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
 	"historiadorgo/internal/infrastructure/config"
 )
 
@@ -57,6 +68,361 @@ func TestNewJiraClient_TrimsSlash(t *testing.T) {
 	}
 }
 
+func TestNewJiraClient_ConnectionLimits(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.MaxIdleConnsPerHost = 4
+	cfg.MaxConnsPerHost = 8
+	client := NewJiraClient(cfg)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.httpClient.Transport)
+	}
+	if transport.MaxIdleConnsPerHost != 4 {
+		t.Errorf("Expected MaxIdleConnsPerHost 4, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 8 {
+		t.Errorf("Expected MaxConnsPerHost 8, got %d", transport.MaxConnsPerHost)
+	}
+
+	// Sin valores configurados se conservan los defaults de Go
+	defaultClient := NewJiraClient(createTestConfig())
+	defaultTransport := defaultClient.httpClient.Transport.(*http.Transport)
+	if defaultTransport.MaxConnsPerHost != 0 {
+		t.Errorf("Expected unlimited MaxConnsPerHost by default, got %d", defaultTransport.MaxConnsPerHost)
+	}
+	if defaultTransport == http.DefaultTransport {
+		t.Error("Expected a dedicated transport, not http.DefaultTransport")
+	}
+}
+
+func TestNewJiraClient_Proxy(t *testing.T) {
+	var proxied []string
+	proxyStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Un proxy http recibe la URL absoluta del destino
+		proxied = append(proxied, r.URL.String())
+		w.Write([]byte(`{"accountId": "acc-1"}`))
+	}))
+	defer proxyStub.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = "http://jira.example.test"
+	cfg.HTTPProxy = proxyStub.URL
+	client := NewJiraClient(cfg)
+
+	if err := client.TestConnection(context.Background()); err != nil {
+		t.Fatalf("TestConnection() through proxy error = %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://jira.example.test/rest/api/3/myself" {
+		t.Errorf("Expected the request to traverse the proxy, got %v", proxied)
+	}
+
+	// NO_PROXY y los esquemas socks5 se resuelven en el transport
+	cfg.HTTPSProxy = "socks5://proxy.corp:1080"
+	cfg.NoProxy = "jira.interno.corp,.intranet"
+	transport := NewJiraClient(cfg).httpClient.Transport.(*http.Transport)
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"https://empresa.atlassian.net/rest/api/3/myself", "socks5://proxy.corp:1080"},
+		{"http://jira.example.test/rest/api/3/myself", proxyStub.URL},
+		{"https://jira.interno.corp/rest/api/2/myself", ""},
+		{"https://jira.intranet/rest/api/2/myself", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.target, nil)
+		proxyURL, err := transport.Proxy(req)
+		if err != nil {
+			t.Fatalf("Proxy(%s) error = %v", tt.target, err)
+		}
+		got := ""
+		if proxyURL != nil {
+			got = proxyURL.String()
+		}
+		if got != tt.want {
+			t.Errorf("Proxy(%s) = %q, want %q", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestJiraClient_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"accountId": "acc-1"}`))
+	}))
+	defer server.Close()
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caPath, caPEM, 0600); err != nil {
+		t.Fatalf("Failed to write CA: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		caPath   string
+		insecure bool
+		wantErr  bool
+	}{
+		{name: "unknown_authority_fails", wantErr: true},
+		{name: "custom_ca_trusted", caPath: caPath},
+		{name: "insecure_skip_verify", insecure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.CACertPath = tt.caPath
+			cfg.TLSInsecureSkipVerify = tt.insecure
+
+			err := NewJiraClient(cfg).TestConnection(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestConnection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJiraClient_ProxyConnectionFailure(t *testing.T) {
+	// Un puerto sin nadie escuchando simula un proxy caido
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	deadProxy := "http://" + listener.Addr().String()
+	listener.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = "http://jira.example.test"
+	cfg.HTTPProxy = deadProxy
+
+	err = NewJiraClient(cfg).TestConnection(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "proxy connection failed") {
+		t.Errorf("TestConnection() error = %v, want a proxy connection failure", err)
+	}
+	if err != nil && entities.ClassifyError(err.Error()) != entities.ErrorCodeNetwork {
+		t.Errorf("ClassifyError(%q) = %s, want %s", err, entities.ClassifyError(err.Error()), entities.ErrorCodeNetwork)
+	}
+}
+
+func TestJiraClient_RateLimitStats(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 3:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"accountId": "test"}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	client := NewJiraClient(cfg)
+
+	for i := 0; i < 4; i++ {
+		client.TestConnection(context.Background())
+	}
+
+	stats := client.RateLimitStats()
+	if stats.ThrottledResponses != 3 {
+		t.Errorf("Expected 3 throttled responses, got %d", stats.ThrottledResponses)
+	}
+	if stats.RetryAfterWaits != 2 {
+		t.Errorf("Expected 2 Retry-After hints, got %d", stats.RetryAfterWaits)
+	}
+	if stats.RetryAfterTotal != 5*time.Second {
+		t.Errorf("Expected 5s total Retry-After, got %v", stats.RetryAfterTotal)
+	}
+}
+
+func TestJiraClient_RetryableStatuses(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     string
+		wantRequests int
+		wantSuccess  bool
+	}{
+		{"409 not retried by default", "", 1, false},
+		{"409 retried when configured", "409,503", 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				if requests < 3 {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "10001", "key": "PROJ-1"}`))
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.MaxRetries = 3
+			cfg.RetryableStatuses = tt.statuses
+			client := NewJiraClient(cfg)
+			client.retryBaseDelay = time.Millisecond
+
+			resp, err := client.createIssue(context.Background(), map[string]interface{}{"fields": map[string]interface{}{"summary": "Test"}})
+			if requests != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, requests)
+			}
+			if tt.wantSuccess {
+				if err != nil || resp.Key != "PROJ-1" {
+					t.Fatalf("Expected issue to be created after retries, got %v, %v", resp, err)
+				}
+				for _, body := range bodies {
+					if body != bodies[0] || body == "" {
+						t.Errorf("Expected the same payload on every attempt, got %q", body)
+					}
+				}
+			} else if err == nil {
+				t.Error("Expected 409 to fail without retries")
+			}
+		})
+	}
+}
+
+func TestJiraClient_createIssue_RetriesTransientErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantRequests int
+		wantSuccess  bool
+	}{
+		{"429 retried", http.StatusTooManyRequests, 3, true},
+		{"503 retried", http.StatusServiceUnavailable, 3, true},
+		{"400 fails fast", http.StatusBadRequest, 1, false},
+		{"401 fails fast", http.StatusUnauthorized, 1, false},
+		{"403 fails fast", http.StatusForbidden, 1, false},
+		{"404 fails fast", http.StatusNotFound, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= 2 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "10001", "key": "PROJ-1"}`))
+			}))
+			defer server.Close()
+
+			// Config por defecto: 3 reintentos sobre 429, 502, 503 y 504
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.MaxRetries = 3
+			cfg.RetryableStatuses = config.DefaultRetryableStatuses
+			cfg.RetryBaseDelayMs = 1
+			client := NewJiraClient(cfg)
+
+			resp, err := client.createIssue(context.Background(), map[string]interface{}{"fields": map[string]interface{}{"summary": "Test"}})
+			if got := atomic.LoadInt32(&requests); int(got) != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, got)
+			}
+			if tt.wantSuccess && (err != nil || resp.Key != "PROJ-1") {
+				t.Fatalf("Expected issue to be created after retries, got %v, %v", resp, err)
+			}
+			if !tt.wantSuccess && err == nil {
+				t.Errorf("Expected %d to fail without retries", tt.status)
+			}
+		})
+	}
+}
+
+func TestJiraClient_createSubtasks_RetriesTransientErrors(t *testing.T) {
+	var subtaskRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"parent"`) && atomic.AddInt32(&subtaskRequests, 1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "10002", "key": "PROJ-2"}`))
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.MaxRetries = 3
+	cfg.RetryableStatuses = config.DefaultRetryableStatuses
+	client := NewJiraClient(cfg)
+
+	story := entities.NewUserStory("Historia", "Desc", "Crit", "Subtarea 1", "")
+	result, err := client.CreateUserStory(context.Background(), story, "PROJ", 2)
+	if err != nil || !result.Success {
+		t.Fatalf("Expected story to be created, got %v / %+v", err, result)
+	}
+	if len(result.Subtareas) != 1 || !result.Subtareas[0].Success {
+		t.Errorf("Expected the subtask to be created after retries, got %+v", result.Subtareas)
+	}
+	if got := atomic.LoadInt32(&subtaskRequests); got != 3 {
+		t.Errorf("Expected 3 subtask requests, got %d", got)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	base := 100 * time.Millisecond
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond} {
+		for i := 0; i < 20; i++ {
+			got := retryBackoff(base, attempt)
+			if got < want/2 || got > want {
+				t.Fatalf("retryBackoff(%v, %d) = %v, want between %v and %v", base, attempt, got, want/2, want)
+			}
+		}
+	}
+
+	if got := retryBackoff(base, 40); got < maxRetryDelay/2 || got > maxRetryDelay {
+		t.Errorf("retryBackoff() = %v, want it capped at %v", got, maxRetryDelay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		wantOK bool
+		want   time.Duration
+	}{
+		{"seconds", "10", true, 10 * time.Second},
+		{"empty", "", false, 0},
+		{"invalid", "soon", false, 0},
+		{"past_date", "Mon, 02 Jan 2006 15:04:05 GMT", true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = (%v, %v), want (%v, %v)", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestJiraClient_TestConnection(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -294,6 +660,117 @@ func TestJiraClient_ValidateSubtaskIssueType(t *testing.T) {
 	}
 }
 
+func TestJiraClient_ValidateStoryIssueType(t *testing.T) {
+	createMeta := `{"projects": [{"issuetypes": [
+		{"name": "Task", "subtask": false},
+		{"name": "Sub-task", "subtask": true},
+		{"name": "Feature", "subtask": false},
+		{"name": "Bug", "subtask": false}
+	]}]}`
+
+	tests := []struct {
+		name          string
+		issueType     string
+		autoPick      bool
+		expectedType  string
+		expectedError string
+	}{
+		{
+			name:         "configured_type_available",
+			issueType:    "Task",
+			expectedType: "Task",
+		},
+		{
+			name:          "configured_type_missing_suggests_available",
+			issueType:     "Story",
+			expectedType:  "Story",
+			expectedError: "issue type 'Story' is not available in project 'PROJ' (available: Task, Bug)",
+		},
+		{
+			name:         "configured_type_missing_auto_pick",
+			issueType:    "Story",
+			autoPick:     true,
+			expectedType: "Task",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/3/issue/createmeta" || r.URL.Query().Get("projectKeys") != "PROJ" {
+					t.Errorf("Unexpected request %s", r.URL.String())
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(createMeta))
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.DefaultIssueType = tt.issueType
+			cfg.AutoPickIssueType = tt.autoPick
+			client := NewJiraClient(cfg)
+
+			err := client.ValidateStoryIssueType(context.Background(), "PROJ")
+
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			} else if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("Expected error '%s', got: %v", tt.expectedError, err)
+			}
+
+			if got := client.storyIssueType("PROJ"); got != tt.expectedType {
+				t.Errorf("Expected issue type %s, got %s", tt.expectedType, got)
+			}
+			if cfg.DefaultIssueType != tt.issueType {
+				t.Errorf("DefaultIssueType changed to %s, the shared config should not be modified", cfg.DefaultIssueType)
+			}
+		})
+	}
+}
+
+func TestJiraClient_ValidateStoryIssueType_PerProject(t *testing.T) {
+	createMeta := map[string]string{
+		"PROJ":  `{"projects": [{"issuetypes": [{"name": "Task"}, {"name": "Bug"}]}]}`,
+		"OTHER": `{"projects": [{"issuetypes": [{"name": "Story"}, {"name": "Task"}]}]}`,
+		"EMPTY": `{"projects": [{"issuetypes": [{"name": "Sub-task", "subtask": true}, {"name": "Feature"}]}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(createMeta[r.URL.Query().Get("projectKeys")]))
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.DefaultIssueType = "Story"
+	cfg.AutoPickIssueType = true
+	client := NewJiraClient(cfg)
+
+	// El tipo elegido para PROJ no pasa a OTHER, que si tiene Story
+	for _, project := range []string{"PROJ", "OTHER"} {
+		if err := client.ValidateStoryIssueType(context.Background(), project); err != nil {
+			t.Fatalf("ValidateStoryIssueType(%s) error = %v", project, err)
+		}
+	}
+	if got := client.storyIssueType("PROJ"); got != "Task" {
+		t.Errorf("PROJ issue type = %s, want the auto-picked Task", got)
+	}
+	if got := client.storyIssueType("OTHER"); got != "Story" {
+		t.Errorf("OTHER issue type = %s, want Story", got)
+	}
+	fields := client.buildIssuePayload(entities.NewUserStory("Login", "Desc", "Crit", "", ""), "PROJ")["fields"].(map[string]interface{})
+	if issueType := fields["issuetype"].(map[string]interface{})["name"]; issueType != "Task" {
+		t.Errorf("PROJ payload issuetype = %v, want Task", issueType)
+	}
+
+	err := client.ValidateStoryIssueType(context.Background(), "EMPTY")
+	if err == nil || !strings.Contains(err.Error(), "has no other story issue types") {
+		t.Errorf("ValidateStoryIssueType(EMPTY) error = %v, want an error without candidates", err)
+	}
+}
+
 func TestJiraClient_ValidateFeatureIssueType(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -410,50 +887,203 @@ func TestJiraClient_ValidateParentIssue(t *testing.T) {
 	}
 }
 
-func TestJiraClient_CreateUserStory(t *testing.T) {
+func TestJiraClient_CreateIssueLink(t *testing.T) {
 	tests := []struct {
-		name         string
-		story        *entities.UserStory
-		statusCode   int
-		responseBody string
-		expectError  bool
+		name          string
+		statusCode    int
+		expectedError string
 	}{
 		{
-			name: "successful_story_creation",
-			story: entities.NewUserStory(
-				"Test Story",
-				"Test Description",
-				"Test Criteria",
-				"",
-				"",
-			),
-			statusCode:   http.StatusCreated,
-			responseBody: `{"id": "10001", "key": "TEST-123", "self": "https://test.atlassian.net/rest/api/3/issue/10001"}`,
-			expectError:  false,
+			name:       "link_created",
+			statusCode: http.StatusCreated,
 		},
 		{
-			name: "story_with_subtasks",
-			story: entities.NewUserStory(
-				"Test Story",
-				"Test Description",
-				"Test Criteria",
-				"Task 1;Task 2",
-				"",
-			),
-			statusCode:   http.StatusCreated,
-			responseBody: `{"id": "10002", "key": "TEST-124", "self": "https://test.atlassian.net/rest/api/3/issue/10002"}`,
-			expectError:  false,
+			name:          "link_type_not_found",
+			statusCode:    http.StatusNotFound,
+			expectedError: "error creating issue link: status 404",
 		},
-		{
-			name: "story_creation_failed",
-			story: entities.NewUserStory(
-				"Test Story",
-				"Test Description",
-				"Test Criteria",
-				"",
-				"",
-			),
-			statusCode:   http.StatusBadRequest,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/rest/api/3/issueLink" {
+					t.Errorf("Expected POST /rest/api/3/issueLink, got %s %s", r.Method, r.URL.Path)
+				}
+
+				var payload map[string]map[string]string
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("Failed to decode payload: %v", err)
+				}
+				if payload["type"]["name"] != "Relates" || payload["inwardIssue"]["key"] != "PROJ-2" || payload["outwardIssue"]["key"] != "PROJ-1" {
+					t.Errorf("Unexpected link payload: %v", payload)
+				}
+
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			client := NewJiraClient(cfg)
+
+			err := client.CreateIssueLink(context.Background(), "PROJ-2", "PROJ-1", "Relates")
+
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestJiraClient_DeleteIssue(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedError string
+		expectedIs    error
+	}{
+		{
+			name:       "issue_deleted",
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:          "already_deleted",
+			statusCode:    http.StatusNotFound,
+			expectedError: "PROJ-1 does not exist or was already deleted",
+			expectedIs:    repositories.ErrIssueNotFound,
+		},
+		{
+			name:          "no_delete_permission",
+			statusCode:    http.StatusForbidden,
+			expectedError: "requires the Delete Issues project permission",
+			expectedIs:    repositories.ErrPermissionDenied,
+		},
+		{
+			name:          "delete_rejected",
+			statusCode:    http.StatusBadRequest,
+			body:          `{"errorMessages": ["The issue has subtasks"]}`,
+			expectedError: "error deleting issue PROJ-1: status 400: The issue has subtasks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "DELETE" || r.URL.Path != "/rest/api/3/issue/PROJ-1" {
+					t.Errorf("Expected DELETE /rest/api/3/issue/PROJ-1, got %s %s", r.Method, r.URL.Path)
+				}
+				if r.URL.Query().Get("deleteSubtasks") != "true" {
+					t.Errorf("Expected deleteSubtasks=true, got %q", r.URL.RawQuery)
+				}
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			client := NewJiraClient(cfg)
+
+			err := client.DeleteIssue(context.Background(), "PROJ-1")
+
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.expectedError, err)
+			}
+			if tt.expectedIs != nil && !errors.Is(err, tt.expectedIs) {
+				t.Errorf("Expected error to wrap %v, got: %v", tt.expectedIs, err)
+			}
+		})
+	}
+}
+
+func TestJiraClient_DeleteIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/PROJ-1":
+			w.WriteHeader(http.StatusNoContent)
+		case "/rest/api/3/issue/PROJ-2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	client := NewJiraClient(cfg)
+
+	results := client.DeleteIssues(context.Background(), []string{"PROJ-1", "PROJ-2", "PROJ-3"})
+	if len(results) != 3 {
+		t.Fatalf("Expected one result per key, got %d", len(results))
+	}
+	if !results[0].Deleted || results[0].Failed() {
+		t.Errorf("Expected PROJ-1 to be deleted, got %+v", results[0])
+	}
+	if !results[1].AlreadyDeleted || results[1].Failed() {
+		t.Errorf("Expected PROJ-2 to be reported as already deleted, got %+v", results[1])
+	}
+	if !results[2].Failed() || !strings.Contains(results[2].Error, "permission denied") {
+		t.Errorf("Expected PROJ-3 to fail with a permissions error, got %+v", results[2])
+	}
+}
+
+func TestJiraClient_CreateUserStory(t *testing.T) {
+	tests := []struct {
+		name         string
+		story        *entities.UserStory
+		statusCode   int
+		responseBody string
+		expectError  bool
+	}{
+		{
+			name: "successful_story_creation",
+			story: entities.NewUserStory(
+				"Test Story",
+				"Test Description",
+				"Test Criteria",
+				"",
+				"",
+			),
+			statusCode:   http.StatusCreated,
+			responseBody: `{"id": "10001", "key": "TEST-123", "self": "https://test.atlassian.net/rest/api/3/issue/10001"}`,
+			expectError:  false,
+		},
+		{
+			name: "story_with_subtasks",
+			story: entities.NewUserStory(
+				"Test Story",
+				"Test Description",
+				"Test Criteria",
+				"Task 1;Task 2",
+				"",
+			),
+			statusCode:   http.StatusCreated,
+			responseBody: `{"id": "10002", "key": "TEST-124", "self": "https://test.atlassian.net/rest/api/3/issue/10002"}`,
+			expectError:  false,
+		},
+		{
+			name: "story_creation_failed",
+			story: entities.NewUserStory(
+				"Test Story",
+				"Test Description",
+				"Test Criteria",
+				"",
+				"",
+			),
+			statusCode:   http.StatusBadRequest,
 			responseBody: `{"errorMessages": ["Invalid request"]}`,
 			expectError:  true,
 		},
@@ -486,7 +1116,7 @@ func TestJiraClient_CreateUserStory(t *testing.T) {
 			cfg.ProjectKey = "TEST"
 			client := NewJiraClient(cfg)
 
-			result, err := client.CreateUserStory(context.Background(), tt.story, 1)
+			result, err := client.CreateUserStory(context.Background(), tt.story, "", 1)
 
 			if tt.expectError {
 				if result.Success {
@@ -521,6 +1151,69 @@ func TestJiraClient_CreateUserStory(t *testing.T) {
 	}
 }
 
+func TestJiraClient_newRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		path    string
+		wantURL string
+	}{
+		{
+			name:    "path with leading slash",
+			baseURL: "https://test.atlassian.net",
+			path:    "/rest/api/3/myself",
+			wantURL: "https://test.atlassian.net/rest/api/3/myself",
+		},
+		{
+			name:    "base URL with trailing slash",
+			baseURL: "https://test.atlassian.net/",
+			path:    "/rest/api/3/myself",
+			wantURL: "https://test.atlassian.net/rest/api/3/myself",
+		},
+		{
+			name:    "path without leading slash",
+			baseURL: "https://test.atlassian.net/jira/",
+			path:    "rest/api/3/search?jql=project%3DPROJ",
+			wantURL: "https://test.atlassian.net/jira/rest/api/3/search?jql=project%3DPROJ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.JiraURL = tt.baseURL
+			client := NewJiraClient(cfg)
+			client.baseURL = tt.baseURL
+
+			req, err := client.newRequest(context.Background(), "POST", tt.path, strings.NewReader("{}"))
+			if err != nil {
+				t.Fatalf("newRequest() error = %v", err)
+			}
+
+			if req.URL.String() != tt.wantURL {
+				t.Errorf("URL = %s, want %s", req.URL.String(), tt.wantURL)
+			}
+			if req.Method != "POST" {
+				t.Errorf("Method = %s, want POST", req.Method)
+			}
+
+			email, token, ok := req.BasicAuth()
+			if !ok || email != cfg.JiraEmail || token != cfg.JiraAPIToken {
+				t.Errorf("BasicAuth = %q/%q (ok=%v), want %q/%q", email, token, ok, cfg.JiraEmail, cfg.JiraAPIToken)
+			}
+			for header, want := range map[string]string{
+				"Content-Type": "application/json",
+				"Accept":       "application/json",
+				"User-Agent":   userAgent,
+			} {
+				if got := req.Header.Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestJiraClient_isJiraKey(t *testing.T) {
 	cfg := createTestConfig()
 	client := NewJiraClient(cfg)
@@ -619,6 +1312,393 @@ func TestJiraClient_buildIssuePayload(t *testing.T) {
 	}
 }
 
+func TestJiraClient_CreateUserStory_RowSubtaskType(t *testing.T) {
+	tests := []struct {
+		name             string
+		subtaskType      string
+		wantSuccess      bool
+		wantSubtaskTypes []string
+		wantErrContains  string
+	}{
+		{
+			name:             "global subtask type",
+			wantSuccess:      true,
+			wantSubtaskTypes: []string{"Sub-task", "Sub-task"},
+		},
+		{
+			name:             "per-row subtask type override",
+			subtaskType:      "Technical Task",
+			wantSuccess:      true,
+			wantSubtaskTypes: []string{"Technical Task", "Technical Task"},
+		},
+		{
+			name:            "non-subtask type is rejected",
+			subtaskType:     "Bug",
+			wantSuccess:     false,
+			wantErrContains: "invalid subtask_type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var createdTypes []string
+			issueTypeCalls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/rest/api/3/issuetype":
+					issueTypeCalls++
+					w.Write([]byte(`[{"name": "Sub-task", "subtask": true}, {"name": "Technical Task", "subtask": true}, {"name": "Bug", "subtask": false}]`))
+				case r.URL.Path == "/rest/api/3/issue" && r.Method == "POST":
+					var payload map[string]map[string]interface{}
+					json.NewDecoder(r.Body).Decode(&payload)
+					issueType := payload["fields"]["issuetype"].(map[string]interface{})["name"].(string)
+					if _, isSubtask := payload["fields"]["parent"]; isSubtask {
+						createdTypes = append(createdTypes, issueType)
+					}
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(fmt.Sprintf(`{"id": "1000%d", "key": "TEST-%d"}`, len(createdTypes), len(createdTypes))))
+				}
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			client := NewJiraClient(cfg)
+
+			for i := 0; i < 2; i++ {
+				story := entities.NewUserStory("Story", "Description", "Criteria", "Task 1;Task 2", "")
+				story.SubtaskType = tt.subtaskType
+				createdTypes = nil
+
+				result, err := client.CreateUserStory(context.Background(), story, "TEST", 2)
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				if result.Success != tt.wantSuccess {
+					t.Fatalf("Success = %v, want %v (error: %s)", result.Success, tt.wantSuccess, result.ErrorMessage)
+				}
+				if tt.wantErrContains != "" && !strings.Contains(result.ErrorMessage, tt.wantErrContains) {
+					t.Errorf("ErrorMessage = %q, want it to contain %q", result.ErrorMessage, tt.wantErrContains)
+				}
+				if strings.Join(createdTypes, ",") != strings.Join(tt.wantSubtaskTypes, ",") {
+					t.Errorf("Subtask issue types = %v, want %v", createdTypes, tt.wantSubtaskTypes)
+				}
+			}
+
+			// Los tipos por fila se validan una sola vez por ejecucion
+			if tt.subtaskType != "" && tt.wantSuccess && issueTypeCalls != 1 {
+				t.Errorf("Expected subtask type to be validated once, got %d issuetype calls", issueTypeCalls)
+			}
+		})
+	}
+}
+
+func TestJiraClient_CreateUserStory_SkipExisting(t *testing.T) {
+	searchBody := `{"total": 3, "issues": [
+		{"key": "TEST-10", "fields": {"summary": "Login de usuario", "parent": {"key": "TEST-1"}}},
+		{"key": "TEST-11", "fields": {"summary": "Login de usuario"}},
+		{"key": "TEST-12", "fields": {"summary": "Login de usuario admin", "parent": {"key": "TEST-2"}}}
+	]}`
+
+	tests := []struct {
+		name        string
+		title       string
+		parent      string
+		wantKey     string
+		wantCreated bool
+	}{
+		{"existing_with_same_parent", "Login de usuario", "TEST-1", "TEST-10", false},
+		{"existing_without_parent", "login de usuario.", "", "TEST-11", false},
+		{"same_summary_other_parent_is_created", "Login de usuario", "TEST-2", "TEST-99", true},
+		{"no_exact_match_is_created", "Login", "", "TEST-99", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/rest/api/3/serverInfo":
+					w.Write([]byte(`{"deploymentType": "Server"}`))
+				case "/rest/api/3/search":
+					jql := r.URL.Query().Get("jql")
+					if !strings.Contains(jql, `issuetype = "Story"`) || !strings.Contains(jql, `project = "TEST"`) {
+						t.Errorf("Unexpected JQL: %s", jql)
+					}
+					w.Write([]byte(searchBody))
+				case "/rest/api/3/issue":
+					posts++
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(`{"id": "10099", "key": "TEST-99"}`))
+				default:
+					t.Errorf("Unexpected request to %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.SkipExisting = true
+			client := NewJiraClient(cfg)
+
+			story := entities.NewUserStory(tt.title, "Desc", "Criterio", "", tt.parent)
+			result, err := client.CreateUserStory(context.Background(), story, "TEST", 2)
+			if err != nil {
+				t.Fatalf("CreateUserStory() error = %v", err)
+			}
+
+			if !result.Success || result.IssueKey != tt.wantKey {
+				t.Errorf("Expected successful result with key %s, got %+v", tt.wantKey, result)
+			}
+			if result.WasCreated != tt.wantCreated {
+				t.Errorf("WasCreated = %v, want %v", result.WasCreated, tt.wantCreated)
+			}
+			wantPosts := 0
+			if tt.wantCreated {
+				wantPosts = 1
+			}
+			if posts != wantPosts {
+				t.Errorf("Expected %d POST /issue, got %d", wantPosts, posts)
+			}
+			if !tt.wantCreated && len(result.Warnings) != 1 {
+				t.Errorf("Expected a warning for the existing issue, got %v", result.Warnings)
+			}
+		})
+	}
+}
+
+func TestJiraClient_CreateUserStory_SkipExistingEpicLink(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/serverInfo":
+			w.Write([]byte(`{"deploymentType": "Server"}`))
+		case "/rest/api/3/search":
+			if fields := r.URL.Query().Get("fields"); !strings.Contains(fields, "customfield_10014") {
+				t.Errorf("Expected the Epic Link field to be requested, got fields=%s", fields)
+			}
+			// Proyecto company-managed: la historia tiene el Epic en el campo Epic Link, sin parent
+			w.Write([]byte(`{"total": 2, "issues": [
+				{"key": "TEST-10", "fields": {"summary": "Login de usuario", "customfield_10014": "TEST-1"}},
+				{"key": "TEST-11", "fields": {"summary": "Login de usuario", "customfield_10014": "TEST-2"}}
+			]}`))
+		case "/rest/api/3/issue":
+			posts++
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "10099", "key": "TEST-99"}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.SkipExisting = true
+	cfg.EpicLinkField = "customfield_10014"
+	client := NewJiraClient(cfg)
+
+	story := entities.NewUserStory("Login de usuario", "Desc", "Criterio", "", "TEST-2")
+	result, err := client.CreateUserStory(context.Background(), story, "TEST", 2)
+	if err != nil {
+		t.Fatalf("CreateUserStory() error = %v", err)
+	}
+	if !result.Success || result.IssueKey != "TEST-11" || result.WasCreated {
+		t.Errorf("Expected the story already linked to Epic TEST-2 to be reused, got %+v", result)
+	}
+	if posts != 0 {
+		t.Errorf("Expected no issue to be created, got %d POSTs", posts)
+	}
+}
+
+func TestJiraClient_CreateUserStory_SkipExistingQuotedTitle(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/serverInfo":
+			w.Write([]byte(`{"deploymentType": "Server"}`))
+		case "/rest/api/3/search":
+			jql := r.URL.Query().Get("jql")
+			if !strings.HasSuffix(jql, `AND summary ~ "exportar reporte mensual"`) {
+				t.Errorf("Unexpected JQL for a quoted title: %s", jql)
+			}
+			w.Write([]byte(`{"total": 1, "issues": [{"key": "TEST-20", "fields": {"summary": "Exportar \"reporte\" mensual"}}]}`))
+		case "/rest/api/3/issue":
+			posts++
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "10099", "key": "TEST-99"}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.SkipExisting = true
+	client := NewJiraClient(cfg)
+
+	story := entities.NewUserStory(`Exportar "reporte" mensual`, "Desc", "Criterio", "", "")
+	result, err := client.CreateUserStory(context.Background(), story, "TEST", 2)
+	if err != nil {
+		t.Fatalf("CreateUserStory() error = %v", err)
+	}
+	if !result.Success || result.IssueKey != "TEST-20" || result.WasCreated {
+		t.Errorf("Expected the existing TEST-20 to be reused, got %+v", result)
+	}
+	if posts != 0 {
+		t.Errorf("Expected no issue to be created, got %d POSTs", posts)
+	}
+}
+
+func TestJiraClient_CreateUserStory_SkipExistingSearchFails(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue":
+			posts++
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.SkipExisting = true
+	client := NewJiraClient(cfg)
+
+	result, err := client.CreateUserStory(context.Background(), entities.NewUserStory("Login", "Desc", "Criterio", "", ""), "TEST", 2)
+	if err != nil {
+		t.Fatalf("CreateUserStory() error = %v", err)
+	}
+	if result.Success || !strings.Contains(result.ErrorMessage, "could not check for an existing issue") {
+		t.Errorf("Expected failed row when the search fails, got %+v", result)
+	}
+	if posts != 0 {
+		t.Errorf("Expected no issue to be created when the search fails, got %d POSTs", posts)
+	}
+}
+
+func TestJiraClient_CreateUserStory_SubtaskFailurePolicy(t *testing.T) {
+	tests := []struct {
+		policy       string
+		wantSuccess  bool
+		wantWarnings int
+	}{
+		{policy: config.SubtaskFailureIgnore, wantSuccess: true, wantWarnings: 0},
+		{policy: config.SubtaskFailureWarn, wantSuccess: true, wantWarnings: 1},
+		{policy: config.SubtaskFailureFail, wantSuccess: false, wantWarnings: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload map[string]map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&payload)
+				if _, isSubtask := payload["fields"]["parent"]; isSubtask {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"errorMessages": ["Subtask rejected"]}`))
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "10001", "key": "TEST-1"}`))
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.SubtaskFailurePolicy = tt.policy
+			client := NewJiraClient(cfg)
+
+			story := entities.NewUserStory("Story", "Description", "Criteria", "Task 1;Task 2", "")
+			result, err := client.CreateUserStory(context.Background(), story, "TEST", 2)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if !result.AllSubtasksFailed() {
+				t.Fatalf("Expected all subtasks to fail, got %d results", len(result.Subtareas))
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Success = %v, want %v", result.Success, tt.wantSuccess)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+			if !tt.wantSuccess && !strings.Contains(result.ErrorMessage, "all 2 subtasks failed") {
+				t.Errorf("ErrorMessage = %q, want all-subtasks-failed message", result.ErrorMessage)
+			}
+			if result.IssueKey != "TEST-1" {
+				t.Errorf("IssueKey = %q, want the created story key to be kept", result.IssueKey)
+			}
+		})
+	}
+}
+
+// recordingSubtaskLogger guarda las lineas de log de subtareas
+type recordingSubtaskLogger struct {
+	lines []string
+}
+
+func (l *recordingSubtaskLogger) LogSubtaskCreated(parentKey, subtaskKey, description string) {
+	l.lines = append(l.lines, "created "+subtaskKey)
+}
+
+func (l *recordingSubtaskLogger) LogSubtaskError(parentKey, description string, err error) {
+	l.lines = append(l.lines, "error "+description)
+}
+
+func (l *recordingSubtaskLogger) LogSubtasksSummary(parentKey string, created, total int) {
+	l.lines = append(l.lines, fmt.Sprintf("created %d/%d subtasks for %s", created, total, parentKey))
+}
+
+func TestJiraClient_CreateUserStory_SubtaskLogMode(t *testing.T) {
+	tests := []struct {
+		mode  string
+		lines []string
+	}{
+		{config.SubtaskLogVerbose, []string{"created TEST-2", "created TEST-3", "error Task 3"}},
+		{config.SubtaskLogSummary, []string{"created 2/3 subtasks for TEST-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			var created int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload map[string]map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&payload)
+				if payload["fields"]["summary"] == "Task 3" {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"errorMessages": ["Subtask rejected"]}`))
+					return
+				}
+				created++
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"id": "1000%d", "key": "TEST-%d"}`, created, created)
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.SubtaskLogMode = tt.mode
+			client := NewJiraClient(cfg)
+			recorder := &recordingSubtaskLogger{}
+			client.SetSubtaskLogger(recorder)
+
+			story := entities.NewUserStory("Story", "Description", "Criteria", "Task 1;Task 2;Task 3", "")
+			if _, err := client.CreateUserStory(context.Background(), story, "TEST", 2); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if strings.Join(recorder.lines, "|") != strings.Join(tt.lines, "|") {
+				t.Errorf("log lines = %v, want %v", recorder.lines, tt.lines)
+			}
+		})
+	}
+}
+
 func TestJiraClient_buildSubtaskPayload(t *testing.T) {
 	cfg := createTestConfig()
 	client := NewJiraClient(cfg)
@@ -627,7 +1707,7 @@ func TestJiraClient_buildSubtaskPayload(t *testing.T) {
 	parentKey := "TEST-123"
 	projectKey := "PROJ"
 
-	payload := client.buildSubtaskPayload(description, parentKey, projectKey)
+	payload := client.buildSubtaskPayload(description, &JiraCreateResponse{ID: "10001", Key: parentKey}, projectKey, client.config.SubtaskIssueType)
 
 	fields, ok := payload["fields"].(map[string]interface{})
 	if !ok {
@@ -672,6 +1752,64 @@ func TestJiraClient_buildSubtaskPayload(t *testing.T) {
 	}
 }
 
+func TestJiraClient_buildSubtaskPayload_ParentStyle(t *testing.T) {
+	tests := []struct {
+		name       string
+		style      string
+		parent     *JiraCreateResponse
+		wantParent map[string]interface{}
+	}{
+		{
+			name:       "default style uses key",
+			style:      "",
+			parent:     &JiraCreateResponse{ID: "10001", Key: "TEST-123"},
+			wantParent: map[string]interface{}{"key": "TEST-123"},
+		},
+		{
+			name:       "key style",
+			style:      config.SubtaskParentStyleKey,
+			parent:     &JiraCreateResponse{ID: "10001", Key: "TEST-123"},
+			wantParent: map[string]interface{}{"key": "TEST-123"},
+		},
+		{
+			name:       "id style",
+			style:      config.SubtaskParentStyleID,
+			parent:     &JiraCreateResponse{ID: "10001", Key: "TEST-123"},
+			wantParent: map[string]interface{}{"id": "10001"},
+		},
+		{
+			name:       "id style without id falls back to key",
+			style:      config.SubtaskParentStyleID,
+			parent:     &JiraCreateResponse{Key: "TEST-123"},
+			wantParent: map[string]interface{}{"key": "TEST-123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.SubtaskParentStyle = tt.style
+			client := NewJiraClient(cfg)
+
+			payload := client.buildSubtaskPayload("Subtask", tt.parent, "PROJ", cfg.SubtaskIssueType)
+			fields := payload["fields"].(map[string]interface{})
+			parent, ok := fields["parent"].(map[string]interface{})
+			if !ok {
+				t.Fatal("Expected parent to be a map")
+			}
+
+			if len(parent) != len(tt.wantParent) {
+				t.Fatalf("parent = %v, want %v", parent, tt.wantParent)
+			}
+			for key, want := range tt.wantParent {
+				if parent[key] != want {
+					t.Errorf("parent[%q] = %v, want %v", key, parent[key], want)
+				}
+			}
+		})
+	}
+}
+
 func TestJiraClient_GetIssueTypes_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -994,7 +2132,7 @@ func TestJiraClient_createSubtasks_ComprehensiveTests(t *testing.T) {
 			client := NewJiraClient(cfg)
 
 			result := entities.NewProcessResult(1)
-			client.createSubtasks(context.Background(), tt.story, tt.parentKey, result)
+			client.createSubtasks(context.Background(), tt.story, &JiraCreateResponse{Key: tt.parentKey}, "TEST", result)
 
 			// Verify number of calls made
 			if callCount != tt.expectedCalls {
@@ -1033,6 +2171,69 @@ func TestJiraClient_createSubtasks_ComprehensiveTests(t *testing.T) {
 	}
 }
 
+func TestJiraClient_HTTPTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Jira colgado: no responde hasta que termina el test
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.HTTPTimeoutSeconds = 1
+	client := NewJiraClient(cfg)
+
+	// El timeout es por intento, no del http.Client, para no acotar las llamadas de metadata
+	if client.httpClient.Timeout != 0 {
+		t.Errorf("httpClient.Timeout = %v, want no client-wide timeout", client.httpClient.Timeout)
+	}
+
+	start := time.Now()
+	err := client.TestConnection(context.Background())
+	if err == nil || err.Error() != "request timed out after 1s" {
+		t.Errorf("TestConnection() error = %v, want request timed out after 1s", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("TestConnection() took %v, expected the timeout to stop it", elapsed)
+	}
+
+	result, err := client.CreateUserStory(context.Background(), entities.NewUserStory("Login", "Desc", "Criterio", "", ""), "TEST", 2)
+	if err != nil {
+		t.Fatalf("CreateUserStory() error = %v", err)
+	}
+	if result.Success || !strings.Contains(result.ErrorMessage, "request timed out after 1s") {
+		t.Errorf("Expected the row to fail with a timeout, got %+v", result)
+	}
+	if code := entities.ClassifyError(result.ErrorMessage); code != entities.ErrorCodeNetwork {
+		t.Errorf("ClassifyError() = %s, want %s", code, entities.ErrorCodeNetwork)
+	}
+}
+
+func TestJiraClient_MetadataTimeoutLongerThanHTTPTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// createmeta tarda mas que HTTP_TIMEOUT_SECONDS pero menos que METADATA_TIMEOUT_SECONDS
+		time.Sleep(1500 * time.Millisecond)
+		w.Write([]byte(`{"projects": [{"key": "PROJ", "issuetypes": [{"name": "Story"}]}]}`))
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.HTTPTimeoutSeconds = 1
+	cfg.MetadataTimeoutSeconds = 5
+	client := NewJiraClient(cfg)
+
+	issueTypes, err := client.getCreatableIssueTypes(context.Background(), "PROJ")
+	if err != nil {
+		t.Fatalf("getCreatableIssueTypes() error = %v, want METADATA_TIMEOUT_SECONDS to apply", err)
+	}
+	if len(issueTypes) != 1 || issueTypes[0].Name != "Story" {
+		t.Errorf("getCreatableIssueTypes() = %+v, want [Story]", issueTypes)
+	}
+}
+
 func TestJiraClient_TestConnection_NetworkErrors(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1256,6 +2457,460 @@ func TestJiraClient_ValidateFeatureIssueType_ErrorPaths(t *testing.T) {
 	}
 }
 
+func TestJiraClient_buildIssuePayload_CriteriaHeading(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.AcceptanceCriteriaField = ""
+	cfg.CriteriaHeading = "Acceptance Criteria"
+	client := NewJiraClient(cfg)
+
+	story := entities.NewUserStory("Test Story", "Test Description", "Criteria 1; Criteria 2", "", "")
+
+	payload := client.buildIssuePayload(story, "PROJ")
+	fields := payload["fields"].(map[string]interface{})
+
+	data, err := json.Marshal(fields["description"])
+	if err != nil {
+		t.Fatalf("Failed to marshal description: %v", err)
+	}
+	if !strings.Contains(string(data), "--- Acceptance Criteria ---") {
+		t.Errorf("Expected configured heading in description ADF, got %s", data)
+	}
+	if strings.Contains(string(data), "Criterios de Aceptación") {
+		t.Errorf("Expected default heading to be replaced, got %s", data)
+	}
+}
+
+func TestJiraClient_buildIssuePayload_AcceptanceCriteriaAsList(t *testing.T) {
+	for _, field := range []string{"", "customfield_10001"} {
+		cfg := createTestConfig()
+		cfg.AcceptanceCriteriaField = field
+		cfg.AcceptanceCriteriaAsList = true
+		client := NewJiraClient(cfg)
+
+		story := entities.NewUserStory("Test Story", "Test Description", "Criteria 1; Criteria 2", "", "")
+		fields := client.buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+
+		target := "description"
+		if field != "" {
+			target = field
+		}
+		data, _ := json.Marshal(fields[target])
+		if strings.Count(string(data), `"type":"listItem"`) != 2 {
+			t.Errorf("field %q: expected 2 listItem nodes, got %s", target, data)
+		}
+	}
+}
+
+func TestJiraClient_buildIssuePayload_ImportDateField(t *testing.T) {
+	cfg := createTestConfig()
+	story := entities.NewUserStory("Test Story", "Test Description", "Criteria", "", "")
+
+	fields := NewJiraClient(cfg).buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+	if _, ok := fields["customfield_10050"]; ok {
+		t.Error("Expected no import date field when IMPORT_DATE_FIELD is unset")
+	}
+
+	cfg.ImportDateField = "customfield_10050"
+	before := time.Now().Add(-time.Second)
+	fields = NewJiraClient(cfg).buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+
+	value, ok := fields["customfield_10050"].(string)
+	if !ok {
+		t.Fatalf("Expected import date as string, got %T", fields["customfield_10050"])
+	}
+	imported, err := time.Parse(jiraDateTimeLayout, value)
+	if err != nil {
+		t.Fatalf("Import date %q is not a valid Jira date-time: %v", value, err)
+	}
+	if imported.Before(before) || imported.After(time.Now().Add(time.Second)) {
+		t.Errorf("Import date %v is not the current time", imported)
+	}
+}
+
+func TestJiraClient_buildIssuePayload_Labels(t *testing.T) {
+	cfg := createTestConfig()
+	story := entities.NewUserStory("Test Story", "Test Description", "Criteria", "", "")
+
+	fields := NewJiraClient(cfg).buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+	if _, ok := fields["labels"]; ok {
+		t.Error("Expected no labels field when the story has no labels")
+	}
+
+	story.Labels = []string{"backend", "tech debt", "tech_debt"}
+	fields = NewJiraClient(cfg).buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+	if labels, ok := fields["labels"].([]string); !ok || strings.Join(labels, ",") != "backend,tech_debt" {
+		t.Errorf("labels = %v, want [backend tech_debt]", fields["labels"])
+	}
+}
+
+func TestJiraClient_CreateUserStory_LabelSpacesError(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.LabelSpaces = config.LabelSpacesError
+	client := NewJiraClient(cfg)
+
+	story := entities.NewUserStory("Login", "Desc", "Criterio", "", "")
+	story.Labels = []string{"backend", "tech debt"}
+	result, err := client.CreateUserStory(context.Background(), story, "TEST", 2)
+	if err != nil {
+		t.Fatalf("CreateUserStory() error = %v", err)
+	}
+	if result.Success || !strings.Contains(result.ErrorMessage, `"tech debt"`) {
+		t.Errorf("Expected failed row naming the label with spaces, got %+v", result)
+	}
+	if posts != 0 {
+		t.Errorf("Expected no request to Jira, got %d", posts)
+	}
+}
+
+func TestJiraClient_buildUpdatePayload(t *testing.T) {
+	story := entities.NewUserStory("Nuevo titulo", "", "Criterio 1", "", "")
+
+	tests := []struct {
+		name       string
+		clearEmpty bool
+		criteria   string
+		wantClears []string
+	}{
+		{"empty_columns_left_untouched", false, "", nil},
+		{"empty_columns_cleared_when_opted_in", true, "", []string{"description", "environment"}},
+		{"empty_criteria_field_cleared", true, "customfield_10001", []string{"description", "environment"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.UpdateClearsEmpty = tt.clearEmpty
+			cfg.AcceptanceCriteriaField = tt.criteria
+			fields := NewJiraClient(cfg).buildUpdatePayload(story)["fields"].(map[string]interface{})
+
+			if fields["summary"] != "Nuevo titulo" {
+				t.Errorf("summary = %v, want Nuevo titulo", fields["summary"])
+			}
+
+			for _, field := range []string{"description", "environment"} {
+				value, present := fields[field]
+				wantClear := containsString(tt.wantClears, field)
+				if tt.criteria == "" && field == "description" {
+					// Sin campo de criterios, la descripcion lleva los criterios y no queda vacia
+					if !present || value == nil {
+						t.Errorf("Expected description with the criteria, got %v", value)
+					}
+					continue
+				}
+				if wantClear && (!present || value != nil) {
+					t.Errorf("%s = %v (present %v), want cleared with null", field, value, present)
+				}
+				if !wantClear && present {
+					t.Errorf("%s = %v, want field left out of the update", field, value)
+				}
+			}
+		})
+	}
+
+	cfg := createTestConfig()
+	cfg.UpdateClearsEmpty = true
+	fields := NewJiraClient(cfg).buildUpdatePayload(entities.NewUserStory("", "Desc", "Crit", "", ""))["fields"].(map[string]interface{})
+	if _, ok := fields["summary"]; ok {
+		t.Error("Expected an empty titulo never to clear the summary")
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestJiraClient_buildIssuePayload_Environment(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		environment string
+		wantField   bool
+		wantPlain   bool
+	}{
+		{"omitted_when_empty", "", "   ", false, false},
+		{"adf_by_default", "", "Chrome 120 / Windows 11", true, false},
+		{"plain_when_configured", config.EnvironmentFormatPlain, "Chrome 120 / Windows 11", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.EnvironmentFormat = tt.format
+			client := NewJiraClient(cfg)
+
+			story := entities.NewUserStory("Bug", "Desc", "Criterio", "", "")
+			story.Environment = tt.environment
+
+			fields := client.buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+			value, ok := fields["environment"]
+			if ok != tt.wantField {
+				t.Fatalf("environment present = %v, want %v", ok, tt.wantField)
+			}
+			if !tt.wantField {
+				return
+			}
+
+			if tt.wantPlain {
+				if value != tt.environment {
+					t.Errorf("environment = %v, want plain %q", value, tt.environment)
+				}
+				return
+			}
+			data, _ := json.Marshal(value)
+			if !strings.Contains(string(data), `"type":"doc"`) || !strings.Contains(string(data), tt.environment) {
+				t.Errorf("Expected environment as ADF, got %s", data)
+			}
+		})
+	}
+}
+
+func TestJiraClient_buildIssuePayload_APIVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       string
+		criteriaField string
+		wantWiki      bool
+	}{
+		{"v3_adf_by_default", "", "", false},
+		{"v3_adf", config.JiraAPIVersion3, "customfield_10001", false},
+		{"v2_wiki_in_description", config.JiraAPIVersion2, "", true},
+		{"v2_wiki_in_criteria_field", config.JiraAPIVersion2, "customfield_10001", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.JiraAPIVersion = tt.version
+			cfg.AcceptanceCriteriaField = tt.criteriaField
+			cfg.AcceptanceCriteriaAsList = true
+			client := NewJiraClient(cfg)
+
+			story := entities.NewUserStory("Login", "Como usuario quiero entrar", "Valida email;Valida clave", "", "")
+			story.Environment = "Chrome"
+			fields := client.buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+
+			textFields := []string{"description", "environment"}
+			if tt.criteriaField != "" {
+				textFields = append(textFields, tt.criteriaField)
+			}
+			for _, field := range textFields {
+				_, isString := fields[field].(string)
+				_, isADF := fields[field].(*ADFDocument)
+				if tt.wantWiki && !isString {
+					t.Errorf("%s = %T, want a wiki markup string", field, fields[field])
+				}
+				if !tt.wantWiki && !isADF {
+					t.Errorf("%s = %T, want an ADF document", field, fields[field])
+				}
+			}
+
+			if !tt.wantWiki {
+				return
+			}
+			want := "Como usuario quiero entrar\n\n--- Criterios de Aceptación ---\n\n* Valida email\n* Valida clave"
+			if tt.criteriaField != "" {
+				want = "Como usuario quiero entrar"
+				if criteria := fields[tt.criteriaField]; criteria != "* Valida email\n* Valida clave" {
+					t.Errorf("criteria = %q, want a wiki list", criteria)
+				}
+			}
+			if fields["description"] != want {
+				t.Errorf("description = %q, want %q", fields["description"], want)
+			}
+		})
+	}
+}
+
+func TestJiraClient_AuthMethod(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		want   string
+	}{
+		{"basic_by_default", "", "Basic "},
+		{"basic", config.AuthMethodBasic, "Basic "},
+		{"bearer", config.AuthMethodBearer, "Bearer pat-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var headers []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				headers = append(headers, r.Header.Get("Authorization"))
+				mu.Unlock()
+				if r.Method == "POST" {
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(`{"id": "10001", "key": "PROJ-1"}`))
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.AuthMethod = tt.method
+			cfg.JiraPAT = "pat-token"
+			client := NewJiraClient(cfg)
+			ctx := context.Background()
+
+			client.TestConnection(ctx)
+			client.CreateUserStory(ctx, entities.NewUserStory("Historia", "Desc", "Crit", "", ""), "PROJ", 2)
+
+			if len(headers) != 2 {
+				t.Fatalf("Expected 2 requests, got %d", len(headers))
+			}
+			for _, header := range headers {
+				if !strings.HasPrefix(header, tt.want) {
+					t.Errorf("Authorization = %q, want prefix %q", header, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestJiraClient_APIVersionPaths(t *testing.T) {
+	tests := []struct {
+		version    string
+		wantPrefix string
+	}{
+		{"", "/rest/api/3/"},
+		{config.JiraAPIVersion3, "/rest/api/3/"},
+		{config.JiraAPIVersion2, "/rest/api/2/"},
+	}
+
+	for _, tt := range tests {
+		t.Run("version_"+tt.version, func(t *testing.T) {
+			var mu sync.Mutex
+			var paths []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				paths = append(paths, r.URL.Path)
+				mu.Unlock()
+				switch {
+				case r.Method == "POST":
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(`{"id": "10001", "key": "PROJ-1"}`))
+				case strings.HasSuffix(r.URL.Path, "/issuetype"):
+					w.Write([]byte(`[]`))
+				default:
+					w.Write([]byte(`{}`))
+				}
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.JiraAPIVersion = tt.version
+			client := NewJiraClient(cfg)
+			ctx := context.Background()
+
+			client.TestConnection(ctx)
+			client.ValidateProject(ctx, "PROJ")
+			client.GetIssueTypes(ctx)
+			client.ValidateParentIssue(ctx, "PROJ-9")
+			client.CreateUserStory(ctx, entities.NewUserStory("Historia", "Desc", "Crit", "", ""), "PROJ", 2)
+
+			if len(paths) != 5 {
+				t.Fatalf("Expected 5 requests, got %v", paths)
+			}
+			for _, path := range paths {
+				if !strings.HasPrefix(path, tt.wantPrefix) {
+					t.Errorf("Expected %s to start with %s", path, tt.wantPrefix)
+				}
+			}
+		})
+	}
+}
+
+func TestJiraClient_buildIssuePayload_MaxDescriptionLength(t *testing.T) {
+	story := entities.NewUserStory("Titulo", "Descripción muy larga pegada por accidente", "Criterio", "", "")
+
+	tests := []struct {
+		name     string
+		max      int
+		policy   string
+		marker   string
+		expected string
+	}{
+		{
+			name:     "truncate_policy",
+			max:      20,
+			policy:   "truncate",
+			expected: "Descripción muy l...",
+		},
+		{
+			name:     "truncate_with_custom_marker",
+			max:      20,
+			policy:   "truncate",
+			marker:   "…",
+			expected: "Descripción muy lar…",
+		},
+		{
+			name:     "warn_policy_sends_full_description",
+			max:      20,
+			policy:   "warn",
+			expected: story.Descripcion,
+		},
+		{
+			name:     "no_limit",
+			max:      0,
+			policy:   "truncate",
+			expected: story.Descripcion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.MaxDescriptionLength = tt.max
+			cfg.DescriptionLengthPolicy = tt.policy
+			cfg.TruncationMarker = tt.marker
+			client := NewJiraClient(cfg)
+
+			payload := client.buildIssuePayload(story, "PROJ")
+			fields := payload["fields"].(map[string]interface{})
+
+			data, err := json.Marshal(fields["description"])
+			if err != nil {
+				t.Fatalf("Failed to marshal description: %v", err)
+			}
+
+			expected, _ := json.Marshal(tt.expected)
+			if !strings.Contains(string(data), `"text":`+string(expected)) {
+				t.Errorf("Expected description text %s, got %s", expected, data)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	if got := truncateText("áéíóúáéíóú", 8, "..."); got != "áéíóú..." {
+		t.Errorf("Expected rune-safe truncation, got %q", got)
+	}
+	if got := truncateText("corto", 10, "..."); got != "corto" {
+		t.Errorf("Expected short text unchanged, got %q", got)
+	}
+	if got := truncateText("abcdef", 2, "..."); got != "ab" {
+		t.Errorf("Expected hard cut when marker does not fit, got %q", got)
+	}
+}
+
 func TestJiraClient_buildIssuePayload_VariousScenarios(t *testing.T) {
 	tests := []struct {
 		name                        string
@@ -1351,3 +3006,148 @@ func TestJiraClient_buildIssuePayload_VariousScenarios(t *testing.T) {
 		})
 	}
 }
+
+// countingRoundTripper cuenta los requests y responde sin red
+type countingRoundTripper struct {
+	paths []string
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.paths = append(rt.paths, req.Method+" "+req.URL.Path)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(`{"accountId":"123"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestNewJiraClient_WithTransport(t *testing.T) {
+	transport := &countingRoundTripper{}
+	client := NewJiraClient(createTestConfig(), WithTransport(transport))
+
+	for i := 0; i < 2; i++ {
+		if err := client.TestConnection(context.Background()); err != nil {
+			t.Fatalf("TestConnection() error = %v", err)
+		}
+	}
+
+	if len(transport.paths) != 2 {
+		t.Fatalf("Expected the custom transport to see 2 requests, got %v", transport.paths)
+	}
+	if transport.paths[0] != "GET /rest/api/3/myself" {
+		t.Errorf("request = %q, want GET /rest/api/3/myself", transport.paths[0])
+	}
+
+	if _, ok := NewJiraClient(createTestConfig(), WithTransport(nil)).httpClient.Transport.(*http.Transport); !ok {
+		t.Error("Expected the default transport when nil is passed")
+	}
+}
+
+// inflightRoundTripper mide cuantos requests estan en curso a la vez
+type inflightRoundTripper struct {
+	current int32
+	max     int32
+	total   int32
+}
+
+func (rt *inflightRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	now := atomic.AddInt32(&rt.current, 1)
+	for {
+		seen := atomic.LoadInt32(&rt.max)
+		if now <= seen || atomic.CompareAndSwapInt32(&rt.max, seen, now) {
+			break
+		}
+	}
+	atomic.AddInt32(&rt.total, 1)
+	time.Sleep(5 * time.Millisecond)
+	atomic.AddInt32(&rt.current, -1)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestJiraClient_MaxConcurrentRequests(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.MaxConcurrentRequests = 3
+	transport := &inflightRoundTripper{}
+	client := NewJiraClient(cfg, WithTransport(transport))
+
+	// Varios "archivos" en paralelo, cada uno con varias "historias" en paralelo, comparten el cliente
+	var files sync.WaitGroup
+	for f := 0; f < 4; f++ {
+		files.Add(1)
+		go func() {
+			defer files.Done()
+			var stories sync.WaitGroup
+			for s := 0; s < 5; s++ {
+				stories.Add(1)
+				go func() {
+					defer stories.Done()
+					if err := client.TestConnection(context.Background()); err != nil {
+						t.Errorf("TestConnection() error = %v", err)
+					}
+				}()
+			}
+			stories.Wait()
+		}()
+	}
+	files.Wait()
+
+	if transport.total != 20 {
+		t.Errorf("Expected 20 requests, got %d", transport.total)
+	}
+	if transport.max > 3 {
+		t.Errorf("in-flight requests reached %d, want at most 3", transport.max)
+	}
+	if len(client.inflight) != 0 {
+		t.Errorf("Expected every slot to be released, %d still taken", len(client.inflight))
+	}
+}
+
+func TestJiraClient_CreateUserStory_MaxIssuesPerRun(t *testing.T) {
+	var posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&posts, 1)
+		if n == 1 {
+			// El primer intento falla y no debe contar para el tope
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorMessages": ["bad request"]}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(fmt.Sprintf(`{"id": "%d", "key": "PROJ-%d"}`, n, n)))
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.MaxIssuesPerRun = 2
+	client := NewJiraClient(cfg)
+	ctx := context.Background()
+	story := entities.NewUserStory("Historia", "Desc", "Crit", "", "")
+
+	result, err := client.CreateUserStory(ctx, story, "PROJ", 2)
+	if err != nil || result.Success {
+		t.Fatalf("Expected a failed row without error, got %v / %+v", err, result)
+	}
+
+	for row := 3; row <= 4; row++ {
+		result, err := client.CreateUserStory(ctx, story, "PROJ", row)
+		if err != nil || !result.Success {
+			t.Fatalf("Row %d: expected success, got %v / %+v", row, err, result)
+		}
+	}
+
+	_, err = client.CreateUserStory(ctx, story, "PROJ", 5)
+	if !errors.Is(err, repositories.ErrIssueLimitReached) {
+		t.Fatalf("Expected ErrIssueLimitReached after the second story, got %v", err)
+	}
+	if got := atomic.LoadInt32(&posts); got != 3 {
+		t.Errorf("Expected 3 create requests, got %d", got)
+	}
+}
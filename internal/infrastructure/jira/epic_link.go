@@ -0,0 +1,114 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// epicHierarchyLevel es el nivel de jerarquia de los Epics en Jira Cloud; Jira Server no lo
+// informa y se reconoce el tipo por nombre
+const epicHierarchyLevel = 1
+
+// epicCache guarda, por key, si el parent ya consultado es un Epic
+type epicCache struct {
+	mu    sync.Mutex
+	epics map[string]bool
+}
+
+func (c *epicCache) get(key string) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	epic, ok := c.epics[key]
+	return epic, ok
+}
+
+func (c *epicCache) set(key string, epic bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epics == nil {
+		c.epics = make(map[string]bool)
+	}
+	c.epics[key] = epic
+}
+
+// setEpicLink reemplaza fields.parent por el campo Epic Link (EPIC_LINK_FIELD) cuando el parent
+// es un Epic: los proyectos clasicos (company-managed) no aceptan parent para vincular una
+// historia a su Epic. Sin EPIC_LINK_FIELD, o con un parent de otro tipo, queda parent. Si no se
+// puede consultar el tipo del parent se envia parent con un aviso
+func (jc *JiraClient) setEpicLink(ctx context.Context, payload map[string]interface{}, result *entities.ProcessResult) {
+	if jc.config.EpicLinkField == "" {
+		return
+	}
+
+	fields, ok := payload["fields"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	parent, ok := fields["parent"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	parentKey, _ := parent["key"].(string)
+
+	epic, err := jc.isEpic(ctx, parentKey)
+	if err != nil {
+		result.AddWarning(fmt.Sprintf("could not check whether parent %s is an Epic, sent as parent: %v", parentKey, err))
+		return
+	}
+	if epic {
+		delete(fields, "parent")
+		fields[jc.config.EpicLinkField] = parentKey
+	}
+}
+
+// isEpic consulta una sola vez por ejecucion el tipo de cada parent
+func (jc *JiraClient) isEpic(ctx context.Context, issueKey string) (bool, error) {
+	if epic, ok := jc.epics.get(issueKey); ok {
+		return epic, nil
+	}
+
+	ctx, cancel := jc.withRequestTimeout(ctx)
+	defer cancel()
+
+	req, err := jc.newRequest(ctx, "GET", jc.apiPath("issue/%s?fields=issuetype", issueKey), nil)
+	if err != nil {
+		return false, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		return false, fmt.Errorf("error getting issue type: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("error getting issue type: status %d", resp.StatusCode)
+	}
+
+	var issue struct {
+		Fields struct {
+			IssueType struct {
+				Name           string `json:"name"`
+				HierarchyLevel *int   `json:"hierarchyLevel"`
+			} `json:"issuetype"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return false, fmt.Errorf("error decoding issue: %w", err)
+	}
+
+	issueType := issue.Fields.IssueType
+	epic := strings.EqualFold(issueType.Name, "Epic")
+	if issueType.HierarchyLevel != nil {
+		epic = *issueType.HierarchyLevel == epicHierarchyLevel
+	}
+
+	jc.epics.set(issueKey, epic)
+	return epic, nil
+}
@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// epicLinkServer responde el tipo de los parents de issueTypes y guarda los fields de cada
+// historia creada; cuenta las consultas de tipo por key
+type epicLinkServer struct {
+	mu         sync.Mutex
+	issueTypes map[string]string
+	lookups    map[string]int
+	created    []map[string]interface{}
+}
+
+func (s *epicLinkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/rest/api/3/issue/"):
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/3/issue/")
+		s.lookups[key]++
+		issueType, ok := s.issueTypes[key]
+		if !ok || r.URL.Query().Get("fields") != "issuetype" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"key": "` + key + `", "fields": {"issuetype": ` + issueType + `}}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/3/issue":
+		var payload struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		s.created = append(s.created, payload.Fields)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "10001", "key": "PROJ-100"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestJiraClient_CreateUserStory_EpicLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		epicLinkField string
+		parent        string
+		wantEpicLink  bool
+		wantWarning   bool
+	}{
+		{name: "team_managed_uses_parent", epicLinkField: "", parent: "PROJ-1"},
+		{name: "classic_epic_uses_epic_link", epicLinkField: "customfield_10014", parent: "PROJ-1", wantEpicLink: true},
+		{name: "classic_server_epic_by_name", epicLinkField: "customfield_10014", parent: "PROJ-2", wantEpicLink: true},
+		{name: "classic_feature_above_epic_uses_parent", epicLinkField: "customfield_10014", parent: "PROJ-3"},
+		{name: "classic_unknown_parent_type_uses_parent", epicLinkField: "customfield_10014", parent: "PROJ-404", wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &epicLinkServer{
+				issueTypes: map[string]string{
+					"PROJ-1": `{"name": "Epic", "hierarchyLevel": 1}`,
+					"PROJ-2": `{"name": "Epic"}`,
+					"PROJ-3": `{"name": "Initiative", "hierarchyLevel": 2}`,
+				},
+				lookups: map[string]int{},
+			}
+			httpServer := httptest.NewServer(server)
+			defer httpServer.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = httpServer.URL
+			cfg.EpicLinkField = tt.epicLinkField
+			client := NewJiraClient(cfg)
+
+			// Dos historias con el mismo parent: el tipo se consulta una sola vez si la consulta funciona
+			for _, title := range []string{"Login", "Logout"} {
+				story := entities.NewUserStory(title, "Desc", "Crit", "", tt.parent)
+				result, err := client.CreateUserStory(context.Background(), story, "PROJ", 2)
+				if err != nil || !result.Success {
+					t.Fatalf("CreateUserStory() = %+v, %v", result, err)
+				}
+				if got := len(result.Warnings) > 0; got != tt.wantWarning {
+					t.Errorf("Warnings = %v, want warning %v", result.Warnings, tt.wantWarning)
+				}
+			}
+
+			for _, fields := range server.created {
+				epicLink, hasEpicLink := fields["customfield_10014"]
+				parent, hasParent := fields["parent"].(map[string]interface{})
+				if tt.wantEpicLink {
+					if !hasEpicLink || epicLink != tt.parent || hasParent {
+						t.Errorf("fields = %v, want customfield_10014=%s and no parent", fields, tt.parent)
+					}
+					continue
+				}
+				if hasEpicLink || !hasParent || parent["key"] != tt.parent {
+					t.Errorf("fields = %v, want parent %s and no Epic Link", fields, tt.parent)
+				}
+			}
+
+			// Un error de la consulta no se cachea: se reintenta con la historia siguiente
+			wantLookups := 1
+			switch {
+			case tt.epicLinkField == "":
+				wantLookups = 0
+			case tt.wantWarning:
+				wantLookups = 2
+			}
+			if server.lookups[tt.parent] != wantLookups {
+				t.Errorf("Issue type lookups = %d, want %d", server.lookups[tt.parent], wantLookups)
+			}
+		})
+	}
+}
@@ -3,47 +3,75 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 
 	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
 	"historiadorgo/internal/infrastructure/config"
 )
 
 type FeatureManager struct {
 	jiraClient *JiraClient
 	config     *config.Config
-}
 
-type JiraSearchResponse struct {
-	Issues []JiraIssue `json:"issues"`
-	Total  int         `json:"total"`
+	// locks serializa la resolucion de una misma Feature (por proyecto y descripcion normalizada)
+	// para que goroutines concurrentes no la creen dos veces; resolved guarda la key obtenida
+	// porque la busqueda JQL puede tardar en indexar una Feature recien creada
+	mu       sync.Mutex
+	locks    map[string]*sync.Mutex
+	resolved map[string]string
 }
 
 func NewFeatureManager(jiraClient *JiraClient, cfg *config.Config) *FeatureManager {
 	return &FeatureManager{
 		jiraClient: jiraClient,
 		config:     cfg,
+		locks:      make(map[string]*sync.Mutex),
+		resolved:   make(map[string]string),
 	}
 }
 
 func (fm *FeatureManager) CreateOrGetFeature(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
 	result := entities.NewFeatureResult(description)
 
-	if fm.isJiraKey(description) {
-		if err := fm.jiraClient.ValidateParentIssue(ctx, description); err != nil {
-			result.SetError(fmt.Sprintf("Parent issue validation failed: %v", err))
+	// PARENT_RESOLUTION decide si un valor con forma de key se usa como key o como descripcion
+	resolution := fm.config.GetParentResolution()
+	looksLikeKey := fm.isJiraKey(description)
+
+	switch {
+	case looksLikeKey && (resolution == config.ParentResolutionKeyFirst || resolution == config.ParentResolutionKeyOnly):
+		return fm.existingParent(ctx, description, result), nil
+	case !looksLikeKey && resolution == config.ParentResolutionKeyOnly:
+		result.SetError(fmt.Sprintf("parent '%s' is not an issue key (PARENT_RESOLUTION=%s)", description, resolution))
+		return result, nil
+	}
+
+	if fm.config.ParentBySummary {
+		issueKey, err := fm.FindIssueBySummary(ctx, description, projectKey)
+		if err != nil {
+			result.SetError(fmt.Sprintf("Parent lookup by summary failed: %v", err))
 			return result, nil
 		}
-		result.SetExisting(description)
+		result.SetExisting(issueKey)
 		return result, nil
 	}
 
 	normalizedDesc := fm.normalizeDescription(description)
 	result.SetNormalizedDescription(normalizedDesc)
 
+	lockKey := projectKey + "|" + normalizedDesc
+	unlock := fm.lockFeature(lockKey)
+	defer unlock()
+
+	if resolvedKey, ok := fm.resolvedFeature(lockKey); ok {
+		result.SetExisting(resolvedKey)
+		return result, nil
+	}
+
 	existingKey, err := fm.SearchExistingFeature(ctx, description, projectKey)
 	if err != nil {
 		result.SetError(fmt.Sprintf("Error searching existing features: %v", err))
@@ -51,24 +79,85 @@ func (fm *FeatureManager) CreateOrGetFeature(ctx context.Context, description, p
 	}
 
 	if existingKey != "" {
+		fm.rememberFeature(lockKey, existingKey)
 		result.SetExisting(existingKey)
 		return result, nil
 	}
 
+	// Con feature_first, un valor con forma de key sin Feature parecida se usa como key
+	if looksLikeKey && resolution == config.ParentResolutionFeatureFirst {
+		return fm.existingParent(ctx, description, result), nil
+	}
+
 	issuePayload := fm.buildFeaturePayload(description, projectKey)
 
 	issue, err := fm.jiraClient.createIssue(ctx, issuePayload)
+	if errors.Is(err, repositories.ErrIssueLimitReached) {
+		return nil, err
+	}
 	if err != nil {
 		result.SetError(fmt.Sprintf("Error creating feature: %v", err))
 		return result, nil
 	}
 
 	issueURL := fmt.Sprintf("%s/browse/%s", fm.jiraClient.baseURL, issue.Key)
+	fm.rememberFeature(lockKey, issue.Key)
 	result.SetSuccess(issue.Key, issueURL, true)
 
 	return result, nil
 }
 
+// existingParent valida que issueKey exista y lo devuelve como parent ya existente
+func (fm *FeatureManager) existingParent(ctx context.Context, issueKey string, result *entities.FeatureResult) *entities.FeatureResult {
+	if err := fm.jiraClient.ValidateParentIssue(ctx, issueKey); err != nil {
+		result.SetError(fmt.Sprintf("Parent issue validation failed: %v", err))
+		return result
+	}
+	result.SetExisting(issueKey)
+	return result
+}
+
+// lockFeature toma el mutex asociado a key y devuelve la funcion para liberarlo
+func (fm *FeatureManager) lockFeature(key string) func() {
+	fm.mu.Lock()
+	lock, ok := fm.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		fm.locks[key] = lock
+	}
+	fm.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+func (fm *FeatureManager) resolvedFeature(key string) (string, bool) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	issueKey, ok := fm.resolved[key]
+	return issueKey, ok
+}
+
+func (fm *FeatureManager) rememberFeature(key, issueKey string) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	fm.resolved[key] = issueKey
+}
+
+// ForgetFeature descarta las descripciones resueltas a featureKey
+func (fm *FeatureManager) ForgetFeature(featureKey string) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	for key, issueKey := range fm.resolved {
+		if issueKey == featureKey {
+			delete(fm.resolved, key)
+		}
+	}
+}
+
 func (fm *FeatureManager) SearchExistingFeature(ctx context.Context, description, projectKey string) (string, error) {
 	normalizedDesc := fm.normalizeDescription(description)
 
@@ -79,29 +168,12 @@ func (fm *FeatureManager) SearchExistingFeature(ctx context.Context, description
 		fm.escapeJQLString(normalizedDesc),
 	)
 
-	searchURL := fmt.Sprintf("/rest/api/3/search?jql=%s&fields=key,summary", url.QueryEscape(jql))
-
-	req, err := fm.jiraClient.createRequest(ctx, "GET", searchURL, nil)
+	issues, err := fm.jiraClient.searchIssues(ctx, jql, "key,summary")
 	if err != nil {
-		return "", fmt.Errorf("error creating search request: %w", err)
-	}
-
-	resp, err := fm.jiraClient.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("error executing search: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("search failed with status: %d", resp.StatusCode)
-	}
-
-	var searchResp JiraSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
-		return "", fmt.Errorf("error decoding search response: %w", err)
+		return "", err
 	}
 
-	for _, issue := range searchResp.Issues {
+	for _, issue := range issues {
 		if summary, ok := issue.Fields["summary"].(string); ok {
 			existingNormalized := fm.normalizeDescription(summary)
 			if fm.isSimilarDescription(normalizedDesc, existingNormalized) {
@@ -113,15 +185,53 @@ func (fm *FeatureManager) SearchExistingFeature(ctx context.Context, description
 	return "", nil
 }
 
+// FindIssueBySummary busca un issue existente del proyecto cuyo summary coincida exactamente
+// (sin distinguir mayusculas ni puntuacion). Sin coincidencias o con varias devuelve error
+func (fm *FeatureManager) FindIssueBySummary(ctx context.Context, summary, projectKey string) (string, error) {
+	normalized := fm.normalizeDescription(summary)
+
+	jql := fmt.Sprintf(
+		`project = "%s" AND summary ~ "%s"`,
+		projectKey,
+		fm.escapeJQLString(normalized),
+	)
+
+	issues, err := fm.jiraClient.searchIssues(ctx, jql, "key,summary")
+	if err != nil {
+		return "", err
+	}
+
+	// summary ~ es busqueda de texto: se filtran las coincidencias exactas
+	var matches []string
+	for _, issue := range issues {
+		if existing, ok := issue.Fields["summary"].(string); ok && fm.normalizeDescription(existing) == normalized {
+			matches = append(matches, issue.Key)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no issue found with summary '%s' in project %s", summary, projectKey)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("ambiguous parent summary '%s': matches %s", summary, strings.Join(matches, ", "))
+	}
+}
+
 func (fm *FeatureManager) ValidateFeatureRequiredFields(ctx context.Context, projectKey string) ([]string, error) {
-	endpoint := fmt.Sprintf("/rest/api/3/issue/createmeta?projectKeys=%s&expand=projects.issuetypes.fields", projectKey)
+	// createmeta usa su propio timeout para no bloquear el preview si el endpoint es lento
+	ctx, cancel := context.WithTimeout(ctx, fm.config.GetMetadataTimeout())
+	defer cancel()
+
+	endpoint := fm.jiraClient.apiPath("issue/createmeta?projectKeys=%s&expand=projects.issuetypes.fields", projectKey)
 
-	req, err := fm.jiraClient.createRequest(ctx, "GET", endpoint, nil)
+	req, err := fm.jiraClient.newRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	resp, err := fm.jiraClient.httpClient.Do(req)
+	resp, err := fm.jiraClient.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error getting create meta: %w", err)
 	}
@@ -183,12 +293,15 @@ func (fm *FeatureManager) ValidateFeatureRequiredFields(ctx context.Context, pro
 }
 
 func (fm *FeatureManager) buildFeaturePayload(description, projectKey string) map[string]interface{} {
+	// El summary se recorta al limite de Jira; la descripcion conserva el texto completo
+	summary := truncateText(description, fm.config.GetFeatureSummaryMaxLength(), fm.config.GetTruncationMarker())
+
 	fields := map[string]interface{}{
 		"project": map[string]interface{}{
 			"key": projectKey,
 		},
-		"summary":     description,
-		"description": CreateDescriptionADF(fmt.Sprintf("Feature creado automáticamente: %s", description)),
+		"summary":     summary,
+		"description": fm.jiraClient.descriptionValue(fmt.Sprintf("Feature creado automáticamente: %s", description)),
 		"issuetype": map[string]interface{}{
 			"name": fm.config.FeatureIssueType,
 		},
@@ -203,60 +316,68 @@ func (fm *FeatureManager) buildFeaturePayload(description, projectKey string) ma
 		}
 	}
 
-	return map[string]interface{}{
-		"fields": fields,
+	// Labels y componentes comunes se suman a los que ya vengan en los campos requeridos
+	if labels := fm.config.GetFeatureLabels(); len(labels) > 0 {
+		merged := existingList(fields["labels"])
+		for _, label := range labels {
+			if !containsValue(merged, label) {
+				merged = append(merged, label)
+			}
+		}
+		fields["labels"] = merged
 	}
-}
-
-func (fm *FeatureManager) normalizeDescription(description string) string {
-	desc := strings.ToLower(description)
-	desc = strings.TrimSpace(desc)
 
-	re := regexp.MustCompile(`[^\w\s]`)
-	desc = re.ReplaceAllString(desc, "")
-
-	re = regexp.MustCompile(`\s+`)
-	desc = re.ReplaceAllString(desc, " ")
+	if components := fm.config.GetFeatureComponents(); len(components) > 0 {
+		merged := existingList(fields["components"])
+		for _, name := range components {
+			if !containsComponent(merged, name) {
+				merged = append(merged, map[string]interface{}{"name": name})
+			}
+		}
+		fields["components"] = merged
+	}
 
-	return desc
+	return map[string]interface{}{
+		"fields": fields,
+	}
 }
 
-func (fm *FeatureManager) isSimilarDescription(desc1, desc2 string) bool {
-	words1 := strings.Fields(desc1)
-	words2 := strings.Fields(desc2)
-
-	if len(words1) == 0 || len(words2) == 0 {
-		return desc1 == desc2
+// existingList devuelve el valor de un campo de tipo lista, o una lista vacia si no lo es
+func existingList(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
 	}
+	return []interface{}{}
+}
 
-	commonWords := 0
-	wordMap := make(map[string]bool)
-
-	for _, word := range words1 {
-		if len(word) > 2 {
-			wordMap[word] = true
+func containsValue(list []interface{}, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
 		}
 	}
+	return false
+}
 
-	for _, word := range words2 {
-		if len(word) > 2 && wordMap[word] {
-			commonWords++
+func containsComponent(list []interface{}, name string) bool {
+	for _, item := range list {
+		if component, ok := item.(map[string]interface{}); ok && component["name"] == name {
+			return true
 		}
 	}
+	return false
+}
 
-	totalWords := len(words1)
-	if len(words2) > totalWords {
-		totalWords = len(words2)
-	}
+func (fm *FeatureManager) normalizeDescription(description string) string {
+	return entities.NormalizeFeatureDescription(description)
+}
 
-	similarity := float64(commonWords) / float64(totalWords)
-	return similarity >= 0.7
+func (fm *FeatureManager) isSimilarDescription(desc1, desc2 string) bool {
+	return entities.FeatureDescriptionSimilarity(desc1, desc2) >= fm.config.GetFeatureSimilarityThreshold()
 }
 
 func (fm *FeatureManager) escapeJQLString(str string) string {
-	str = strings.ReplaceAll(str, `"`, `\"`)
-	str = strings.ReplaceAll(str, `\`, `\\`)
-	return str
+	return escapeJQLString(str)
 }
 
 func (fm *FeatureManager) isJiraKey(str string) bool {
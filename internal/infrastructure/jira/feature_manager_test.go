@@ -3,11 +3,17 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"historiadorgo/internal/domain/entities"
 	"historiadorgo/internal/infrastructure/config"
 )
 
@@ -189,6 +195,91 @@ func TestFeatureManager_CreateOrGetFeature_CreateNew(t *testing.T) {
 	}
 }
 
+func TestFeatureManager_CreateOrGetFeature_ConcurrentSameFeature(t *testing.T) {
+	fm, server := createTestFeatureManager()
+	defer server.Close()
+
+	var createCalls int32
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/rest/api/3/search") {
+			// La busqueda nunca encuentra la Feature, como si aun no estuviera indexada
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(JiraSearchResponse{Issues: []JiraIssue{}, Total: 0})
+		} else if strings.Contains(r.URL.Path, "/rest/api/3/issue") && r.Method == "POST" {
+			atomic.AddInt32(&createCalls, 1)
+			time.Sleep(20 * time.Millisecond)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(JiraCreateResponse{ID: "10001", Key: "PROJ-900"})
+		}
+	})
+
+	const workers = 20
+	results := make([]*entities.FeatureResult, workers)
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = fm.CreateOrGetFeature(context.Background(), "Nueva Feature Compartida", "PROJ")
+		}(i)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&createCalls); got != 1 {
+		t.Errorf("Expected exactly 1 create call, got %d", got)
+	}
+
+	created := 0
+	for i := 0; i < workers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("Worker %d: expected no error, got: %v", i, errs[i])
+		}
+		if results[i].IssueKey != "PROJ-900" {
+			t.Errorf("Worker %d: expected key PROJ-900, got %s", i, results[i].IssueKey)
+		}
+		if results[i].WasCreated {
+			created++
+		}
+	}
+	if created != 1 {
+		t.Errorf("Expected exactly 1 result marked as created, got %d", created)
+	}
+}
+
+func TestFeatureManager_ForgetFeature(t *testing.T) {
+	fm, server := createTestFeatureManager()
+	defer server.Close()
+
+	var createCalls int32
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/rest/api/3/search") {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(JiraSearchResponse{Issues: []JiraIssue{}, Total: 0})
+		} else if strings.Contains(r.URL.Path, "/rest/api/3/issue") && r.Method == "POST" {
+			calls := atomic.AddInt32(&createCalls, 1)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(JiraCreateResponse{ID: "10001", Key: fmt.Sprintf("PROJ-90%d", calls)})
+		}
+	})
+
+	first, err := fm.CreateOrGetFeature(context.Background(), "Feature eliminada", "PROJ")
+	if err != nil || first.IssueKey != "PROJ-901" {
+		t.Fatalf("Expected PROJ-901 to be created, got %+v, %v", first, err)
+	}
+
+	fm.ForgetFeature("PROJ-901")
+
+	second, err := fm.CreateOrGetFeature(context.Background(), "Feature eliminada", "PROJ")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if second.IssueKey != "PROJ-902" || !second.WasCreated {
+		t.Errorf("Expected a forgotten feature to be created again, got %+v", second)
+	}
+}
+
 func TestFeatureManager_SearchExistingFeature(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -615,6 +706,40 @@ func TestFeatureManager_ValidateFeatureRequiredFields_NetworkErrors(t *testing.T
 	})
 }
 
+func TestFeatureManager_ValidateFeatureRequiredFields_MetadataTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// createmeta lento: responde solo despues de que el cliente abandona
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.MetadataTimeoutSeconds = 1
+
+	fm := NewFeatureManager(NewJiraClient(cfg), cfg)
+
+	start := time.Now()
+	fields, err := fm.ValidateFeatureRequiredFields(context.Background(), "PROJ")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("Expected timeout error, got none")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context deadline exceeded, got: %v", err)
+	}
+	if fields != nil {
+		t.Error("Expected nil fields on timeout")
+	}
+	if elapsed > 3*time.Second {
+		t.Errorf("Expected metadata call to time out after ~1s, took %v", elapsed)
+	}
+}
+
 func TestFeatureManager_ValidateFeatureRequiredFields_EdgeCases(t *testing.T) {
 	fm, server := createTestFeatureManager()
 	defer server.Close()
@@ -833,7 +958,7 @@ func TestFeatureManager_escapeJQLString(t *testing.T) {
 		},
 		{
 			input:    `Text with "quotes"`,
-			expected: `Text with \\"quotes\\"`,
+			expected: `Text with \"quotes\"`,
 		},
 		{
 			input:    `Text with \backslash`,
@@ -841,7 +966,11 @@ func TestFeatureManager_escapeJQLString(t *testing.T) {
 		},
 		{
 			input:    `Text with "quotes" and \backslash`,
-			expected: `Text with \\"quotes\\" and \\backslash`,
+			expected: `Text with \"quotes\" and \\backslash`,
+		},
+		{
+			input:    `Escaped \"quote`,
+			expected: `Escaped \\\"quote`,
 		},
 		{
 			input:    ``,
@@ -962,6 +1091,86 @@ func TestFeatureManager_buildFeaturePayload(t *testing.T) {
 	}
 }
 
+func TestFeatureManager_buildFeaturePayload_LabelsAndComponents(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.FeatureRequiredFields = `{"labels": ["feature", "importado"], "components": [{"name": "Core"}]}`
+	cfg.FeatureLabels = "importado, roadmap"
+	cfg.FeatureComponents = "Backend,Core"
+	fm := NewFeatureManager(NewJiraClient(cfg), cfg)
+
+	payload := fm.buildFeaturePayload("Test Feature", "PROJ")
+	fields := payload["fields"].(map[string]interface{})
+
+	labels, ok := fields["labels"].([]interface{})
+	if !ok {
+		t.Fatalf("Expected labels to be a list, got %T", fields["labels"])
+	}
+	if len(labels) != 3 || labels[0] != "feature" || labels[1] != "importado" || labels[2] != "roadmap" {
+		t.Errorf("labels = %v, want [feature importado roadmap]", labels)
+	}
+
+	components, ok := fields["components"].([]interface{})
+	if !ok {
+		t.Fatalf("Expected components to be a list, got %T", fields["components"])
+	}
+	if len(components) != 2 {
+		t.Fatalf("Expected 2 components, got %v", components)
+	}
+	if name := components[1].(map[string]interface{})["name"]; name != "Backend" {
+		t.Errorf("components[1] = %v, want Backend", name)
+	}
+
+	// Sin campos requeridos, los valores configurados se envian tal cual
+	cfg.FeatureRequiredFields = ""
+	fields = fm.buildFeaturePayload("Test Feature", "PROJ")["fields"].(map[string]interface{})
+	if labels := fields["labels"].([]interface{}); len(labels) != 2 {
+		t.Errorf("labels = %v, want [importado roadmap]", labels)
+	}
+}
+
+func TestFeatureManager_buildFeaturePayload_SummaryMaxLength(t *testing.T) {
+	description := strings.Repeat("Gestión de usuarios ", 20)
+
+	tests := []struct {
+		name      string
+		maxLength int
+		wantLen   int
+	}{
+		{"jira_limit_by_default", 0, 255},
+		{"configured_limit", 40, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.FeatureSummaryMaxLength = tt.maxLength
+			cfg.TruncationMarker = "..."
+			fm := NewFeatureManager(NewJiraClient(cfg), cfg)
+
+			fields := fm.buildFeaturePayload(description, "PROJ")["fields"].(map[string]interface{})
+
+			summary := fields["summary"].(string)
+			if got := len([]rune(summary)); got != tt.wantLen {
+				t.Errorf("summary length = %d, want %d", got, tt.wantLen)
+			}
+			if !strings.HasSuffix(summary, "...") {
+				t.Errorf("Expected truncated summary to end with marker, got %q", summary)
+			}
+
+			data, _ := json.Marshal(fields["description"])
+			if !strings.Contains(string(data), strings.TrimSpace(description)) {
+				t.Errorf("Expected full text in feature description, got %s", data)
+			}
+		})
+	}
+
+	cfg := createTestConfig()
+	fm := NewFeatureManager(NewJiraClient(cfg), cfg)
+	if summary := fm.buildFeaturePayload("Short feature", "PROJ")["fields"].(map[string]interface{})["summary"]; summary != "Short feature" {
+		t.Errorf("summary = %v, want unchanged short description", summary)
+	}
+}
+
 func TestFeatureManager_CreateOrGetFeature_SearchError(t *testing.T) {
 	fm, server := createTestFeatureManager()
 	defer server.Close()
@@ -1178,3 +1387,145 @@ func TestFeatureManager_SearchExistingFeature_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestFeatureManager_CreateOrGetFeature_ParentBySummary(t *testing.T) {
+	tests := []struct {
+		name          string
+		issues        string
+		wantSuccess   bool
+		wantKey       string
+		expectedError string
+	}{
+		{
+			name:        "unique_summary_match",
+			issues:      `[{"key": "PROJ-7", "fields": {"summary": "Gestión de Usuarios"}}, {"key": "PROJ-8", "fields": {"summary": "Gestion de usuarios avanzada"}}]`,
+			wantSuccess: true,
+			wantKey:     "PROJ-7",
+		},
+		{
+			name:          "no_match",
+			issues:        `[{"key": "PROJ-8", "fields": {"summary": "Gestion de usuarios avanzada"}}]`,
+			expectedError: "no issue found with summary 'Gestión de usuarios'",
+		},
+		{
+			name:          "ambiguous_match",
+			issues:        `[{"key": "PROJ-7", "fields": {"summary": "Gestión de usuarios"}}, {"key": "PROJ-9", "fields": {"summary": "gestión de usuarios."}}]`,
+			expectedError: "ambiguous parent summary 'Gestión de usuarios': matches PROJ-7, PROJ-9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "POST" {
+					t.Error("Expected no issue to be created with PARENT_BY_SUMMARY")
+				}
+				jql := r.URL.Query().Get("jql")
+				if strings.Contains(jql, "issuetype") {
+					t.Errorf("Expected summary lookup across issue types, got JQL: %s", jql)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"issues": ` + tt.issues + `}`))
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.ParentBySummary = true
+			fm := NewFeatureManager(NewJiraClient(cfg), cfg)
+
+			result, err := fm.CreateOrGetFeature(context.Background(), "Gestión de usuarios", "PROJ")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Expected success %v, got %v (%s)", tt.wantSuccess, result.Success, result.ErrorMessage)
+			}
+			if tt.wantKey != "" && (result.IssueKey != tt.wantKey || result.WasCreated) {
+				t.Errorf("Expected existing issue %s, got %s (created=%v)", tt.wantKey, result.IssueKey, result.WasCreated)
+			}
+			if tt.expectedError != "" && !strings.Contains(result.ErrorMessage, tt.expectedError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectedError, result.ErrorMessage)
+			}
+		})
+	}
+}
+
+func TestFeatureManager_CreateOrGetFeature_ParentResolution(t *testing.T) {
+	// "REL-2024" parece una key pero tambien es la descripcion de una Feature existente
+	const parent = "REL-2024"
+
+	tests := []struct {
+		name          string
+		resolution    string
+		featureExists bool
+		keyExists     bool
+		wantKey       string
+		wantCreated   bool
+		expectedError string
+	}{
+		{name: "key_first uses the key", resolution: config.ParentResolutionKeyFirst, featureExists: true, keyExists: true, wantKey: parent},
+		{name: "unset behaves as key_first", resolution: "", featureExists: true, keyExists: true, wantKey: parent},
+		{name: "key_first does not fall back to a feature", resolution: config.ParentResolutionKeyFirst, featureExists: true, expectedError: "Parent issue validation failed"},
+		{name: "feature_first prefers the feature", resolution: config.ParentResolutionFeatureFirst, featureExists: true, keyExists: true, wantKey: "PROJ-50"},
+		{name: "feature_first falls back to the key", resolution: config.ParentResolutionFeatureFirst, keyExists: true, wantKey: parent},
+		{name: "key_only uses the key", resolution: config.ParentResolutionKeyOnly, featureExists: true, keyExists: true, wantKey: parent},
+		{name: "feature_only finds the feature", resolution: config.ParentResolutionFeatureOnly, featureExists: true, keyExists: true, wantKey: "PROJ-50"},
+		{name: "feature_only creates the feature", resolution: config.ParentResolutionFeatureOnly, keyExists: true, wantKey: "PROJ-60", wantCreated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Query().Get("jql") != "":
+					if tt.featureExists {
+						w.Write([]byte(`{"issues": [{"key": "PROJ-50", "fields": {"summary": "REL-2024"}}]}`))
+						return
+					}
+					w.Write([]byte(`{"issues": []}`))
+				case r.Method == "POST":
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(`{"key": "PROJ-60"}`))
+				case r.URL.Path == "/rest/api/3/issue/"+parent && tt.keyExists:
+					w.Write([]byte(`{"key": "REL-2024"}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.ParentResolution = tt.resolution
+			fm := NewFeatureManager(NewJiraClient(cfg), cfg)
+
+			result, err := fm.CreateOrGetFeature(context.Background(), parent, "PROJ")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if tt.expectedError != "" {
+				if result.Success || !strings.Contains(result.ErrorMessage, tt.expectedError) {
+					t.Errorf("Expected error containing %q, got success=%v %q", tt.expectedError, result.Success, result.ErrorMessage)
+				}
+				return
+			}
+			if !result.Success || result.IssueKey != tt.wantKey || result.WasCreated != tt.wantCreated {
+				t.Errorf("Expected %s (created=%v), got %s (created=%v, error=%q)", tt.wantKey, tt.wantCreated, result.IssueKey, result.WasCreated, result.ErrorMessage)
+			}
+		})
+	}
+
+	t.Run("key_only rejects a description", func(t *testing.T) {
+		cfg := createTestConfig()
+		cfg.ParentResolution = config.ParentResolutionKeyOnly
+		fm := NewFeatureManager(NewJiraClient(cfg), cfg)
+
+		result, _ := fm.CreateOrGetFeature(context.Background(), "Gestión de usuarios", "PROJ")
+		if result.Success || !strings.Contains(result.ErrorMessage, "is not an issue key") {
+			t.Errorf("Expected key_only to reject a description, got success=%v %q", result.Success, result.ErrorMessage)
+		}
+	})
+}
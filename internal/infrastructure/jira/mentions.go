@@ -0,0 +1,100 @@
+package jira
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// mentionPattern reconoce @email y @accountId (24 hex o "prefijo:uuid") al inicio del texto o
+// despues de un separador, para no confundir el @ de un email comun con una mencion
+var mentionPattern = regexp.MustCompile(`(^|[^\w@.])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)*\.[A-Za-z]{2,}|[0-9a-f]{24}|\d+:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\b`)
+
+// MentionResolver devuelve el accountId de un token de mencion (sin el @); ok=false deja el texto literal
+type MentionResolver func(token string) (accountID string, ok bool)
+
+// ResolveMentions reemplaza los tokens @email/@accountId de los textos del documento por nodos
+// mention. Los tokens que resolve no reconoce quedan como texto
+func (doc *ADFDocument) ResolveMentions(resolve MentionResolver) {
+	resolveMentionsIn(doc.Content, resolve)
+}
+
+func resolveMentionsIn(nodes []ADFContent, resolve MentionResolver) {
+	for i := range nodes {
+		if len(nodes[i].Items) > 0 {
+			resolveMentionsIn(nodes[i].Items, resolve)
+		}
+
+		var content []ADFText
+		for _, node := range nodes[i].Content {
+			content = append(content, splitMentions(node, resolve)...)
+		}
+		nodes[i].Content = content
+	}
+}
+
+// splitMentions parte un nodo de texto en texto y menciones; conserva los marks del texto original
+func splitMentions(node ADFText, resolve MentionResolver) []ADFText {
+	if node.Type != "text" {
+		return []ADFText{node}
+	}
+
+	var parts []ADFText
+	last := 0
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(node.Text, -1) {
+		// match[4:6] es el token; el @ esta justo antes
+		atIndex, tokenEnd := match[4]-1, match[5]
+		token := node.Text[match[4]:tokenEnd]
+
+		accountID, ok := resolve(token)
+		if !ok {
+			continue
+		}
+
+		if atIndex > last {
+			parts = append(parts, ADFText{Type: "text", Text: node.Text[last:atIndex], Marks: node.Marks})
+		}
+		parts = append(parts, ADFText{Type: "mention", Attrs: &ADFMentionAttrs{ID: accountID, Text: "@" + token}})
+		last = tokenEnd
+	}
+
+	if last == 0 {
+		return []ADFText{node}
+	}
+	if last < len(node.Text) {
+		parts = append(parts, ADFText{Type: "text", Text: node.Text[last:], Marks: node.Marks})
+	}
+
+	return parts
+}
+
+// resolveMentions convierte las menciones de los campos ADF del payload cuando RESOLVE_MENTIONS esta activo.
+// Un @accountId se usa tal cual; un @email se busca en Jira y, si no hay una unica cuenta, queda como texto
+func (jc *JiraClient) resolveMentions(ctx context.Context, payload map[string]interface{}) {
+	if !jc.config.ResolveMentions {
+		return
+	}
+
+	fields, ok := payload["fields"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	resolve := func(token string) (string, bool) {
+		if !strings.Contains(token, "@") {
+			return token, true
+		}
+
+		accountID, err := jc.ResolveAccountID(ctx, token)
+		if err != nil {
+			return "", false
+		}
+		return accountID, true
+	}
+
+	for _, value := range fields {
+		if doc, ok := value.(*ADFDocument); ok {
+			doc.ResolveMentions(resolve)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestADFDocument_ResolveMentions(t *testing.T) {
+	resolve := func(token string) (string, bool) {
+		switch token {
+		case "user@example.com":
+			return "acc-1", true
+		case "5b10ac8d82e05b22cc7d4ef5":
+			return token, true
+		}
+		return "", false
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want []ADFText
+	}{
+		{
+			name: "email mention",
+			text: "Revisar con @user@example.com.",
+			want: []ADFText{
+				{Type: "text", Text: "Revisar con "},
+				{Type: "mention", Attrs: &ADFMentionAttrs{ID: "acc-1", Text: "@user@example.com"}},
+				{Type: "text", Text: "."},
+			},
+		},
+		{
+			name: "account id mention at start",
+			text: "@5b10ac8d82e05b22cc7d4ef5 aprueba",
+			want: []ADFText{
+				{Type: "mention", Attrs: &ADFMentionAttrs{ID: "5b10ac8d82e05b22cc7d4ef5", Text: "@5b10ac8d82e05b22cc7d4ef5"}},
+				{Type: "text", Text: " aprueba"},
+			},
+		},
+		{
+			name: "unresolvable token stays literal",
+			text: "Avisar a @nadie@example.com",
+			want: []ADFText{{Type: "text", Text: "Avisar a @nadie@example.com"}},
+		},
+		{
+			name: "plain email is not a mention",
+			text: "Escribir a user@example.com",
+			want: []ADFText{{Type: "text", Text: "Escribir a user@example.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := CreateDescriptionADF(tt.text)
+			doc.ResolveMentions(resolve)
+
+			got := doc.Content[0].Content
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d nodes, got %+v", len(tt.want), got)
+			}
+			for i := range tt.want {
+				if got[i].Type != tt.want[i].Type || got[i].Text != tt.want[i].Text {
+					t.Errorf("Node %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+				if tt.want[i].Attrs != nil && (got[i].Attrs == nil || *got[i].Attrs != *tt.want[i].Attrs) {
+					t.Errorf("Node %d attrs = %+v, want %+v", i, got[i].Attrs, tt.want[i].Attrs)
+				}
+			}
+		})
+	}
+}
+
+func TestADFDocument_ResolveMentions_NativeList(t *testing.T) {
+	doc := CreateAcceptanceCriteriaListADF("Valida @user@example.com; Otro criterio", true)
+	doc.ResolveMentions(func(token string) (string, bool) { return "acc-1", true })
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `{"type":"mention","attrs":{"id":"acc-1","text":"@user@example.com"}}`) {
+		t.Errorf("Expected a mention node inside the list, got %s", data)
+	}
+}
+
+func TestJiraClient_resolveMentions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "user@example.com" {
+			w.Write([]byte(`[{"accountId": "acc-1", "displayName": "User", "emailAddress": "user@example.com", "active": true}]`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.ResolveMentions = true
+	client := NewJiraClient(cfg)
+
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"summary":     "Historia",
+			"description": CreateDescriptionADF("Hablar con @user@example.com y @nadie@example.com"),
+		},
+	}
+	client.resolveMentions(context.Background(), payload)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"type":"mention","attrs":{"id":"acc-1"`) {
+		t.Errorf("Expected resolved mention, got %s", data)
+	}
+	if !strings.Contains(string(data), `"text":" y @nadie@example.com"`) {
+		t.Errorf("Expected unresolvable token to stay literal, got %s", data)
+	}
+
+	cfg.ResolveMentions = false
+	doc := CreateDescriptionADF("Hablar con @user@example.com")
+	NewJiraClient(cfg).resolveMentions(context.Background(), map[string]interface{}{"fields": map[string]interface{}{"description": doc}})
+	if len(doc.Content[0].Content) != 1 {
+		t.Errorf("Expected mentions untouched when RESOLVE_MENTIONS is off, got %+v", doc.Content[0].Content)
+	}
+}
@@ -0,0 +1,40 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetPriorities devuelve los nombres de las prioridades configuradas en Jira, en el orden de
+// /rest/api/{version}/priority; validate los usa para avisar de prioridades desconocidas
+func (jc *JiraClient) GetPriorities(ctx context.Context) ([]string, error) {
+	req, err := jc.newRequest(ctx, "GET", jc.apiPath("priority"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting priorities: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting priorities: status %d", resp.StatusCode)
+	}
+
+	var priorities []struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&priorities); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	names := make([]string, 0, len(priorities))
+	for _, priority := range priorities {
+		names = append(names, priority.Name)
+	}
+	return names, nil
+}
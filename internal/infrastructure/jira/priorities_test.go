@@ -0,0 +1,94 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+func TestJiraClient_GetPriorities(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiVersion string
+		status     int
+		body       string
+		wantPath   string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:     "names_in_jira_order",
+			status:   http.StatusOK,
+			body:     `[{"id": "1", "name": "Highest"}, {"id": "3", "name": "Medium"}, {"id": "5", "name": "Lowest"}]`,
+			wantPath: "/rest/api/3/priority",
+			want:     []string{"Highest", "Medium", "Lowest"},
+		},
+		{
+			name:       "api_v2_path",
+			apiVersion: "2",
+			status:     http.StatusOK,
+			body:       `[{"id": "1", "name": "Blocker"}]`,
+			wantPath:   "/rest/api/2/priority",
+			want:       []string{"Blocker"},
+		},
+		{
+			name:     "error_status",
+			status:   http.StatusForbidden,
+			wantPath: "/rest/api/3/priority",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid_json",
+			status:   http.StatusOK,
+			body:     `{`,
+			wantPath: "/rest/api/3/priority",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("Path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.JiraAPIVersion = tt.apiVersion
+
+			got, err := NewJiraClient(cfg).GetPriorities(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPriorities() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("GetPriorities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJiraClient_buildIssuePayload_Priority(t *testing.T) {
+	client := NewJiraClient(createTestConfig())
+	story := entities.NewUserStory("Test Story", "Test Description", "Criteria", "", "")
+
+	fields := client.buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+	if _, ok := fields["priority"]; ok {
+		t.Error("Expected no priority field when the story has no priority")
+	}
+
+	story.Prioridad = " High "
+	fields = client.buildIssuePayload(story, "PROJ")["fields"].(map[string]interface{})
+	priority, ok := fields["priority"].(map[string]interface{})
+	if !ok || priority["name"] != "High" {
+		t.Errorf("priority = %v, want {name: High}", fields["priority"])
+	}
+}
@@ -0,0 +1,88 @@
+package jira
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"historiadorgo/internal/infrastructure/config"
+)
+
+// requestLogBodyLimit es el maximo de bytes del body que se incluyen en el comando curl
+const requestLogBodyLimit = 1000
+
+// RequestLogger registra cada request saliente a Jira como comando curl (LOG_REQUESTS)
+type RequestLogger interface {
+	LogRequest(curl string)
+}
+
+// SetRequestLogger registra cada request a Jira como un comando curl con el token enmascarado,
+// para poder reproducir a mano una llamada que falla
+func (jc *JiraClient) SetRequestLogger(logger RequestLogger) {
+	jc.requestLogger = logger
+}
+
+// curlCommand arma un comando curl equivalente a req. La cabecera Authorization se enmascara y
+// el body se recorta a requestLogBodyLimit bytes; el body original no se consume
+func curlCommand(req *http.Request) string {
+	parts := []string{"curl", "-X", req.Method, shellQuote(req.URL.String())}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if strings.EqualFold(name, "Authorization") {
+				value = maskAuthorization(value)
+			}
+			parts = append(parts, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	if body := requestBody(req); body != "" {
+		parts = append(parts, "--data", shellQuote(body))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// maskAuthorization conserva el esquema (Basic, Bearer) y oculta la credencial
+func maskAuthorization(value string) string {
+	scheme, _, found := strings.Cut(value, " ")
+	if !found {
+		return config.MaskedSecret
+	}
+	return scheme + " " + config.MaskedSecret
+}
+
+// requestBody lee una copia del body via GetBody; sin GetBody (body no reproducible) no se incluye
+func requestBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, requestLogBodyLimit+1))
+	if err != nil {
+		return ""
+	}
+
+	if len(data) > requestLogBodyLimit {
+		return fmt.Sprintf("%s...(truncated)", data[:requestLogBodyLimit])
+	}
+	return string(data)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,83 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordingRequestLogger struct {
+	lines []string
+}
+
+func (l *recordingRequestLogger) LogRequest(curl string) {
+	l.lines = append(l.lines, curl)
+}
+
+func TestJiraClient_RequestLogger(t *testing.T) {
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+		receivedBody = buf.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	client := NewJiraClient(cfg)
+	recorder := &recordingRequestLogger{}
+	client.SetRequestLogger(recorder)
+
+	req, err := client.newRequest(context.Background(), "POST", "/rest/api/3/issue", bytes.NewBufferString(`{"fields":{"summary":"It's done"}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(recorder.lines) != 1 {
+		t.Fatalf("Expected one logged request, got %d", len(recorder.lines))
+	}
+	line := recorder.lines[0]
+
+	for _, expected := range []string{
+		"curl -X POST '" + server.URL + "/rest/api/3/issue'",
+		"-H 'Authorization: Basic ********'",
+		"-H 'Content-Type: application/json'",
+		`--data '{"fields":{"summary":"It'\''s done"}}'`,
+	} {
+		if !strings.Contains(line, expected) {
+			t.Errorf("Expected log line to contain %q, got: %s", expected, line)
+		}
+	}
+	if strings.Contains(line, cfg.JiraAPIToken) {
+		t.Errorf("Log line leaks the API token: %s", line)
+	}
+	if receivedBody != `{"fields":{"summary":"It's done"}}` {
+		t.Errorf("Logging should not consume the request body, server got %q", receivedBody)
+	}
+}
+
+func TestCurlCommand_TruncatesBody(t *testing.T) {
+	body := strings.Repeat("a", requestLogBodyLimit+50)
+	req, err := http.NewRequest("POST", "https://example.atlassian.net/rest/api/3/issue", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	line := curlCommand(req)
+	if !strings.Contains(line, strings.Repeat("a", requestLogBodyLimit)+"...(truncated)'") {
+		t.Errorf("Expected body truncated to %d bytes, got: %s", requestLogBodyLimit, line)
+	}
+	if strings.Contains(line, strings.Repeat("a", requestLogBodyLimit+1)) {
+		t.Errorf("Body was not truncated: %s", line)
+	}
+}
@@ -0,0 +1,220 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+// Jira Cloud reemplazo /rest/api/{2,3}/search por /search/jql, que pagina con nextPageToken
+// en lugar de startAt/total. Server y Data Center siguen usando el endpoint clasico.
+const (
+	deploymentCloud = "Cloud"
+
+	searchPageSize = 50
+	searchMaxPages = 20
+)
+
+type JiraSearchResponse struct {
+	Issues        []JiraIssue `json:"issues"`
+	Total         int         `json:"total"`
+	StartAt       int         `json:"startAt"`
+	NextPageToken string      `json:"nextPageToken"`
+	IsLast        bool        `json:"isLast"`
+}
+
+type serverInfoResponse struct {
+	DeploymentType string `json:"deploymentType"`
+}
+
+// isCloud detecta el tipo de despliegue via /serverInfo; el resultado se cachea por cliente solo
+// si la deteccion funciona. Si falla (timeout, 5xx) se asume Server para esta busqueda y se
+// vuelve a intentar en la siguiente, para no tratar una instancia Cloud como Server toda la ejecucion
+func (jc *JiraClient) isCloud(ctx context.Context) bool {
+	jc.deploymentMu.Lock()
+	defer jc.deploymentMu.Unlock()
+
+	if !jc.deploymentKnown {
+		deployment, err := jc.detectDeploymentType(ctx)
+		if err != nil {
+			return false
+		}
+		jc.deployment = deployment
+		jc.deploymentKnown = true
+	}
+
+	return strings.EqualFold(jc.deployment, deploymentCloud)
+}
+
+func (jc *JiraClient) detectDeploymentType(ctx context.Context) (string, error) {
+	req, err := jc.newRequest(ctx, "GET", jc.apiPath("serverInfo"), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// Un 4xx es definitivo (Cloud siempre expone serverInfo); un 5xx puede ser pasajero
+	if resp.StatusCode >= 500 {
+		return "", fmt.Errorf("error getting server info: status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != 200 {
+		return "", nil
+	}
+
+	var info serverInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("error decoding server info: %w", err)
+	}
+
+	return info.DeploymentType, nil
+}
+
+// searchIssues ejecuta una busqueda JQL con el endpoint y la paginacion que corresponden al despliegue
+func (jc *JiraClient) searchIssues(ctx context.Context, jql, fields string) ([]JiraIssue, error) {
+	cloud := jc.isCloud(ctx)
+
+	var issues []JiraIssue
+	nextPageToken := ""
+	for page := 0; page < searchMaxPages; page++ {
+		params := url.Values{}
+		params.Set("jql", jql)
+		params.Set("fields", fields)
+		params.Set("maxResults", fmt.Sprintf("%d", searchPageSize))
+
+		endpoint := jc.apiPath("search")
+		if cloud {
+			endpoint = jc.apiPath("search/jql")
+			if nextPageToken != "" {
+				params.Set("nextPageToken", nextPageToken)
+			}
+		} else {
+			params.Set("startAt", fmt.Sprintf("%d", len(issues)))
+		}
+
+		searchResp, err := jc.searchPage(ctx, endpoint+"?"+params.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, searchResp.Issues...)
+
+		if cloud {
+			if searchResp.IsLast || searchResp.NextPageToken == "" {
+				break
+			}
+			nextPageToken = searchResp.NextPageToken
+		} else if len(searchResp.Issues) == 0 || len(issues) >= searchResp.Total {
+			break
+		}
+	}
+
+	return issues, nil
+}
+
+func (jc *JiraClient) searchPage(ctx context.Context, searchURL string) (*JiraSearchResponse, error) {
+	req, err := jc.newRequest(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating search request: %w", err)
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing search: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("search failed with status: %d", resp.StatusCode)
+	}
+
+	var searchResp JiraSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+		return nil, fmt.Errorf("error decoding search response: %w", err)
+	}
+
+	return &searchResp, nil
+}
+
+// findExistingStory busca en el proyecto una historia con el mismo summary y el mismo parent
+// (SKIP_EXISTING), para que re-ejecutar un archivo no duplique las filas ya creadas. Devuelve
+// "" si no hay ninguna; el parent (o el Epic de EPIC_LINK_FIELD) distingue historias homonimas de
+// distintas Features
+func (jc *JiraClient) findExistingStory(ctx context.Context, story *entities.UserStory, projectKey string) (string, error) {
+	summary := entities.NormalizeFeatureDescription(story.Titulo)
+	if summary == "" {
+		return "", nil
+	}
+
+	jql := fmt.Sprintf(
+		`project = "%s" AND issuetype = "%s" AND summary ~ "%s"`,
+		projectKey,
+		jc.storyIssueType(projectKey),
+		escapeJQLString(summary),
+	)
+
+	// Con EPIC_LINK_FIELD una historia de un Epic lo tiene en ese campo y no en parent
+	fields := "key,summary,parent"
+	if jc.config.EpicLinkField != "" {
+		fields += "," + jc.config.EpicLinkField
+	}
+
+	issues, err := jc.searchIssues(ctx, jql, fields)
+	if err != nil {
+		return "", err
+	}
+
+	parentKey := ""
+	if story.HasParent() && jc.isJiraKey(story.Parent) {
+		parentKey = story.Parent
+	}
+
+	// summary ~ es busqueda de texto: se filtran las coincidencias exactas
+	for _, issue := range issues {
+		existing, ok := issue.Fields["summary"].(string)
+		if !ok || entities.NormalizeFeatureDescription(existing) != summary {
+			continue
+		}
+		if strings.EqualFold(jc.existingParentKey(issue), parentKey) {
+			return issue.Key, nil
+		}
+	}
+
+	return "", nil
+}
+
+// existingParentKey devuelve el parent de una historia de la busqueda: fields.parent o, si no tiene
+// y EPIC_LINK_FIELD esta configurado, el Epic de ese campo
+func (jc *JiraClient) existingParentKey(issue JiraIssue) string {
+	if key := issueParentKey(issue); key != "" || jc.config.EpicLinkField == "" {
+		return key
+	}
+	epicKey, _ := issue.Fields[jc.config.EpicLinkField].(string)
+	return epicKey
+}
+
+// issueParentKey devuelve la key de fields.parent de un issue de la busqueda, o "" si no tiene
+func issueParentKey(issue JiraIssue) string {
+	parent, ok := issue.Fields["parent"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	key, _ := parent["key"].(string)
+	return key
+}
+
+// escapeJQLString escapa las comillas y barras de un valor que va entre comillas en JQL. Las barras
+// se escapan primero para no duplicar las que agrega el escape de las comillas
+func escapeJQLString(str string) string {
+	str = strings.ReplaceAll(str, `\`, `\\`)
+	str = strings.ReplaceAll(str, `"`, `\"`)
+	return str
+}
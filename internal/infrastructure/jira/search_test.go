@@ -0,0 +1,180 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJiraClient_searchIssues_Cloud(t *testing.T) {
+	serverInfoCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/serverInfo":
+			serverInfoCalls++
+			w.Write([]byte(`{"deploymentType": "Cloud"}`))
+		case "/rest/api/3/search/jql":
+			if r.URL.Query().Get("startAt") != "" {
+				t.Error("Cloud search should not paginate with startAt")
+			}
+			if r.URL.Query().Get("nextPageToken") == "" {
+				w.Write([]byte(`{"issues": [{"key": "PROJ-1"}, {"key": "PROJ-2"}], "nextPageToken": "page2", "isLast": false}`))
+				return
+			}
+			if r.URL.Query().Get("nextPageToken") != "page2" {
+				t.Errorf("Expected nextPageToken page2, got %s", r.URL.Query().Get("nextPageToken"))
+			}
+			w.Write([]byte(`{"issues": [{"key": "PROJ-3"}], "isLast": true}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	client := NewJiraClient(cfg)
+
+	for i := 0; i < 2; i++ {
+		issues, err := client.searchIssues(context.Background(), `project = "PROJ"`, "key,summary")
+		if err != nil {
+			t.Fatalf("searchIssues() error = %v", err)
+		}
+		if len(issues) != 3 || issues[2].Key != "PROJ-3" {
+			t.Errorf("Expected 3 issues across pages, got %+v", issues)
+		}
+	}
+
+	if serverInfoCalls != 1 {
+		t.Errorf("Expected deployment detection to be cached, got %d serverInfo calls", serverInfoCalls)
+	}
+}
+
+func TestJiraClient_searchIssues_DetectionFailureNotCached(t *testing.T) {
+	serverInfoCalls := 0
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/serverInfo":
+			serverInfoCalls++
+			if serverInfoCalls == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"deploymentType": "Cloud"}`))
+		case "/rest/api/3/search", "/rest/api/3/search/jql":
+			searches = append(searches, r.URL.Path)
+			w.Write([]byte(`{"issues": [], "total": 0, "isLast": true}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	cfg.MaxRetries = 0
+	client := NewJiraClient(cfg)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.searchIssues(context.Background(), `project = "PROJ"`, "key"); err != nil {
+			t.Fatalf("searchIssues() error = %v", err)
+		}
+	}
+
+	// La primera busqueda cae al endpoint clasico; la deteccion se reintenta y luego se cachea
+	want := "[/rest/api/3/search /rest/api/3/search/jql /rest/api/3/search/jql]"
+	if got := fmt.Sprint(searches); got != want {
+		t.Errorf("Searches = %s, want %s", got, want)
+	}
+	if serverInfoCalls != 2 {
+		t.Errorf("Expected a failed detection to be retried once and then cached, got %d serverInfo calls", serverInfoCalls)
+	}
+}
+
+func TestJiraClient_searchIssues_Server(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverInfo func(w http.ResponseWriter)
+	}{
+		{
+			name: "server_deployment",
+			serverInfo: func(w http.ResponseWriter) {
+				w.Write([]byte(`{"deploymentType": "Server"}`))
+			},
+		},
+		{
+			name: "detection_fails_falls_back_to_classic_search",
+			serverInfo: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/rest/api/3/serverInfo":
+					tt.serverInfo(w)
+				case "/rest/api/3/search":
+					switch r.URL.Query().Get("startAt") {
+					case "0":
+						w.Write([]byte(`{"issues": [{"key": "PROJ-1"}, {"key": "PROJ-2"}], "startAt": 0, "total": 3}`))
+					case "2":
+						w.Write([]byte(`{"issues": [{"key": "PROJ-3"}], "startAt": 2, "total": 3}`))
+					default:
+						t.Errorf("Unexpected startAt %q", r.URL.Query().Get("startAt"))
+					}
+				default:
+					t.Errorf("Unexpected request to %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			client := NewJiraClient(cfg)
+
+			issues, err := client.searchIssues(context.Background(), `project = "PROJ"`, "key,summary")
+			if err != nil {
+				t.Fatalf("searchIssues() error = %v", err)
+			}
+			if len(issues) != 3 {
+				t.Errorf("Expected 3 issues across pages, got %d", len(issues))
+			}
+		})
+	}
+}
+
+func TestFeatureManager_SearchExistingFeature_CloudEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/serverInfo":
+			w.Write([]byte(`{"deploymentType": "Cloud"}`))
+		case "/rest/api/3/search/jql":
+			w.Write([]byte(`{"issues": [{"key": "PROJ-42", "fields": {"summary": "Gestion completa de usuarios del sistema"}}], "isLast": true}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	fm := NewFeatureManager(NewJiraClient(cfg), cfg)
+
+	key, err := fm.SearchExistingFeature(context.Background(), "Gestion completa de usuarios del sistema", "PROJ")
+	if err != nil {
+		t.Fatalf("SearchExistingFeature() error = %v", err)
+	}
+	if key != "PROJ-42" {
+		t.Errorf("Expected PROJ-42, got %q", key)
+	}
+}
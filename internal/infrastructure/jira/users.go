@@ -0,0 +1,158 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/infrastructure/config"
+)
+
+// accountIDPattern reconoce un accountId de Jira Cloud (24 hex o "prefijo:uuid"), que no hace falta resolver
+var accountIDPattern = regexp.MustCompile(`^([0-9a-f]{24}|\d+:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// JiraUser es un usuario devuelto por /rest/api/{version}/user/search
+type JiraUser struct {
+	AccountID    string `json:"accountId"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+	Active       bool   `json:"active"`
+}
+
+// userCache guarda los accountId ya resueltos durante la ejecucion
+type userCache struct {
+	mu       sync.Mutex
+	accounts map[string]string
+}
+
+func (c *userCache) get(query string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	accountID, ok := c.accounts[query]
+	return accountID, ok
+}
+
+func (c *userCache) set(query, accountID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.accounts == nil {
+		c.accounts = make(map[string]string)
+	}
+	c.accounts[query] = accountID
+}
+
+// ResolveAccountID busca el accountId de un usuario por email o nombre visible (assignee/reporter).
+// Se prefieren las coincidencias exactas; si varias cuentas coinciden falla con "ambiguous user"
+// para no asignar a la persona equivocada. Los resultados se cachean por ejecucion.
+func (jc *JiraClient) ResolveAccountID(ctx context.Context, user string) (string, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return "", fmt.Errorf("empty user")
+	}
+
+	cacheKey := strings.ToLower(user)
+	if accountID, ok := jc.users.get(cacheKey); ok {
+		return accountID, nil
+	}
+
+	candidates, err := jc.searchUsers(ctx, user)
+	if err != nil {
+		return "", err
+	}
+
+	matches := matchUsers(candidates, user)
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no Jira user matches '%s'", user)
+	case 1:
+		jc.users.set(cacheKey, matches[0].AccountID)
+		return matches[0].AccountID, nil
+	default:
+		var names []string
+		for _, match := range matches {
+			names = append(names, fmt.Sprintf("%s (%s)", match.DisplayName, match.AccountID))
+		}
+		return "", fmt.Errorf("ambiguous user '%s': matches %s", user, strings.Join(names, ", "))
+	}
+}
+
+// setAssignee completa fields.assignee con la columna asignado. En la API v3 se envia el accountId,
+// resuelto por email o nombre si hace falta; la API v2 de Jira Server recibe el username tal cual.
+// Si el usuario no se puede resolver la historia se crea sin asignar y con un aviso
+func (jc *JiraClient) setAssignee(ctx context.Context, story *entities.UserStory, payload map[string]interface{}, result *entities.ProcessResult) {
+	assignee := strings.TrimSpace(story.Assignee)
+	if assignee == "" {
+		return
+	}
+
+	fields, ok := payload["fields"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if jc.config.JiraAPIVersion == config.JiraAPIVersion2 {
+		fields["assignee"] = map[string]interface{}{"name": assignee}
+		return
+	}
+
+	accountID := assignee
+	if !accountIDPattern.MatchString(assignee) {
+		resolved, err := jc.ResolveAccountID(ctx, assignee)
+		if err != nil {
+			result.AddWarning(fmt.Sprintf("created unassigned: could not resolve assignee '%s': %v", assignee, err))
+			return
+		}
+		accountID = resolved
+	}
+
+	fields["assignee"] = map[string]interface{}{"accountId": accountID}
+}
+
+// matchUsers devuelve las cuentas activas cuyo email o nombre visible coincide exactamente;
+// si ninguna coincide exacto (ej: Jira oculta el email) se usan todas las activas encontradas
+func matchUsers(users []JiraUser, query string) []JiraUser {
+	var active, exact []JiraUser
+	for _, user := range users {
+		if !user.Active {
+			continue
+		}
+		active = append(active, user)
+		if strings.EqualFold(user.EmailAddress, query) || strings.EqualFold(strings.TrimSpace(user.DisplayName), query) {
+			exact = append(exact, user)
+		}
+	}
+
+	if len(exact) > 0 {
+		return exact
+	}
+	return active
+}
+
+func (jc *JiraClient) searchUsers(ctx context.Context, query string) ([]JiraUser, error) {
+	req, err := jc.newRequest(ctx, "GET", jc.apiPath("user/search?query=%s", url.QueryEscape(query)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating user search request: %w", err)
+	}
+
+	resp, err := jc.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error searching users: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("user search failed with status: %d", resp.StatusCode)
+	}
+
+	var users []JiraUser
+	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+		return nil, fmt.Errorf("error decoding user search response: %w", err)
+	}
+
+	return users, nil
+}
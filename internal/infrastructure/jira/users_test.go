@@ -0,0 +1,159 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"historiadorgo/internal/domain/entities"
+)
+
+func TestJiraClient_ResolveAccountID(t *testing.T) {
+	searches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/user/search" {
+			http.NotFound(w, r)
+			return
+		}
+		searches++
+		switch r.URL.Query().Get("query") {
+		case "Ana Pérez":
+			w.Write([]byte(`[
+				{"accountId": "acc-1", "displayName": "Ana Pérez", "active": true},
+				{"accountId": "acc-2", "displayName": "Ana Pérez Gómez", "active": true}
+			]`))
+		case "ana@example.com":
+			w.Write([]byte(`[{"accountId": "acc-3", "displayName": "Ana", "active": true}]`))
+		case "Juan García":
+			w.Write([]byte(`[
+				{"accountId": "acc-4", "displayName": "Juan García", "active": true},
+				{"accountId": "acc-5", "displayName": "Juan García", "active": true},
+				{"accountId": "acc-6", "displayName": "Juan García", "active": false}
+			]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.JiraURL = server.URL
+	client := NewJiraClient(cfg)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		user    string
+		want    string
+		wantErr string
+	}{
+		{"unique_display_name", "Ana Pérez", "acc-1", ""},
+		{"email_with_hidden_address", "ana@example.com", "acc-3", ""},
+		{"no_match", "Nadie", "", "no Jira user matches 'Nadie'"},
+		{"ambiguous", "Juan García", "", "ambiguous user 'Juan García': matches Juan García (acc-4), Juan García (acc-5)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ResolveAccountID(ctx, tt.user)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveAccountID() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveAccountID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveAccountID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	before := searches
+	if got, err := client.ResolveAccountID(ctx, "ana pérez"); err != nil || got != "acc-1" {
+		t.Fatalf("cached ResolveAccountID() = %q, %v", got, err)
+	}
+	if searches != before {
+		t.Errorf("Expected a resolved name to be cached within the run, got %d extra searches", searches-before)
+	}
+}
+
+func TestJiraClient_CreateUserStory_Assignee(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiVersion   string
+		assignee     string
+		wantAssignee map[string]interface{}
+		wantSearches int
+		wantWarning  bool
+	}{
+		{name: "no_assignee", assignee: "  "},
+		{name: "email_resolved_to_account_id", assignee: "ana@empresa.com", wantAssignee: map[string]interface{}{"accountId": "acc-ana"}, wantSearches: 1},
+		{name: "account_id_used_as_is", assignee: "5b10a2844c20165700ede21f", wantAssignee: map[string]interface{}{"accountId": "5b10a2844c20165700ede21f"}},
+		{name: "unknown_user_created_unassigned", assignee: "nadie@empresa.com", wantSearches: 2, wantWarning: true},
+		{name: "api_v2_uses_username", apiVersion: "2", assignee: "aperez", wantAssignee: map[string]interface{}{"name": "aperez"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searches := 0
+			var fields map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/user/search"):
+					searches++
+					if r.URL.Query().Get("query") == "ana@empresa.com" {
+						w.Write([]byte(`[{"accountId": "acc-ana", "emailAddress": "ana@empresa.com", "active": true}]`))
+						return
+					}
+					w.Write([]byte(`[]`))
+				case strings.HasSuffix(r.URL.Path, "/issue"):
+					var payload map[string]map[string]interface{}
+					json.NewDecoder(r.Body).Decode(&payload)
+					fields = payload["fields"]
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(`{"id": "10001", "key": "PROJ-1"}`))
+				default:
+					t.Errorf("Unexpected request to %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			cfg := createTestConfig()
+			cfg.JiraURL = server.URL
+			cfg.JiraAPIVersion = tt.apiVersion
+			client := NewJiraClient(cfg)
+
+			// Dos historias con el mismo asignado: la resolucion se cachea
+			for i := 0; i < 2; i++ {
+				story := entities.NewUserStory("Story", "Desc", "Criterio", "", "")
+				story.Assignee = tt.assignee
+				result, err := client.CreateUserStory(context.Background(), story, "PROJ", 2)
+				if err != nil || !result.Success {
+					t.Fatalf("CreateUserStory() = %+v, %v", result, err)
+				}
+				if tt.wantWarning != (len(result.Warnings) == 1) {
+					t.Errorf("Warnings = %v, want warning: %v", result.Warnings, tt.wantWarning)
+				}
+			}
+
+			assignee, ok := fields["assignee"]
+			if tt.wantAssignee == nil {
+				if ok {
+					t.Errorf("Expected no assignee field, got %v", assignee)
+				}
+			} else if !reflect.DeepEqual(assignee, tt.wantAssignee) {
+				t.Errorf("assignee = %v, want %v", assignee, tt.wantAssignee)
+			}
+			if searches != tt.wantSearches {
+				t.Errorf("Expected %d user searches, got %d", tt.wantSearches, searches)
+			}
+		})
+	}
+}
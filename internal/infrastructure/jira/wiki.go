@@ -0,0 +1,62 @@
+package jira
+
+import (
+	"strings"
+)
+
+// Los builders de este archivo arman los mismos textos que los de adf.go pero como wiki markup,
+// el formato que acepta la API v2 de Jira (JIRA_API_VERSION=2) en description y campos de texto
+
+// CreateDescriptionWiki devuelve la descripción tal cual; la API v2 la recibe como texto
+func CreateDescriptionWiki(description string) string {
+	return description
+}
+
+// CreateAcceptanceCriteriaWiki arma los criterios como párrafo, viñetas de texto o lista wiki ("* ") según asList
+func CreateAcceptanceCriteriaWiki(criteriaText string, asList bool) string {
+	if criteriaText == "" {
+		return ""
+	}
+
+	return strings.Join(wikiCriteria(criteriaText, asList), "\n")
+}
+
+// CreateDescriptionWithCriteriaWiki igual que CreateDescriptionWithCriteriaListADF pero en wiki markup
+func CreateDescriptionWithCriteriaWiki(description, criteriaText, heading string, asList bool) string {
+	if strings.TrimSpace(heading) == "" {
+		heading = DefaultCriteriaHeading
+	}
+
+	var paragraphs []string
+	if description != "" {
+		paragraphs = append(paragraphs, description)
+	}
+
+	if criteriaText != "" {
+		paragraphs = append(paragraphs, "--- "+strings.TrimSpace(heading)+" ---")
+		paragraphs = append(paragraphs, CreateAcceptanceCriteriaWiki(criteriaText, asList))
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// wikiCriteria devuelve una línea por criterio con el mismo formato que addCriteria en ADF
+func wikiCriteria(criteriaText string, asList bool) []string {
+	criteria := splitCriteria(criteriaText)
+	if len(criteria) == 1 && !asList {
+		return criteria
+	}
+
+	prefix := "• "
+	if asList {
+		prefix = "* "
+	}
+
+	var lines []string
+	for _, item := range criteria {
+		if text := strings.TrimSpace(item); text != "" {
+			lines = append(lines, prefix+text)
+		}
+	}
+	return lines
+}
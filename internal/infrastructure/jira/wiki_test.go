@@ -0,0 +1,50 @@
+package jira
+
+import (
+	"testing"
+)
+
+func TestCreateAcceptanceCriteriaWiki(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria string
+		asList   bool
+		want     string
+	}{
+		{"empty", "", false, ""},
+		{"single_criterion", "Debe validar email", false, "Debe validar email"},
+		{"multiple_criteria_as_bullets", "Valida email; Valida clave", false, "• Valida email\n• Valida clave"},
+		{"lines_as_wiki_list", "Valida email\nValida clave", true, "* Valida email\n* Valida clave"},
+		{"single_criterion_as_wiki_list", "Debe validar email", true, "* Debe validar email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CreateAcceptanceCriteriaWiki(tt.criteria, tt.asList); got != tt.want {
+				t.Errorf("CreateAcceptanceCriteriaWiki() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateDescriptionWithCriteriaWiki(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		criteria    string
+		heading     string
+		want        string
+	}{
+		{"description_only", "Descripcion", "", "", "Descripcion"},
+		{"criteria_only", "", "Criterio", "", "--- Criterios de Aceptación ---\n\nCriterio"},
+		{"both_with_heading", "Descripcion", "A;B", "Definition of Done", "Descripcion\n\n--- Definition of Done ---\n\n• A\n• B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CreateDescriptionWithCriteriaWiki(tt.description, tt.criteria, tt.heading, false); got != tt.want {
+				t.Errorf("CreateDescriptionWithCriteriaWiki() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
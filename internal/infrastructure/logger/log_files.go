@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogFile describe un archivo de log generado por una ejecucion
+type LogFile struct {
+	Path    string
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// ListLogFiles devuelve los historiador_*.log del directorio ordenados del mas reciente al mas antiguo
+func ListLogFiles(logsDir string) ([]LogFile, error) {
+	entries, err := os.ReadDir(logsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading logs directory: %w", err)
+	}
+
+	var files []LogFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "historiador_") || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		files = append(files, LogFile{
+			Path:    filepath.Join(logsDir, name),
+			Name:    name,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+	}
+
+	// El nombre lleva el timestamp de inicio, por lo que desempata ejecuciones del mismo segundo
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].ModTime.Equal(files[j].ModTime) {
+			return files[i].ModTime.After(files[j].ModTime)
+		}
+		return files[i].Name > files[j].Name
+	})
+
+	return files, nil
+}
+
+// TailLines devuelve las ultimas n lineas del archivo
+func TailLines(path string, n int) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening log file: %w", err)
+	}
+	defer file.Close()
+
+	if n <= 0 {
+		return []string{}, nil
+	}
+
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading log file: %w", err)
+	}
+
+	return lines, nil
+}
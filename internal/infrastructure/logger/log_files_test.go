@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createLogFiles(t *testing.T, dir string) {
+	t.Helper()
+
+	base := time.Now().Add(-time.Hour)
+	files := []struct {
+		name    string
+		content string
+		age     time.Duration
+	}{
+		{"historiador_20240101_100000.log", "old\n", 30 * time.Minute},
+		{"historiador_20240102_100000.log", "line 1\nline 2\nline 3\nline 4\n", 0},
+		{"historiador_20240101_120000.log", "middle\n", 10 * time.Minute},
+		{"otro.log", "ignored\n", 0},
+		{"historiador_notes.txt", "ignored\n", 0},
+	}
+
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, []byte(f.content), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", f.name, err)
+		}
+		modTime := base.Add(time.Hour - f.age)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set time on %s: %v", f.name, err)
+		}
+	}
+}
+
+func TestListLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	createLogFiles(t, dir)
+
+	files, err := ListLogFiles(dir)
+	if err != nil {
+		t.Fatalf("ListLogFiles() error = %v", err)
+	}
+
+	expected := []string{
+		"historiador_20240102_100000.log",
+		"historiador_20240101_120000.log",
+		"historiador_20240101_100000.log",
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("Expected %d log files, got %d: %+v", len(expected), len(files), files)
+	}
+
+	for i, name := range expected {
+		if files[i].Name != name {
+			t.Errorf("Position %d: expected %s, got %s", i, name, files[i].Name)
+		}
+	}
+
+	if files[0].Size != int64(len("line 1\nline 2\nline 3\nline 4\n")) {
+		t.Errorf("Expected size of most recent log, got %d", files[0].Size)
+	}
+	if files[0].Path != filepath.Join(dir, expected[0]) {
+		t.Errorf("Expected full path, got %s", files[0].Path)
+	}
+}
+
+func TestListLogFiles_MissingDirectory(t *testing.T) {
+	_, err := ListLogFiles(filepath.Join(t.TempDir(), "missing"))
+	if err == nil || !strings.Contains(err.Error(), "error reading logs directory") {
+		t.Errorf("Expected error reading logs directory, got: %v", err)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "historiador_test.log")
+
+	var content strings.Builder
+	for i := 1; i <= 10; i++ {
+		content.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+	if err := os.WriteFile(path, []byte(content.String()), 0644); err != nil {
+		t.Fatalf("Failed to create log file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		n         int
+		wantFirst string
+		wantLen   int
+	}{
+		{"last three", 3, "line 8", 3},
+		{"more than available", 20, "line 1", 10},
+		{"zero", 0, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := TailLines(path, tt.n)
+			if err != nil {
+				t.Fatalf("TailLines() error = %v", err)
+			}
+			if len(lines) != tt.wantLen {
+				t.Errorf("Expected %d lines, got %d", tt.wantLen, len(lines))
+			}
+			if tt.wantLen > 0 && lines[0] != tt.wantFirst {
+				t.Errorf("Expected first line %q, got %q", tt.wantFirst, lines[0])
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"crypto/rand"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +13,32 @@ import (
 type Logger struct {
 	*logrus.Logger
 	logFile *os.File
+	runID   string
+}
+
+// runIDHook agrega el identificador de ejecucion a cada entrada del log
+type runIDHook struct {
+	runID string
+}
+
+func (h *runIDHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *runIDHook) Fire(entry *logrus.Entry) error {
+	entry.Data["run_id"] = h.runID
+	return nil
+}
+
+// NewRunID genera un UUID v4 para correlacionar las lineas de una misma ejecucion
+func NewRunID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
 }
 
 func NewLogger(logsDir string) (*Logger, error) {
@@ -53,6 +80,30 @@ func (l *Logger) Close() error {
 	return nil
 }
 
+// SetRunID hace que todas las entradas posteriores lleven el campo run_id. Reemplaza solo el hook
+// del run_id anterior; los demas hooks del logger se conservan
+func (l *Logger) SetRunID(runID string) {
+	l.runID = runID
+
+	hooks := make(logrus.LevelHooks)
+	for level, levelHooks := range l.Logger.Hooks {
+		for _, hook := range levelHooks {
+			if _, ok := hook.(*runIDHook); !ok {
+				hooks[level] = append(hooks[level], hook)
+			}
+		}
+	}
+	if runID != "" {
+		hooks.Add(&runIDHook{runID: runID})
+	}
+	l.Logger.ReplaceHooks(hooks)
+}
+
+// RunID devuelve el identificador de la ejecucion actual
+func (l *Logger) RunID() string {
+	return l.runID
+}
+
 func (l *Logger) SetLevel(level string) {
 	switch level {
 	case "DEBUG":
@@ -125,6 +176,42 @@ func (l *Logger) LogProcessEnd(filePath string, successful, failed int, duration
 	}).Info("Procesamiento completado")
 }
 
+// LogWatchCycle registra cada revision del directorio de entrada en modo watch
+func (l *Logger) LogWatchCycle(inputDir string, pending, processed int) {
+	l.WithFields(logrus.Fields{
+		"action":    "watch_cycle",
+		"dir":       inputDir,
+		"pending":   pending,
+		"processed": processed,
+	}).Info("Revision del directorio de entrada completada")
+}
+
+func (l *Logger) LogRateLimitSummary(throttled, retryAfterWaits int, retryAfterTotal time.Duration) {
+	l.WithFields(logrus.Fields{
+		"action":           "rate_limit_summary",
+		"throttled":        throttled,
+		"retry_after":      retryAfterWaits,
+		"retry_after_wait": retryAfterTotal.String(),
+	}).Warn("Jira limito la tasa de requests durante la ejecucion")
+}
+
+// LogFieldMapping registra en DEBUG las decisiones de mapeo de una fila (modo --explain)
+func (l *Logger) LogFieldMapping(rowNumber int, decisions []string) {
+	for _, decision := range decisions {
+		l.WithFields(logrus.Fields{
+			"action": "explain",
+			"row":    rowNumber,
+		}).Debug(decision)
+	}
+}
+
+// LogRequest registra en DEBUG un request a Jira como comando curl (LOG_REQUESTS)
+func (l *Logger) LogRequest(curl string) {
+	l.WithFields(logrus.Fields{
+		"action": "jira_request",
+	}).Debug(curl)
+}
+
 func (l *Logger) LogIssueCreated(issueKey, issueType string, rowNumber int) {
 	l.WithFields(logrus.Fields{
 		"action":     "issue_created",
@@ -161,6 +248,23 @@ func (l *Logger) LogSubtaskError(parentKey, description string, err error) {
 	}).Warn("Error creando subtarea")
 }
 
+// LogSubtasksSummary registra una sola linea con las subtareas creadas de una historia (SUBTASK_LOG_MODE=summary)
+func (l *Logger) LogSubtasksSummary(parentKey string, created, total int) {
+	entry := l.WithFields(logrus.Fields{
+		"action":     "subtasks_summary",
+		"parent_key": parentKey,
+		"created":    created,
+		"total":      total,
+	})
+	message := fmt.Sprintf("Subtareas creadas %d/%d para %s", created, total, parentKey)
+
+	if created < total {
+		entry.Warn(message)
+		return
+	}
+	entry.Info(message)
+}
+
 func (l *Logger) LogFeatureCreated(featureKey, description string, wasCreated bool) {
 	action := "feature_found"
 	if wasCreated {
@@ -178,7 +282,11 @@ func (l *Logger) LogFeatureCreated(featureKey, description string, wasCreated bo
 func (l *Logger) WriteFormattedOutput(output string) {
 	if l.logFile != nil {
 		timestamp := time.Now().Format("2006-01-02 15:04:05")
-		formattedOutput := fmt.Sprintf("\n=== SALIDA COMANDO [%s] ===\n%s=== FIN SALIDA ===\n\n", timestamp, output)
+		header := timestamp
+		if l.runID != "" {
+			header = fmt.Sprintf("%s run_id=%s", timestamp, l.runID)
+		}
+		formattedOutput := fmt.Sprintf("\n=== SALIDA COMANDO [%s] ===\n%s=== FIN SALIDA ===\n\n", header, output)
 		if _, err := l.logFile.WriteString(formattedOutput); err != nil {
 			l.WithError(err).Error("Error writing formatted output to log file")
 		}
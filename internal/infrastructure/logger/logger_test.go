@@ -315,6 +315,103 @@ func TestLogger_LogProcessEnd(t *testing.T) {
 	}
 }
 
+func TestLogger_LogWatchCycle(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewLogger(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	logger.LogWatchCycle("entrada", 1, 2)
+
+	logContent := readLogFile(t, tempDir)
+	for _, expected := range []string{"watch_cycle", "pending=1", "processed=2"} {
+		if !strings.Contains(logContent, expected) {
+			t.Errorf("Expected log to contain %q", expected)
+		}
+	}
+}
+
+func TestLogger_LogRateLimitSummary(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewLogger(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	logger.LogRateLimitSummary(3, 2, 5*time.Second)
+
+	logContent := readLogFile(t, tempDir)
+	if !strings.Contains(logContent, "rate_limit_summary") {
+		t.Error("Expected log to contain 'rate_limit_summary'")
+	}
+	if !strings.Contains(logContent, "throttled=3") {
+		t.Error("Expected log to contain throttled count")
+	}
+	if !strings.Contains(logContent, "retry_after_wait=5s") {
+		t.Error("Expected log to contain total Retry-After wait")
+	}
+}
+
+func TestLogger_LogFieldMapping(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewLogger(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	decisions := []string{"summary <- columna titulo", "parent <- sin parent"}
+
+	// En INFO las explicaciones no se registran
+	logger.LogFieldMapping(2, decisions)
+	if strings.Contains(readLogFile(t, tempDir), "action=explain") {
+		t.Error("Expected explain entries to be omitted at INFO level")
+	}
+
+	logger.SetLevel("DEBUG")
+	logger.LogFieldMapping(2, decisions)
+
+	logContent := readLogFile(t, tempDir)
+	if strings.Count(logContent, "action=explain") != 2 {
+		t.Errorf("Expected one explain entry per decision, got: %s", logContent)
+	}
+	if !strings.Contains(logContent, "row=2") {
+		t.Error("Expected log to contain row number")
+	}
+}
+
+func TestLogger_LogRequest(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewLogger(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	curl := "curl -X GET 'https://test.atlassian.net/rest/api/3/myself'"
+
+	// En INFO los requests no se registran
+	logger.LogRequest(curl)
+	if strings.Contains(readLogFile(t, tempDir), "action=jira_request") {
+		t.Error("Expected request entries to be omitted at INFO level")
+	}
+
+	logger.SetLevel("DEBUG")
+	logger.LogRequest(curl)
+
+	logContent := readLogFile(t, tempDir)
+	if !strings.Contains(logContent, "action=jira_request") || !strings.Contains(logContent, "rest/api/3/myself") {
+		t.Errorf("Expected request entry at DEBUG level, got: %s", logContent)
+	}
+}
+
 func TestLogger_LogIssueCreated(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -437,6 +534,97 @@ func TestLogger_WriteFormattedOutput(t *testing.T) {
 	}
 }
 
+func TestLogger_SetRunID(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewLogger(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	runID := NewRunID()
+	logger.SetRunID(runID)
+	if logger.RunID() != runID {
+		t.Errorf("RunID() = %q, want %q", logger.RunID(), runID)
+	}
+
+	logger.LogCommandStart("process", map[string]interface{}{"file": "test.csv"})
+	logger.LogIssueCreated("PROJ-1", "Story", 1)
+	logger.WithField("custom", "value").Warn("custom entry")
+	logger.Info("plain entry")
+	logger.LogCommandEnd("process", true, time.Second)
+	logger.WriteFormattedOutput("output\n")
+
+	lines := strings.Split(strings.TrimSpace(readLogFile(t, tempDir)), "\n")
+	entries := 0
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "time=") && !strings.HasPrefix(line, "=== SALIDA COMANDO") {
+			continue
+		}
+		entries++
+		if !strings.Contains(line, "run_id="+runID) {
+			t.Errorf("Expected line to carry run_id %s: %s", runID, line)
+		}
+	}
+	if entries != 6 {
+		t.Errorf("Expected 6 log entries, got %d", entries)
+	}
+}
+
+// countingHook cuenta las entradas que recibe
+type countingHook struct {
+	fired int
+}
+
+func (h *countingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *countingHook) Fire(entry *logrus.Entry) error {
+	h.fired++
+	return nil
+}
+
+func TestLogger_SetRunID_KeepsOtherHooks(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewLogger(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	hook := &countingHook{}
+	logger.AddHook(hook)
+
+	logger.SetRunID("first")
+	logger.SetRunID("second")
+	logger.Info("entry")
+
+	if hook.fired != 1 {
+		t.Errorf("Expected the hook added at setup to keep firing, fired %d times", hook.fired)
+	}
+	if runIDHooks := len(logger.Hooks[logrus.InfoLevel]) - 1; runIDHooks != 1 {
+		t.Errorf("Expected a single run_id hook, got %d", runIDHooks)
+	}
+	if content := readLogFile(t, tempDir); !strings.Contains(content, "run_id=second") || strings.Contains(content, "run_id=first") {
+		t.Errorf("Expected only the latest run_id, got: %s", content)
+	}
+}
+
+func TestNewRunID(t *testing.T) {
+	first := NewRunID()
+	second := NewRunID()
+
+	if first == second {
+		t.Error("Expected different run ids on each call")
+	}
+	if len(first) != 36 || strings.Count(first, "-") != 4 || first[14] != '4' {
+		t.Errorf("Expected a UUID v4, got %q", first)
+	}
+}
+
 func TestLogger_WriteFormattedOutput_NilFile(t *testing.T) {
 	logger := &Logger{
 		Logger:  logrus.New(),
@@ -557,6 +745,26 @@ func TestLogger_LogSubtaskCreated(t *testing.T) {
 	}
 }
 
+func TestLogger_LogSubtasksSummary(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewLogger(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	logger.LogSubtasksSummary("PROJ-123", 5, 6)
+
+	logContent := readLogFile(t, tempDir)
+	if !strings.Contains(logContent, "subtasks_summary") {
+		t.Error("Expected log to contain 'subtasks_summary'")
+	}
+	if !strings.Contains(logContent, "Subtareas creadas 5/6 para PROJ-123") {
+		t.Errorf("Expected aggregate subtask line, got %s", logContent)
+	}
+}
+
 func TestLogger_LogSubtaskError(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -1,12 +1,21 @@
 package cli
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"historiadorgo/internal/application/usecases"
 	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/domain/repositories"
 	"historiadorgo/internal/infrastructure/config"
 	"historiadorgo/internal/infrastructure/filesystem"
 	"historiadorgo/internal/infrastructure/jira"
@@ -17,13 +26,34 @@ import (
 )
 
 type App struct {
-	config          *config.Config
-	logger          *logger.Logger
-	formatter       *formatters.OutputFormatter
-	testConnUseCase *usecases.TestConnectionUseCase
-	validateUseCase *usecases.ValidateFileUseCase
-	processUseCase  *usecases.ProcessFilesUseCase
-	diagnoseUseCase *usecases.DiagnoseFeaturesUseCase
+	config           *config.Config
+	logger           *logger.Logger
+	formatter        *formatters.OutputFormatter
+	jiraClient       *jira.JiraClient
+	testConnUseCase  *usecases.TestConnectionUseCase
+	validateUseCase  *usecases.ValidateFileUseCase
+	processUseCase   *usecases.ProcessFilesUseCase
+	diagnoseUseCase  *usecases.DiagnoseFeaturesUseCase
+	preflightUseCase *usecases.PreflightUseCase
+	watchUseCase     *usecases.WatchDirectoryUseCase
+
+	// markdownReport es la ruta del checklist Markdown (--report-md); vacio si no se pidio
+	markdownReport string
+
+	// validationManifest es la ruta del manifiesto JSON de validate (--manifest)
+	validationManifest string
+
+	// console recibe la salida formateada; con --out duplica stdout en un archivo
+	console io.Writer
+
+	// jsonOutput reemplaza la salida de texto de process y validate por JSON (--output json)
+	jsonOutput bool
+
+	// resumeResults es el results.json de una ejecucion anterior a completar (--resume)
+	resumeResults string
+
+	// progress muestra el avance por fila en stderr; nil si stderr no es una terminal
+	progress *progressLine
 }
 
 func NewApp() (*App, error) {
@@ -32,34 +62,124 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("error loading config: %w", err)
 	}
 
+	if err := cfg.EnsureDirectories(); err != nil {
+		return nil, err
+	}
+
 	appLogger, err := logger.NewLogger(cfg.LogsDirectory)
 	if err != nil {
 		return nil, fmt.Errorf("error creating logger: %w", err)
 	}
+	// Cada invocacion tiene su propio id para distinguirla en logs compartidos
+	appLogger.SetRunID(logger.NewRunID())
 
 	jiraClient := jira.NewJiraClient(cfg)
+	jiraClient.SetSubtaskLogger(appLogger)
+	if cfg.LogRequests {
+		jiraClient.SetRequestLogger(appLogger)
+	}
 	fileProcessor := filesystem.NewFileProcessor(cfg.ProcessedDirectory)
+	fileProcessor.SetCommentChar(cfg.CSVCommentChar)
+	fileProcessor.SetSubtaskDelimiter(cfg.GetSubtaskDelimiter())
+	if err := fileProcessor.SetColumnAliases(cfg.GetColumnAliases()); err != nil {
+		return nil, fmt.Errorf("invalid COLUMN_ALIASES: %w", err)
+	}
+	fileProcessor.SetRequiredFields(cfg.GetRequiredFields())
+	fileProcessor.SetDeriveSummaryFromDescription(cfg.GetDerivedSummaryLength())
+	fileProcessor.SetSheet(cfg.ExcelSheet)
+	if err := fileProcessor.SetInputEncoding(cfg.InputEncoding); err != nil {
+		return nil, fmt.Errorf("invalid INPUT_ENCODING: %w", err)
+	}
 	featureManager := jira.NewFeatureManager(jiraClient, cfg)
 	formatter := formatters.NewOutputFormatter()
 
-	return &App{
+	processUseCase := usecases.NewProcessFilesUseCase(fileProcessor, jiraClient, featureManager)
+	processUseCase.SetRequestsPerSecond(cfg.RequestsPerSecond)
+	processUseCase.SetSkipFeatureValidation(cfg.SkipFeatureValidation)
+	processUseCase.SetDryRunPrefix(cfg.DryRunPrefix)
+	processUseCase.SetFeatureSimilarityThreshold(cfg.GetFeatureSimilarityThreshold())
+	processUseCase.SetFailOnEmpty(cfg.FailOnEmpty)
+	processUseCase.SetWorkers(cfg.BatchSize)
+	switch cfg.CrossProjectParent {
+	case config.CrossProjectParentWarn:
+		processUseCase.SetCrossProjectParentPolicy(usecases.CrossProjectParentWarn)
+	case config.CrossProjectParentFail:
+		processUseCase.SetCrossProjectParentPolicy(usecases.CrossProjectParentFail)
+	}
+	switch cfg.GetParentResolution() {
+	case config.ParentResolutionFeatureFirst:
+		processUseCase.SetParentResolution(entities.ParentResolutionFeatureFirst)
+	case config.ParentResolutionKeyOnly:
+		processUseCase.SetParentResolution(entities.ParentResolutionKeyOnly)
+	case config.ParentResolutionFeatureOnly:
+		processUseCase.SetParentResolution(entities.ParentResolutionFeatureOnly)
+	}
+	processUseCase.SetFailOnPostCreateError(cfg.PostCreateFailurePolicy == config.PostCreateFailureFail)
+	processUseCase.SetRollbackOnBatchFailure(cfg.RollbackOnBatchFailure)
+	if cfg.DuplicateFileGuard {
+		processUseCase.SetFileLedger(filesystem.NewFileLedger(cfg.StateFile))
+	}
+	validateUseCase := usecases.NewValidateFileUseCase(fileProcessor, jiraClient)
+	validateUseCase.SetMaxDescriptionLength(cfg.MaxDescriptionLength, cfg.DescriptionLengthPolicy != config.DescriptionPolicyWarn)
+	validateUseCase.SetTruncationMarker(cfg.GetTruncationMarker())
+	validateUseCase.SetRejectLabelSpaces(cfg.LabelSpaces == config.LabelSpacesError)
+
+	if cfg.HistoryFile != "" {
+		processUseCase.SetRunHistory(filesystem.NewRunHistory(cfg.HistoryFile))
+	}
+	if cfg.ResultsDirectory != "" {
+		processUseCase.SetResultsStore(filesystem.NewResultsStore(cfg.ResultsDirectory))
+	}
+	if cfg.LinkStoryToFeature {
+		processUseCase.SetFeatureLinkType(cfg.FeatureLinkType)
+	}
+	if cfg.UseBulkCreate {
+		processUseCase.SetBulkCreator(jiraClient)
+	}
+	if cfg.StreamCSV {
+		processUseCase.SetStoryStreamer(fileProcessor)
+	}
+
+	app := &App{
 		config:          cfg,
 		logger:          appLogger,
 		formatter:       formatter,
+		jiraClient:      jiraClient,
 		testConnUseCase: usecases.NewTestConnectionUseCase(jiraClient),
-		validateUseCase: usecases.NewValidateFileUseCase(fileProcessor, jiraClient),
-		processUseCase:  usecases.NewProcessFilesUseCase(fileProcessor, jiraClient, featureManager),
+		validateUseCase: validateUseCase,
+		processUseCase:  processUseCase,
 		diagnoseUseCase: usecases.NewDiagnoseFeaturesUseCase(featureManager),
-	}, nil
+		watchUseCase:    usecases.NewWatchDirectoryUseCase(processUseCase, fileProcessor),
+	}
+	app.preflightUseCase = usecases.NewPreflightUseCase(app.configProblems, jiraClient, validateUseCase)
+
+	// El avance va a stderr para no mezclarse con la salida (ni con --output json); redirigido no se muestra
+	if isTerminal(os.Stderr) {
+		app.progress = &progressLine{w: os.Stderr}
+		processUseCase.SetProgress(app.progress.Update)
+	}
+
+	return app, nil
 }
 
 func NewRootCmd() *cobra.Command {
 	var (
-		projectKey string
-		filePath   string
-		dryRun     bool
-		batchSize  int
-		logLevel   string
+		projectKey         string
+		filePath           string
+		dryRun             bool
+		batchSize          int
+		logLevel           string
+		force              bool
+		reportOnlyFailures bool
+		selectFiles        bool
+		explain            bool
+		reportMarkdown     string
+		dryRunPrefix       string
+		outPath            string
+		timeout            int
+		sheet              string
+		outputFormat       string
+		pretty             bool
 	)
 
 	rootCmd := &cobra.Command{
@@ -74,6 +194,32 @@ con gestión automática de subtareas y Features.`,
 			}
 
 			app.logger.SetLevel(logLevel)
+			app.processUseCase.SetForce(force)
+			if cmd.Flags().Changed("batch-size") {
+				app.processUseCase.SetWorkers(batchSize)
+			}
+			app.formatter.SetReportOnlyFailures(reportOnlyFailures)
+			if selectFiles {
+				app.enableFileSelection()
+			}
+			if explain {
+				app.enableExplain()
+			}
+			app.markdownReport = reportMarkdown
+			if dryRunPrefix != "" {
+				app.processUseCase.SetDryRunPrefix(dryRunPrefix)
+			}
+			if err := app.setOutputFormat(outputFormat); err != nil {
+				return err
+			}
+			app.formatter.SetPrettyJSON(pretty)
+			if outPath != "" {
+				closeOut, err := app.enableOutputFile(outPath)
+				if err != nil {
+					return err
+				}
+				defer closeOut()
+			}
 
 			return app.runProcess(cmd.Context(), projectKey, filePath, dryRun)
 		},
@@ -84,16 +230,53 @@ con gestión automática de subtareas y Features.`,
 	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Modo de prueba sin crear issues")
 	rootCmd.PersistentFlags().IntVarP(&batchSize, "batch-size", "b", 10, "Tamaño del lote de procesamiento")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "INFO", "Nivel de log (DEBUG, INFO, WARN, ERROR)")
+	rootCmd.PersistentFlags().BoolVar(&force, "force", false, "Reprocesar archivos aunque ya hayan sido procesados")
+	rootCmd.PersistentFlags().BoolVar(&reportOnlyFailures, "report-only-failures", false, "Mostrar solo las filas con errores en el reporte")
+	rootCmd.PersistentFlags().BoolVar(&selectFiles, "select", false, "Elegir interactivamente que archivos pendientes procesar")
+	rootCmd.PersistentFlags().BoolVar(&explain, "explain", false, "Registrar en el log (DEBUG) como se mapea cada campo por fila")
+	rootCmd.PersistentFlags().StringVar(&reportMarkdown, "report-md", "", "Escribir las historias creadas como checklist Markdown en la ruta indicada")
+	rootCmd.PersistentFlags().StringVar(&dryRunPrefix, "dry-run-prefix", "", "Prefijo de las keys simuladas en dry-run (ej: PROJ genera PROJ-1)")
+	rootCmd.PersistentFlags().StringVar(&outPath, "out", "", "Copiar la salida de consola a un archivo (sin colores)")
+	rootCmd.PersistentFlags().IntVar(&timeout, "timeout", 0, "Timeout en segundos de cada request a Jira (reemplaza HTTP_TIMEOUT_SECONDS)")
+	rootCmd.PersistentFlags().StringVar(&sheet, "sheet", "", "Hoja de Excel u ODS a leer: nombre, numero desde 1 o * para todas (reemplaza EXCEL_SHEET)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputText, "Formato de la salida: text o json")
+	rootCmd.PersistentFlags().BoolVar(&pretty, "pretty", false, "Indentar la salida JSON (por defecto compacta)")
+
+	// Cada comando carga su propia configuracion; --timeout y --sheet la sobrescriben a traves del entorno
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("timeout") {
+			if timeout <= 0 {
+				return fmt.Errorf("invalid --timeout %d: must be greater than 0 seconds", timeout)
+			}
+			if err := os.Setenv("HTTP_TIMEOUT_SECONDS", strconv.Itoa(timeout)); err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed("sheet") {
+			return os.Setenv("EXCEL_SHEET", sheet)
+		}
+		return nil
+	}
 
 	return rootCmd
 }
 
 func NewProcessCmd() *cobra.Command {
 	var (
-		projectKey string
-		filePath   string
-		dryRun     bool
-		batchSize  int
+		projectKey         string
+		filePath           string
+		dryRun             bool
+		batchSize          int
+		force              bool
+		reportOnlyFailures bool
+		selectFiles        bool
+		explain            bool
+		reportMarkdown     string
+		dryRunPrefix       string
+		outPath            string
+		outputFormat       string
+		pretty             bool
+		resume             string
 	)
 
 	cmd := &cobra.Command{
@@ -105,6 +288,35 @@ func NewProcessCmd() *cobra.Command {
 				return err
 			}
 
+			app.processUseCase.SetForce(force)
+			if cmd.Flags().Changed("batch-size") {
+				app.processUseCase.SetWorkers(batchSize)
+			}
+			app.formatter.SetReportOnlyFailures(reportOnlyFailures)
+			if selectFiles {
+				app.enableFileSelection()
+			}
+			if explain {
+				app.enableExplain()
+			}
+			app.markdownReport = reportMarkdown
+			if dryRunPrefix != "" {
+				app.processUseCase.SetDryRunPrefix(dryRunPrefix)
+			}
+			if err := app.setOutputFormat(outputFormat); err != nil {
+				return err
+			}
+			app.formatter.SetPrettyJSON(pretty)
+			if outPath != "" {
+				closeOut, err := app.enableOutputFile(outPath)
+				if err != nil {
+					return err
+				}
+				defer closeOut()
+			}
+
+			app.resumeResults = resume
+
 			return app.runProcess(cmd.Context(), projectKey, filePath, dryRun)
 		},
 	}
@@ -112,22 +324,82 @@ func NewProcessCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&projectKey, "project", "p", "", "Key del proyecto en Jira (ej: MYPROJ)")
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Archivo Excel o CSV específico")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Modo de prueba sin crear issues")
-	cmd.Flags().IntVarP(&batchSize, "batch-size", "b", 10, "Tamaño del lote de procesamiento")
+	cmd.Flags().IntVarP(&batchSize, "batch-size", "b", 0, "Cantidad de historias que se crean en paralelo (default: BATCH_SIZE)")
+	cmd.Flags().BoolVar(&force, "force", false, "Reprocesar archivos aunque ya hayan sido procesados")
+	cmd.Flags().BoolVar(&reportOnlyFailures, "report-only-failures", false, "Mostrar solo las filas con errores en el reporte")
+	cmd.Flags().BoolVar(&selectFiles, "select", false, "Elegir interactivamente que archivos pendientes procesar")
+	cmd.Flags().BoolVar(&explain, "explain", false, "Registrar en el log (DEBUG) como se mapea cada campo por fila")
+	cmd.Flags().StringVar(&reportMarkdown, "report-md", "", "Escribir las historias creadas como checklist Markdown en la ruta indicada")
+	cmd.Flags().StringVar(&dryRunPrefix, "dry-run-prefix", "", "Prefijo de las keys simuladas en dry-run (ej: PROJ genera PROJ-1)")
+	cmd.Flags().StringVar(&outPath, "out", "", "Copiar la salida de consola a un archivo (sin colores)")
+	cmd.Flags().StringVar(&outputFormat, "output", outputText, "Formato de la salida: text o json")
+	cmd.Flags().BoolVar(&pretty, "pretty", false, "Indentar la salida JSON (por defecto compacta)")
+	cmd.Flags().StringVar(&resume, "resume", "", "Completar una ejecucion anterior desde su results.json: solo procesa las filas sin key creada")
 
 	return cmd
 }
 
-func NewValidateCmd() *cobra.Command {
+func NewWatchCmd() *cobra.Command {
 	var (
 		projectKey string
-		filePath   string
-		rows       int
+		dryRun     bool
+		interval   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Observa el directorio de entrada y procesa los archivos que van llegando",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("invalid --interval %d: must be greater than 0 seconds", interval)
+			}
+
+			logLevel, _ := cmd.Flags().GetString("log-level")
+
+			app, err := NewApp()
+			if err != nil {
+				return err
+			}
+
+			app.logger.SetLevel(logLevel)
+			app.watchUseCase.SetInterval(time.Duration(interval) * time.Second)
+
+			// Ctrl+C deja terminar el archivo en curso antes de salir
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return app.runWatch(ctx, projectKey, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectKey, "project", "p", "", "Key del proyecto en Jira (ej: MYPROJ)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Modo de prueba sin crear issues")
+	cmd.Flags().IntVar(&interval, "interval", int(usecases.DefaultWatchInterval/time.Second), "Segundos entre cada revision del directorio de entrada")
+
+	return cmd
+}
+
+func NewValidateCmd() *cobra.Command {
+	var (
+		projectKey   string
+		filePath     string
+		inputDir     string
+		rows         int
+		manifest     string
+		pretty       bool
+		outPath      string
+		outputFormat string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Valida un archivo sin crear issues en Jira",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// El preview es de un solo archivo; con -d se validan todos sin preview
+			if inputDir != "" && filePath == "" && cmd.Flags().Changed("rows") {
+				return fmt.Errorf("--rows only applies to a single file (--file), not to --dir")
+			}
+
 			logLevel, _ := cmd.Flags().GetString("log-level")
 
 			app, err := NewApp()
@@ -136,6 +408,22 @@ func NewValidateCmd() *cobra.Command {
 			}
 
 			app.logger.SetLevel(logLevel)
+			app.validationManifest = manifest
+			if err := app.setOutputFormat(outputFormat); err != nil {
+				return err
+			}
+			app.formatter.SetPrettyJSON(pretty)
+			if outPath != "" {
+				closeOut, err := app.enableOutputFile(outPath)
+				if err != nil {
+					return err
+				}
+				defer closeOut()
+			}
+
+			if inputDir != "" && filePath == "" {
+				return app.runValidateDirectory(cmd.Context(), projectKey, inputDir)
+			}
 
 			return app.runValidate(cmd.Context(), projectKey, filePath, rows)
 		},
@@ -143,7 +431,12 @@ func NewValidateCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&projectKey, "project", "p", "", "Key del proyecto en Jira")
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Archivo Excel o CSV a validar")
+	cmd.Flags().StringVarP(&inputDir, "dir", "d", "", "Directorio con archivos a validar (todos los pendientes)")
 	cmd.Flags().IntVarP(&rows, "rows", "r", 5, "Número de filas a mostrar en preview")
+	cmd.Flags().StringVar(&manifest, "manifest", "", "Escribir un manifiesto JSON con campos presentes y avisos por fila")
+	cmd.Flags().BoolVar(&pretty, "pretty", false, "Indentar la salida JSON (por defecto compacta)")
+	cmd.Flags().StringVar(&outPath, "out", "", "Copiar la salida de consola a un archivo (sin colores)")
+	cmd.Flags().StringVar(&outputFormat, "output", outputText, "Formato de la salida: text o json")
 
 	return cmd
 }
@@ -186,8 +479,248 @@ func NewDiagnoseCmd() *cobra.Command {
 	return cmd
 }
 
+func NewPreflightCmd() *cobra.Command {
+	var (
+		projectKey  string
+		inputDir    string
+		printConfig bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "preflight",
+		Short: "Verifica configuracion, conexion y archivos pendientes antes de una importacion",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if printConfig {
+				// Sin NewApp: solo se muestra la configuracion, sin crear directorios ni logs
+				cfg, err := config.LoadConfig()
+				if err != nil {
+					return err
+				}
+				return runPrintConfig(cfg, os.Stdout, formatters.NewOutputFormatter())
+			}
+
+			app, err := NewApp()
+			if err != nil {
+				return err
+			}
+
+			return app.runPreflight(cmd.Context(), projectKey, inputDir)
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectKey, "project", "p", "", "Key del proyecto en Jira")
+	cmd.Flags().StringVarP(&inputDir, "dir", "d", "", "Directorio de archivos pendientes (por defecto INPUT_DIRECTORY)")
+	cmd.Flags().BoolVar(&printConfig, "print-config", false, "Mostrar la configuracion efectiva como JSON (token enmascarado) sin ejecutar las verificaciones")
+
+	return cmd
+}
+
+func NewLogsCmd() *cobra.Command {
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Lista los archivos de log recientes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Sin NewApp: crearia un log nuevo que pasaria a ser el mas reciente
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			return runLogs(cfg.LogsDirectory, tail, formatters.NewOutputFormatter())
+		},
+	}
+
+	cmd.Flags().IntVar(&tail, "tail", 0, "Mostrar las ultimas N lineas del log mas reciente")
+
+	return cmd
+}
+
+func NewHistoryCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Muestra el historial local de ejecuciones",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			if cfg.HistoryFile == "" {
+				return fmt.Errorf("run history is disabled. Set HISTORY_FILE to enable it")
+			}
+
+			return runHistory(cmd.Context(), filesystem.NewRunHistory(cfg.HistoryFile), limit, formatters.NewOutputFormatter())
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Cantidad de ejecuciones a mostrar (0 = todas)")
+
+	return cmd
+}
+
+func NewDeleteCmd() *cobra.Command {
+	var (
+		keysFile string
+		yes      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Elimina issues de Jira (con sus subtareas), ej: delete PROJ-1,PROJ-2",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys, err := readIssueKeys(strings.Join(args, ","), keysFile)
+			if err != nil {
+				return err
+			}
+
+			if !yes && !config.ConfirmAction(bufio.NewReader(os.Stdin), fmt.Sprintf("Eliminar %d issues y sus subtareas? No se puede deshacer", len(keys))) {
+				fmt.Println("Eliminacion cancelada")
+				return nil
+			}
+
+			app, err := NewApp()
+			if err != nil {
+				return err
+			}
+
+			return app.runDelete(cmd.Context(), keys)
+		},
+	}
+
+	cmd.Flags().StringVarP(&keysFile, "file", "f", "", "Archivo con las keys a eliminar (una por linea o separadas por coma)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "No pedir confirmacion")
+
+	return cmd
+}
+
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+// readIssueKeys junta las keys de la lista separada por comas y del archivo, sin repetidas.
+// En el archivo se aceptan comas, espacios o saltos de linea como separador y # para comentarios
+func readIssueKeys(list, filePath string) ([]string, error) {
+	text := list
+	if filePath != "" {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading keys file: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if comment := strings.Index(line, "#"); comment >= 0 {
+				line = line[:comment]
+			}
+			text += "," + line
+		}
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\r'
+	}) {
+		key := strings.ToUpper(field)
+		if !issueKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid issue key %q", field)
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no issue keys to delete (pass them as PROJ-1,PROJ-2 or with --file)")
+	}
+	return keys, nil
+}
+
+// enableFileSelection pide al usuario que elija entre los archivos pendientes
+func (app *App) enableFileSelection() {
+	reader := bufio.NewReader(os.Stdin)
+	app.processUseCase.SetFileSelector(func(files []string) []string {
+		return config.SelectFiles(reader, files)
+	})
+}
+
+// enableExplain registra en DEBUG el mapeo de campos de cada fila procesada
+func (app *App) enableExplain() {
+	app.logger.SetLevel("DEBUG")
+	app.processUseCase.SetExplainer(app.logger.LogFieldMapping, usecases.FieldMapping{
+		IssueType:               app.config.DefaultIssueType,
+		SubtaskIssueType:        app.config.SubtaskIssueType,
+		AcceptanceCriteriaField: app.config.AcceptanceCriteriaField,
+		ParentBySummary:         app.config.ParentBySummary,
+		EpicLinkField:           app.config.EpicLinkField,
+	})
+}
+
+// runPrintConfig muestra la configuracion efectiva (env, .env y archivos ya combinados) con el token enmascarado
+func runPrintConfig(cfg *config.Config, w io.Writer, formatter *formatters.OutputFormatter) error {
+	formatter.SetPrettyJSON(true)
+	data, err := formatter.FormatJSON(cfg.Redacted())
+	if err != nil {
+		return fmt.Errorf("error encoding config: %w", err)
+	}
+
+	fmt.Fprint(w, data)
+	return nil
+}
+
+func runLogs(logsDir string, tail int, formatter *formatters.OutputFormatter) error {
+	files, err := logger.ListLogFiles(logsDir)
+	if err != nil {
+		return err
+	}
+
+	if tail <= 0 {
+		fmt.Print(formatter.FormatLogFiles(logsDir, files))
+		return nil
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("no log files found in %s", logsDir)
+	}
+
+	lines, err := logger.TailLines(files[0].Path, tail)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(formatter.FormatLogTail(files[0], lines))
+	return nil
+}
+
+func runHistory(ctx context.Context, history repositories.RunHistory, limit int, formatter *formatters.OutputFormatter) error {
+	summaries, err := history.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Las ejecuciones mas recientes primero
+	for i, j := 0, len(summaries)-1; i < j; i, j = i+1, j-1 {
+		summaries[i], summaries[j] = summaries[j], summaries[i]
+	}
+
+	if limit > 0 && len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+
+	fmt.Print(formatter.FormatHistory(summaries))
+	return nil
+}
+
 func (app *App) runProcess(ctx context.Context, projectKey, filePath string, dryRun bool) error {
 	startTime := time.Now()
+	defer app.finishProgress()
+
+	// Sin --project, cada archivo puede indicar su proyecto en el nombre (PROJ__historias.csv)
+	routeByFilename := projectKey == "" && filePath == "" && app.config.ProjectFromFilename && app.config.ProjectFilenameSeparator != ""
+	if routeByFilename {
+		app.processUseCase.SetProjectFromFilename(app.config.ProjectFilenameSeparator)
+	}
 
 	// Usar configuración por defecto si no se proporciona proyecto
 	if projectKey == "" {
@@ -201,8 +734,8 @@ func (app *App) runProcess(ctx context.Context, projectKey, filePath string, dry
 		"dry_run":     dryRun,
 	})
 
-	// Solo requerir proyecto si no es dry-run y no está en configuración
-	if projectKey == "" && !dryRun {
+	// Solo requerir proyecto si no es dry-run y no está en configuración ni en los nombres de archivo
+	if projectKey == "" && !dryRun && !routeByFilename {
 		app.logger.LogCommandEnd("process", false, time.Since(startTime))
 		return fmt.Errorf("project key is required for real processing. Use -p flag, PROJECT_KEY env var, or --dry-run for testing")
 	}
@@ -216,7 +749,15 @@ func (app *App) runProcess(ctx context.Context, projectKey, filePath string, dry
 	var results []*entities.BatchResult
 	var err error
 
-	if filePath != "" {
+	if app.resumeResults != "" {
+		var result *entities.BatchResult
+		result, err = app.processUseCase.Resume(ctx, app.resumeResults, filePath, app.config.InputDirectory, app.config.ProcessedDirectory, projectKey, dryRun)
+		if err != nil {
+			app.logger.LogCommandEnd("process", false, time.Since(startTime))
+			return fmt.Errorf("error resuming %s: %w", app.resumeResults, err)
+		}
+		results = []*entities.BatchResult{result}
+	} else if filePath != "" {
 		var result *entities.BatchResult
 		result, err = app.processUseCase.Execute(ctx, filePath, projectKey, dryRun)
 		if err != nil {
@@ -232,37 +773,301 @@ func (app *App) runProcess(ctx context.Context, projectKey, filePath string, dry
 		}
 	}
 
+	app.finishProgress()
+
 	// Generar salida formateada
-	var output string
-	if len(results) == 1 {
-		output = app.formatter.FormatBatchResult(results[0])
-	} else {
-		output = app.formatter.FormatMultipleBatchResults(results)
+	output, err := app.formatBatchResults(results)
+	if err != nil {
+		app.logger.LogCommandEnd("process", false, time.Since(startTime))
+		return err
 	}
 
 	// Mostrar en consola
-	fmt.Print(output)
+	fmt.Fprint(app.stdout(), output)
 
 	// Escribir al log
 	app.logger.WriteFormattedOutput(output)
 
+	if app.markdownReport != "" {
+		if err := app.writeMarkdownReport(results); err != nil {
+			app.logger.LogCommandEnd("process", false, time.Since(startTime))
+			return err
+		}
+		if !app.jsonOutput {
+			fmt.Fprintf(app.stdout(), "Reporte Markdown: %s\n", app.markdownReport)
+		}
+	}
+
+	// Un lote detenido (ej: MAX_ISSUES_PER_RUN) hace fallar el comando aunque se muestre lo creado
+	for _, result := range results {
+		if result.Aborted {
+			app.logger.LogCommandEnd("process", false, time.Since(startTime))
+			return fmt.Errorf("processing stopped early in %s; the remaining rows were not processed (see the errors above)", result.FileName)
+		}
+	}
+
 	// Log fin de comando
 	app.logger.LogCommandEnd("process", true, time.Since(startTime))
 
 	return nil
 }
 
-func (app *App) runValidate(ctx context.Context, projectKey, filePath string, rows int) error {
+// Formatos de --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// setOutputFormat aplica --output: text (por defecto) o json
+func (app *App) setOutputFormat(format string) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", outputText:
+		app.jsonOutput = false
+	case outputJSON:
+		app.jsonOutput = true
+	default:
+		return fmt.Errorf("invalid --output %q: must be text or json", format)
+	}
+	return nil
+}
+
+// formatBatchResults arma la salida de process en el formato elegido; en JSON el resumen de
+// throttling solo queda en el log para no romper el documento
+func (app *App) formatBatchResults(results []*entities.BatchResult) (string, error) {
+	rateLimit := app.rateLimitSummary()
+
+	if app.jsonOutput {
+		var (
+			output string
+			err    error
+		)
+		if len(results) == 1 {
+			output, err = app.formatter.FormatBatchResultJSON(results[0])
+		} else {
+			output, err = app.formatter.FormatMultipleBatchResultsJSON(results)
+		}
+		if err != nil {
+			return "", fmt.Errorf("error encoding JSON output: %w", err)
+		}
+		return output, nil
+	}
+
+	var output string
+	if len(results) == 1 {
+		output = app.formatter.FormatBatchResult(results[0])
+	} else {
+		output = app.formatter.FormatMultipleBatchResults(results)
+	}
+	return output + rateLimit, nil
+}
+
+func (app *App) runWatch(ctx context.Context, projectKey string, dryRun bool) error {
 	startTime := time.Now()
 
-	// Usar configuración por defecto si no se proporciona proyecto
+	// Igual que process: sin --project cada archivo puede indicar su proyecto en el nombre
+	routeByFilename := projectKey == "" && app.config.ProjectFromFilename && app.config.ProjectFilenameSeparator != ""
+	if routeByFilename {
+		app.processUseCase.SetProjectFromFilename(app.config.ProjectFilenameSeparator)
+	}
+	if projectKey == "" {
+		projectKey = app.config.ProjectKey
+	}
+	if projectKey == "" && !dryRun && !routeByFilename {
+		return fmt.Errorf("project key is required for real processing. Use -p flag, PROJECT_KEY env var, or --dry-run for testing")
+	}
+	if projectKey == "" && dryRun {
+		projectKey = "DRY-RUN-PROJECT"
+	}
+
+	app.logger.LogCommandStart("watch", map[string]interface{}{
+		"dir":         app.config.InputDirectory,
+		"project_key": projectKey,
+		"dry_run":     dryRun,
+	})
+	fmt.Fprintf(app.stdout(), "Observando %s (Ctrl+C para salir)\n", app.config.InputDirectory)
+
+	app.watchUseCase.SetReporter(&watchReporter{app: app})
+	err := app.watchUseCase.Run(ctx, app.config.InputDirectory, projectKey, dryRun)
+
+	fmt.Fprintln(app.stdout(), "Watch detenido")
+	app.logger.LogCommandEnd("watch", err == nil, time.Since(startTime))
+
+	return err
+}
+
+// watchReporter muestra y registra cada archivo procesado por watch
+type watchReporter struct {
+	app *App
+}
+
+func (r *watchReporter) WatchCycle(pending, processed int) {
+	r.app.logger.LogWatchCycle(r.app.config.InputDirectory, pending, processed)
+}
+
+func (r *watchReporter) WatchFile(filePath string, result *entities.BatchResult, err error) {
+	r.app.finishProgress()
+	if err != nil {
+		r.app.logger.WithField("file", filePath).Errorf("Error procesando archivo: %v", err)
+		fmt.Fprintf(r.app.stdout(), "[ERROR] %s: %v\n", filePath, err)
+		return
+	}
+
+	r.app.logger.LogProcessEnd(filePath, result.SuccessfulRows, result.ErrorRows, result.Duration)
+	output := r.app.formatter.FormatBatchResult(result)
+	fmt.Fprint(r.app.stdout(), output)
+	r.app.logger.WriteFormattedOutput(output)
+}
+
+// progressLine reescribe una sola linea con el avance de las filas del archivo en curso
+type progressLine struct {
+	w       io.Writer
+	pending bool
+}
+
+// Update muestra processed/total; al llegar al total termina la linea. Con total 0 (archivo
+// leido por streaming) muestra solo las filas procesadas y Finish termina la linea
+func (p *progressLine) Update(processed, total int) {
+	if total <= 0 {
+		fmt.Fprintf(p.w, "\rProcesando filas: %d", processed)
+		p.pending = true
+		return
+	}
+
+	fmt.Fprintf(p.w, "\rProcesando filas: %d/%d", processed, total)
+	p.pending = processed < total
+	if !p.pending {
+		fmt.Fprintln(p.w)
+	}
+}
+
+// Finish termina la linea si quedo a medias (ej: lote detenido) para no pegarle la salida siguiente
+func (p *progressLine) Finish() {
+	if p.pending {
+		fmt.Fprintln(p.w)
+		p.pending = false
+	}
+}
+
+// finishProgress termina la linea de avance, si se esta mostrando
+func (app *App) finishProgress() {
+	if app.progress != nil {
+		app.progress.Finish()
+	}
+}
+
+// isTerminal indica si f es una terminal y no un archivo o un pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// stdout devuelve donde se escribe la salida formateada: la consola, o la consola y --out
+func (app *App) stdout() io.Writer {
+	if app.console != nil {
+		return app.console
+	}
+	return os.Stdout
+}
+
+// enableOutputFile duplica la salida formateada de la consola en path, quitando secuencias ANSI (--out).
+// Devuelve la funcion que cierra el archivo al terminar el comando
+func (app *App) enableOutputFile(path string) (func() error, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("error creating output file: %w", err)
+	}
+
+	app.console = io.MultiWriter(app.stdout(), &ansiStripWriter{w: file})
+	return file.Close, nil
+}
+
+// ansiPattern reconoce secuencias de escape ANSI (colores, estilos)
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// ansiStripWriter escribe el texto sin secuencias ANSI
+type ansiStripWriter struct {
+	w io.Writer
+}
+
+func (a *ansiStripWriter) Write(p []byte) (int, error) {
+	if _, err := a.w.Write(ansiPattern.ReplaceAll(p, nil)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// writeMarkdownReport escribe el checklist Markdown de todos los lotes en --report-md
+func (app *App) writeMarkdownReport(results []*entities.BatchResult) error {
+	sections := make([]string, 0, len(results))
+	for _, result := range results {
+		sections = append(sections, app.formatter.FormatBatchResultMarkdown(result))
+	}
+
+	if err := os.WriteFile(app.markdownReport, []byte(strings.Join(sections, "\n")), 0644); err != nil {
+		return fmt.Errorf("error writing markdown report: %w", err)
+	}
+
+	return nil
+}
+
+// writeValidationManifest escribe el manifiesto JSON de validate en --manifest, si se pidio
+func (app *App) writeValidationManifest(files []*usecases.FileValidation) error {
+	if app.validationManifest == "" {
+		return nil
+	}
+
+	data, err := app.formatter.FormatJSON(usecases.BuildValidationManifest(files))
+	if err != nil {
+		return fmt.Errorf("error encoding validation manifest: %w", err)
+	}
+
+	if err := os.WriteFile(app.validationManifest, []byte(data), 0644); err != nil {
+		return fmt.Errorf("error writing validation manifest: %w", err)
+	}
+
+	return nil
+}
+
+// rateLimitSummary registra y formatea el throttling recibido de Jira durante la ejecucion
+func (app *App) rateLimitSummary() string {
+	if app.jiraClient == nil {
+		return ""
+	}
+
+	stats := app.jiraClient.RateLimitStats()
+	if !stats.HasEvents() {
+		return ""
+	}
+
+	app.logger.LogRateLimitSummary(stats.ThrottledResponses, stats.RetryAfterWaits, stats.RetryAfterTotal)
+	return app.formatter.FormatRateLimitSummary(stats)
+}
+
+// validationProject resuelve el proyecto de validate: el de -p o PROJECT_KEY. Sin ninguno se
+// omiten los chequeos contra Jira, salvo que REQUIRE_PROJECT_FOR_VALIDATE lo exija
+func (app *App) validationProject(projectKey string) (string, error) {
 	if projectKey == "" {
 		projectKey = app.config.ProjectKey
 	}
 
+	if projectKey == "" && app.config.ValidateRequiresProject {
+		return "", fmt.Errorf("project key is required for validation (REQUIRE_PROJECT_FOR_VALIDATE=true): use -p or set PROJECT_KEY")
+	}
+
+	return projectKey, nil
+}
+
+func (app *App) runValidate(ctx context.Context, projectKey, filePath string, rows int) error {
+	startTime := time.Now()
+
+	projectKey, err := app.validationProject(projectKey)
+	if err != nil {
+		return err
+	}
+
 	// Validar que se proporcione archivo
 	if filePath == "" {
-		return fmt.Errorf("file path is required. Use -f flag to specify the file to validate")
+		return fmt.Errorf("file path is required. Use -f flag to specify the file to validate or -d to validate a directory")
 	}
 
 	// Log inicio de comando
@@ -274,11 +1079,26 @@ func (app *App) runValidate(ctx context.Context, projectKey, filePath string, ro
 
 	validationResult, err := app.validateUseCase.Execute(ctx, filePath, projectKey, rows)
 
+	if manifestErr := app.writeValidationManifest([]*usecases.FileValidation{
+		{FilePath: filePath, Result: validationResult, Err: err},
+	}); manifestErr != nil && err == nil {
+		err = manifestErr
+	}
+
 	// Generar salida formateada
-	output := app.formatter.FormatValidation(filePath, validationResult, err)
+	output := ""
+	if app.jsonOutput {
+		jsonOutput, jsonErr := app.formatter.FormatValidationJSON(filePath, validationResult, err)
+		if jsonErr != nil {
+			return fmt.Errorf("error encoding JSON output: %w", jsonErr)
+		}
+		output = jsonOutput
+	} else {
+		output = app.formatter.FormatValidation(filePath, validationResult, err)
+	}
 
 	// Mostrar en consola
-	fmt.Print(output)
+	fmt.Fprint(app.stdout(), output)
 
 	// Escribir al log
 	app.logger.WriteFormattedOutput(output)
@@ -296,6 +1116,65 @@ func (app *App) runValidate(ctx context.Context, projectKey, filePath string, ro
 	return err
 }
 
+func (app *App) runValidateDirectory(ctx context.Context, projectKey, inputDir string) error {
+	startTime := time.Now()
+
+	projectKey, err := app.validationProject(projectKey)
+	if err != nil {
+		return err
+	}
+
+	// Log inicio de comando
+	app.logger.LogCommandStart("validate", map[string]interface{}{
+		"dir":         inputDir,
+		"project_key": projectKey,
+	})
+	app.logger.LogValidationStart(inputDir)
+
+	dirResult, err := app.validateUseCase.ExecuteDirectory(ctx, inputDir, projectKey)
+
+	if dirResult != nil {
+		if manifestErr := app.writeValidationManifest(dirResult.Files); manifestErr != nil && err == nil {
+			err = manifestErr
+		}
+	}
+
+	// Generar salida formateada
+	output := ""
+	if app.jsonOutput {
+		jsonOutput, jsonErr := app.formatter.FormatDirectoryValidationJSON(inputDir, dirResult, err)
+		if jsonErr != nil {
+			return fmt.Errorf("error encoding JSON output: %w", jsonErr)
+		}
+		output = jsonOutput
+	} else {
+		output = app.formatter.FormatDirectoryValidation(inputDir, dirResult, err)
+	}
+
+	// Mostrar en consola
+	fmt.Fprint(app.stdout(), output)
+
+	// Escribir al log
+	app.logger.WriteFormattedOutput(output)
+
+	// Un archivo invalido hace fallar la validacion del directorio
+	if err == nil && dirResult.InvalidFiles > 0 {
+		err = fmt.Errorf("%d of %d files failed validation", dirResult.InvalidFiles, len(dirResult.Files))
+	}
+
+	// Log eventos específicos
+	if err != nil {
+		app.logger.LogValidationError(inputDir, err)
+	} else {
+		app.logger.LogValidationSuccess(inputDir, dirResult.Totals.TotalStories)
+	}
+
+	// Log fin de comando
+	app.logger.LogCommandEnd("validate", err == nil, time.Since(startTime))
+
+	return err
+}
+
 func (app *App) runTestConnection(ctx context.Context) error {
 	startTime := time.Now()
 
@@ -308,7 +1187,7 @@ func (app *App) runTestConnection(ctx context.Context) error {
 	output := app.formatter.FormatConnectionTest(err)
 
 	// Mostrar en consola
-	fmt.Print(output)
+	fmt.Fprint(app.stdout(), output)
 
 	// Escribir al log
 	app.logger.WriteFormattedOutput(output)
@@ -339,7 +1218,7 @@ func (app *App) runDiagnose(ctx context.Context, projectKey string) error {
 	if projectKey == "" {
 		app.logger.LogCommandEnd("diagnose", false, time.Since(startTime))
 		output := app.formatter.FormatDiagnosisNoProject()
-		fmt.Print(output)
+		fmt.Fprint(app.stdout(), output)
 		app.logger.WriteFormattedOutput(output)
 		return nil
 	}
@@ -354,7 +1233,7 @@ func (app *App) runDiagnose(ctx context.Context, projectKey string) error {
 	output := app.formatter.FormatDiagnosis(requiredFields)
 
 	// Mostrar en consola
-	fmt.Print(output)
+	fmt.Fprint(app.stdout(), output)
 
 	// Escribir al log
 	app.logger.WriteFormattedOutput(output)
@@ -365,13 +1244,90 @@ func (app *App) runDiagnose(ctx context.Context, projectKey string) error {
 	return nil
 }
 
+func (app *App) runPreflight(ctx context.Context, projectKey, inputDir string) error {
+	startTime := time.Now()
+
+	if projectKey == "" {
+		projectKey = app.config.ProjectKey
+	}
+	if inputDir == "" {
+		inputDir = app.config.InputDirectory
+	}
+
+	app.logger.LogCommandStart("preflight", map[string]interface{}{
+		"dir":         inputDir,
+		"project_key": projectKey,
+	})
+
+	report := app.preflightUseCase.Execute(ctx, inputDir, projectKey)
+
+	output := app.formatter.FormatPreflight(report)
+	fmt.Fprint(app.stdout(), output)
+	app.logger.WriteFormattedOutput(output)
+
+	app.logger.LogCommandEnd("preflight", report.Passed(), time.Since(startTime))
+
+	// Un preflight fallido termina con error para poder condicionar la importacion programada
+	if !report.Passed() {
+		return fmt.Errorf("preflight failed")
+	}
+	return nil
+}
+
+func (app *App) runDelete(ctx context.Context, keys []string) error {
+	startTime := time.Now()
+
+	app.logger.LogCommandStart("delete", map[string]interface{}{
+		"keys": strings.Join(keys, ","),
+	})
+
+	results := app.jiraClient.DeleteIssues(ctx, keys)
+
+	output := app.formatter.FormatDeleteResults(results)
+	fmt.Fprint(app.stdout(), output)
+	app.logger.WriteFormattedOutput(output)
+
+	failed := 0
+	for _, result := range results {
+		if result.Failed() {
+			failed++
+		}
+	}
+
+	app.logger.LogCommandEnd("delete", failed == 0, time.Since(startTime))
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d issues could not be deleted", failed, len(keys))
+	}
+	return nil
+}
+
+// configProblems revisa la configuracion cargada: valores validos y lo necesario para importar
+func (app *App) configProblems(projectKey string) []string {
+	var problems []string
+
+	if err := app.config.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if projectKey == "" {
+		problems = append(problems, "sin proyecto: configurar PROJECT_KEY o usar --project")
+	}
+
+	return problems
+}
+
 func SetupCommands() *cobra.Command {
 	rootCmd := NewRootCmd()
 
 	rootCmd.AddCommand(NewProcessCmd())
+	rootCmd.AddCommand(NewWatchCmd())
 	rootCmd.AddCommand(NewValidateCmd())
 	rootCmd.AddCommand(NewTestConnectionCmd())
 	rootCmd.AddCommand(NewDiagnoseCmd())
+	rootCmd.AddCommand(NewLogsCmd())
+	rootCmd.AddCommand(NewHistoryCmd())
+	rootCmd.AddCommand(NewPreflightCmd())
+	rootCmd.AddCommand(NewDeleteCmd())
 
 	return rootCmd
 }
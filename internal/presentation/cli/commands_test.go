@@ -1,7 +1,29 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"historiadorgo/internal/application/usecases"
+	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/infrastructure/config"
+	"historiadorgo/internal/infrastructure/filesystem"
+	"historiadorgo/internal/infrastructure/jira"
+	"historiadorgo/internal/infrastructure/logger"
+	"historiadorgo/internal/presentation/formatters"
+	"historiadorgo/tests/mocks"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -40,6 +62,7 @@ func TestNewRootCmd(t *testing.T) {
 
 			dryRunFlag := cmd.PersistentFlags().Lookup("dry-run")
 			assert.NotNil(t, dryRunFlag)
+			assert.NotNil(t, cmd.PersistentFlags().Lookup("dry-run-prefix"))
 
 			batchSizeFlag := cmd.PersistentFlags().Lookup("batch-size")
 			assert.NotNil(t, batchSizeFlag)
@@ -51,6 +74,62 @@ func TestNewRootCmd(t *testing.T) {
 	}
 }
 
+func TestNewRootCmd_TimeoutFlag(t *testing.T) {
+	t.Setenv("HTTP_TIMEOUT_SECONDS", "30")
+
+	root := SetupCommands()
+	var validateCmd *cobra.Command
+	for _, sub := range root.Commands() {
+		if sub.Name() == "validate" {
+			validateCmd = sub
+		}
+	}
+	assert.NotNil(t, validateCmd)
+
+	// Sin --timeout se respeta el valor del entorno
+	assert.NoError(t, validateCmd.ParseFlags(nil))
+	assert.NoError(t, root.PersistentPreRunE(validateCmd, nil))
+	assert.Equal(t, "30", os.Getenv("HTTP_TIMEOUT_SECONDS"))
+
+	// El flag heredado del root reemplaza HTTP_TIMEOUT_SECONDS
+	assert.NoError(t, validateCmd.ParseFlags([]string{"--timeout", "90"}))
+	assert.NoError(t, root.PersistentPreRunE(validateCmd, nil))
+	assert.Equal(t, "90", os.Getenv("HTTP_TIMEOUT_SECONDS"))
+
+	assert.NoError(t, validateCmd.ParseFlags([]string{"--timeout", "0"}))
+	assert.Error(t, root.PersistentPreRunE(validateCmd, nil))
+}
+
+func TestNewRootCmd_SheetFlag(t *testing.T) {
+	t.Setenv("EXCEL_SHEET", "Backlog")
+
+	root := SetupCommands()
+	var processCmd *cobra.Command
+	for _, sub := range root.Commands() {
+		if sub.Name() == "process" {
+			processCmd = sub
+		}
+	}
+	assert.NotNil(t, processCmd)
+
+	// Sin --sheet se respeta EXCEL_SHEET
+	assert.NoError(t, processCmd.ParseFlags(nil))
+	assert.NoError(t, root.PersistentPreRunE(processCmd, nil))
+	assert.Equal(t, "Backlog", os.Getenv("EXCEL_SHEET"))
+
+	assert.NoError(t, processCmd.ParseFlags([]string{"--sheet", "*"}))
+	assert.NoError(t, root.PersistentPreRunE(processCmd, nil))
+	assert.Equal(t, "*", os.Getenv("EXCEL_SHEET"))
+}
+
+func TestNewWatchCmd_InvalidInterval(t *testing.T) {
+	cmd := NewWatchCmd()
+	cmd.SetArgs([]string{"--interval", "0"})
+	cmd.SilenceUsage = true
+
+	assert.ErrorContains(t, cmd.Execute(), "invalid --interval 0")
+}
+
 func TestNewProcessCmd(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -84,6 +163,7 @@ func TestNewProcessCmd(t *testing.T) {
 
 			dryRunFlag := cmd.Flags().Lookup("dry-run")
 			assert.NotNil(t, dryRunFlag)
+			assert.NotNil(t, cmd.Flags().Lookup("dry-run-prefix"))
 
 			batchSizeFlag := cmd.Flags().Lookup("batch-size")
 			assert.NotNil(t, batchSizeFlag)
@@ -92,6 +172,77 @@ func TestNewProcessCmd(t *testing.T) {
 	}
 }
 
+func TestNewProcessCmd_BatchSizeFlag(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight, created := 0, 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/myself", "/rest/api/3/project/PROJ":
+			w.Write([]byte(`{}`))
+		case "/rest/api/3/issue/createmeta":
+			w.Write([]byte(`{"projects": [{"issuetypes": [{"name": "Story"}, {"name": "Subtask", "subtask": true}]}]}`))
+		case "/rest/api/3/issuetype":
+			w.Write([]byte(`[{"name": "Subtask", "subtask": true}]`))
+		case "/rest/api/3/issue":
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			// Espera a que haya dos historias en curso; con un solo worker vence el plazo
+			for deadline := time.Now().Add(300 * time.Millisecond); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+				mu.Lock()
+				reached := maxInFlight >= 2
+				mu.Unlock()
+				if reached {
+					break
+				}
+			}
+
+			mu.Lock()
+			inFlight--
+			created++
+			key := fmt.Sprintf("PROJ-%d", created)
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id": "1", "key": %q}`, key)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	t.Setenv("JIRA_URL", server.URL)
+	t.Setenv("JIRA_EMAIL", "test@example.com")
+	t.Setenv("JIRA_API_TOKEN", "token")
+	t.Setenv("DEFAULT_ISSUE_TYPE", "Story")
+	t.Setenv("SUBTASK_ISSUE_TYPE", "Subtask")
+	t.Setenv("BATCH_SIZE", "1")
+	t.Setenv("INPUT_DIRECTORY", filepath.Join(dir, "entrada"))
+	t.Setenv("PROCESSED_DIRECTORY", filepath.Join(dir, "procesados"))
+	t.Setenv("LOGS_DIRECTORY", filepath.Join(dir, "logs"))
+	t.Setenv("HISTORY_FILE", filepath.Join(dir, "history.jsonl"))
+	t.Setenv("STATE_FILE", filepath.Join(dir, "state.json"))
+
+	csvPath := filepath.Join(dir, "historias.csv")
+	content := "titulo,descripcion,criterio_aceptacion\nUno,Desc,Crit\nDos,Desc,Crit\nTres,Desc,Crit\nCuatro,Desc,Crit\n"
+	assert.NoError(t, os.WriteFile(csvPath, []byte(content), 0644))
+
+	cmd := NewProcessCmd()
+	assert.Equal(t, "0", cmd.Flags().Lookup("batch-size").DefValue, "the default comes from BATCH_SIZE")
+	cmd.SetArgs([]string{"-p", "PROJ", "-f", csvPath, "-b", "2"})
+	cmd.SilenceUsage = true
+
+	captureStdout(t, func() {
+		assert.NoError(t, cmd.Execute())
+	})
+	assert.Equal(t, 4, created)
+	assert.Equal(t, 2, maxInFlight, "process -b 2 should create two stories at a time despite BATCH_SIZE=1")
+}
+
 func TestNewValidateCmd(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -126,6 +277,18 @@ func TestNewValidateCmd(t *testing.T) {
 	}
 }
 
+func TestNewValidateCmd_RowsWithDirectory(t *testing.T) {
+	cmd := NewValidateCmd()
+	cmd.SetArgs([]string{"--dir", "entrada", "--rows", "10"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	err := cmd.Execute()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "--rows only applies to a single file")
+	}
+}
+
 func TestNewTestConnectionCmd(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -181,6 +344,410 @@ func TestNewDiagnoseCmd(t *testing.T) {
 	}
 }
 
+func TestNewLogsCmd(t *testing.T) {
+	cmd := NewLogsCmd()
+
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "logs", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotNil(t, cmd.RunE)
+
+	tailFlag := cmd.Flags().Lookup("tail")
+	assert.NotNil(t, tailFlag)
+	assert.Equal(t, "0", tailFlag.DefValue)
+}
+
+func TestRunLogs(t *testing.T) {
+	dir := t.TempDir()
+	older := filepath.Join(dir, "historiador_20240101_100000.log")
+	newer := filepath.Join(dir, "historiador_20240102_100000.log")
+	assert.NoError(t, os.WriteFile(older, []byte("old run\n"), 0644))
+	assert.NoError(t, os.WriteFile(newer, []byte("a\nb\nc\n"), 0644))
+	past := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(older, past, past))
+
+	formatter := formatters.NewOutputFormatter()
+
+	output := captureStdout(t, func() {
+		assert.NoError(t, runLogs(dir, 0, formatter))
+	})
+	assert.Contains(t, output, "Total: 2 archivos")
+	assert.Less(t, strings.Index(output, "historiador_20240102_100000.log"), strings.Index(output, "historiador_20240101_100000.log"))
+
+	output = captureStdout(t, func() {
+		assert.NoError(t, runLogs(dir, 2, formatter))
+	})
+	assert.Contains(t, output, "ULTIMAS 2 LINEAS: historiador_20240102_100000.log")
+	assert.Contains(t, output, "b\nc\n")
+	assert.NotContains(t, output, "old run")
+
+	err := runLogs(t.TempDir(), 5, formatter)
+	assert.ErrorContains(t, err, "no log files found")
+}
+
+func TestRunHistory(t *testing.T) {
+	ctx := context.Background()
+	history := filesystem.NewRunHistory(filepath.Join(t.TempDir(), "history.jsonl"))
+
+	for i, name := range []string{"sprint1.csv", "sprint2.csv"} {
+		summary := &entities.RunSummary{
+			Date:           time.Date(2024, 1, i+1, 10, 0, 0, 0, time.UTC),
+			FileName:       name,
+			TotalRows:      2,
+			SuccessfulRows: 2,
+			SuccessRate:    100,
+		}
+		assert.NoError(t, history.Append(ctx, summary))
+	}
+
+	formatter := formatters.NewOutputFormatter()
+
+	output := captureStdout(t, func() {
+		assert.NoError(t, runHistory(ctx, history, 0, formatter))
+	})
+	assert.Less(t, strings.Index(output, "sprint2.csv"), strings.Index(output, "sprint1.csv"), "most recent run should be listed first")
+
+	output = captureStdout(t, func() {
+		assert.NoError(t, runHistory(ctx, history, 1, formatter))
+	})
+	assert.Contains(t, output, "sprint2.csv")
+	assert.NotContains(t, output, "sprint1.csv")
+}
+
+func TestNewDeleteCmd(t *testing.T) {
+	cmd := NewDeleteCmd()
+
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "delete", cmd.Name())
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotNil(t, cmd.RunE)
+
+	fileFlag := cmd.Flags().Lookup("file")
+	assert.NotNil(t, fileFlag)
+	assert.Equal(t, "f", fileFlag.Shorthand)
+
+	yesFlag := cmd.Flags().Lookup("yes")
+	assert.NotNil(t, yesFlag)
+	assert.Equal(t, "false", yesFlag.DefValue)
+}
+
+func TestReadIssueKeys(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.txt")
+	assert.NoError(t, os.WriteFile(keysFile, []byte("# limpieza del sprint\nPROJ-3\nproj-4, PROJ-1\r\n\n"), 0644))
+
+	keys, err := readIssueKeys("PROJ-1,PROJ-2", keysFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-4"}, keys)
+
+	_, err = readIssueKeys("PROJ-1,historia", "")
+	assert.ErrorContains(t, err, `invalid issue key "historia"`)
+
+	_, err = readIssueKeys("", "")
+	assert.ErrorContains(t, err, "no issue keys to delete")
+
+	_, err = readIssueKeys("", filepath.Join(t.TempDir(), "missing.txt"))
+	assert.ErrorContains(t, err, "error reading keys file")
+}
+
+func TestApp_runDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		switch r.URL.Path {
+		case "/rest/api/3/issue/PROJ-1":
+			w.WriteHeader(http.StatusNoContent)
+		case "/rest/api/3/issue/PROJ-2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	appLogger, err := logger.NewLogger(t.TempDir())
+	assert.NoError(t, err)
+	defer appLogger.Close()
+
+	cfg := &config.Config{JiraURL: server.URL}
+	var console strings.Builder
+	app := &App{
+		config:     cfg,
+		logger:     appLogger,
+		formatter:  formatters.NewOutputFormatter(),
+		jiraClient: jira.NewJiraClient(cfg),
+		console:    &console,
+	}
+
+	assert.NoError(t, app.runDelete(context.Background(), []string{"PROJ-1", "PROJ-2"}))
+	assert.Contains(t, console.String(), "[OK] PROJ-1 eliminado")
+	assert.Contains(t, console.String(), "[WARNING] PROJ-2 no existe o ya fue eliminado")
+
+	console.Reset()
+	err = app.runDelete(context.Background(), []string{"PROJ-1", "PROJ-3"})
+	assert.ErrorContains(t, err, "1 of 2 issues could not be deleted")
+	assert.Contains(t, console.String(), "[ERROR] PROJ-3: permission denied")
+}
+
+func TestApp_writeMarkdownReport(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "reporte.md")
+	app := &App{
+		formatter:      formatters.NewOutputFormatter(),
+		markdownReport: reportPath,
+	}
+
+	first := entities.NewBatchResult("a.csv", 1, false)
+	story := entities.NewProcessResult(2)
+	story.Success = true
+	story.IssueKey = "PROJ-1"
+	story.IssueURL = "https://jira.example.com/browse/PROJ-1"
+	first.AddResult(story)
+
+	second := entities.NewBatchResult("b.csv", 0, false)
+
+	assert.NoError(t, app.writeMarkdownReport([]*entities.BatchResult{first, second}))
+
+	data, err := os.ReadFile(reportPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "## Historias: a.csv")
+	assert.Contains(t, string(data), "- [x] [PROJ-1](https://jira.example.com/browse/PROJ-1)")
+	assert.Contains(t, string(data), "## Historias: b.csv")
+
+	app.markdownReport = filepath.Join(t.TempDir(), "missing", "reporte.md")
+	assert.ErrorContains(t, app.writeMarkdownReport([]*entities.BatchResult{first}), "error writing markdown report")
+}
+
+func TestApp_writeValidationManifest(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	app := &App{validationManifest: manifestPath, formatter: formatters.NewOutputFormatter()}
+
+	files := []*usecases.FileValidation{
+		{FilePath: "ok.csv", Result: &usecases.ValidationResult{Rows: []*usecases.RowManifest{
+			{Row: 2, Fields: map[string]bool{"titulo": true}, Warnings: []string{}},
+		}}},
+		{FilePath: "roto.csv", Err: errors.New("missing required columns")},
+	}
+	assert.NoError(t, app.writeValidationManifest(files))
+
+	data, err := os.ReadFile(manifestPath)
+	assert.NoError(t, err)
+
+	var manifest usecases.ValidationManifest
+	assert.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Len(t, manifest.Files, 2)
+	assert.Len(t, manifest.Files[0].Rows, 1)
+	assert.Equal(t, "missing required columns", manifest.Files[1].Error)
+	assert.NotContains(t, string(data), "\n  ", "manifest should be compact by default")
+
+	app.formatter.SetPrettyJSON(true)
+	assert.NoError(t, app.writeValidationManifest(files))
+	data, err = os.ReadFile(manifestPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "\n  \"files\"")
+
+	app.validationManifest = ""
+	assert.NoError(t, app.writeValidationManifest(files))
+}
+
+func TestApp_enableOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "historias.csv")
+	assert.NoError(t, os.WriteFile(csvPath, []byte("titulo,descripcion,criterio_aceptacion\nStory,Desc,Crit\n"), 0644))
+
+	appLogger, err := logger.NewLogger(dir)
+	assert.NoError(t, err)
+	defer appLogger.Close()
+
+	app := &App{
+		config:          &config.Config{},
+		logger:          appLogger,
+		formatter:       formatters.NewOutputFormatter(),
+		validateUseCase: usecases.NewValidateFileUseCase(filesystem.NewFileProcessor(dir), &mocks.MockJiraRepository{}),
+	}
+	outPath := filepath.Join(dir, "salida.txt")
+
+	stdout := captureStdout(t, func() {
+		closeOut, err := app.enableOutputFile(outPath)
+		assert.NoError(t, err)
+		assert.NoError(t, app.runValidate(context.Background(), "", csvPath, 5))
+		fmt.Fprint(app.stdout(), "\x1b[32m[OK]\x1b[0m listo\n")
+		assert.NoError(t, closeOut())
+	})
+
+	data, err := os.ReadFile(outPath)
+	assert.NoError(t, err)
+	assert.Contains(t, stdout, "\x1b[32m[OK]")
+	assert.Equal(t, strings.ReplaceAll(strings.ReplaceAll(stdout, "\x1b[32m", ""), "\x1b[0m", ""), string(data))
+
+	_, err = app.enableOutputFile(filepath.Join(dir, "missing", "salida.txt"))
+	assert.ErrorContains(t, err, "error creating output file")
+}
+
+func TestProgressLine(t *testing.T) {
+	var out strings.Builder
+	progress := &progressLine{w: &out}
+
+	progress.Update(1, 2)
+	progress.Update(2, 2)
+	progress.Finish()
+	assert.Equal(t, "\rProcesando filas: 1/2\rProcesando filas: 2/2\n", out.String())
+
+	// Un lote detenido deja la linea a medias; Finish la termina una sola vez
+	out.Reset()
+	progress.Update(1, 3)
+	progress.Finish()
+	progress.Finish()
+	assert.Equal(t, "\rProcesando filas: 1/3\n", out.String())
+
+	// Sin total conocido solo se cuentan las filas y Finish termina la linea
+	out.Reset()
+	progress.Update(1, 0)
+	progress.Update(2, 0)
+	progress.Finish()
+	assert.Equal(t, "\rProcesando filas: 1\rProcesando filas: 2\n", out.String())
+}
+
+func TestApp_runValidate_RequireProject(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "historias.csv")
+	assert.NoError(t, os.WriteFile(csvPath, []byte("titulo,descripcion,criterio_aceptacion\nStory,Desc,Crit\n"), 0644))
+
+	appLogger, err := logger.NewLogger(dir)
+	assert.NoError(t, err)
+	defer appLogger.Close()
+
+	tests := []struct {
+		name           string
+		cfg            *config.Config
+		projectKey     string
+		wantError      bool
+		wantJiraChecks int
+	}{
+		{
+			name: "optional project skips jira checks",
+			cfg:  &config.Config{},
+		},
+		{
+			name:      "required project without project fails",
+			cfg:       &config.Config{ValidateRequiresProject: true},
+			wantError: true,
+		},
+		{
+			name:           "required project from flag",
+			cfg:            &config.Config{ValidateRequiresProject: true},
+			projectKey:     "PROJ",
+			wantJiraChecks: 1,
+		},
+		{
+			name:           "required project from config",
+			cfg:            &config.Config{ValidateRequiresProject: true, ProjectKey: "PROJ"},
+			wantJiraChecks: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectChecks := 0
+			jiraRepo := &mocks.MockJiraRepository{
+				ValidateProjectFunc: func(ctx context.Context, projectKey string) error {
+					projectChecks++
+					assert.Equal(t, "PROJ", projectKey)
+					return nil
+				},
+			}
+			app := &App{
+				config:          tt.cfg,
+				logger:          appLogger,
+				formatter:       formatters.NewOutputFormatter(),
+				validateUseCase: usecases.NewValidateFileUseCase(filesystem.NewFileProcessor(dir), jiraRepo),
+			}
+
+			var err error
+			captureStdout(t, func() {
+				err = app.runValidate(context.Background(), tt.projectKey, csvPath, 5)
+			})
+
+			if tt.wantError {
+				assert.ErrorContains(t, err, "REQUIRE_PROJECT_FOR_VALIDATE")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantJiraChecks, projectChecks)
+		})
+	}
+}
+
+func TestApp_runValidate_JSONOutput(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "historias.csv")
+	assert.NoError(t, os.WriteFile(csvPath, []byte("titulo,descripcion,criterio_aceptacion\nStory,Desc,Crit\n"), 0644))
+
+	appLogger, err := logger.NewLogger(dir)
+	assert.NoError(t, err)
+	defer appLogger.Close()
+
+	app := &App{
+		config:          &config.Config{},
+		logger:          appLogger,
+		formatter:       formatters.NewOutputFormatter(),
+		validateUseCase: usecases.NewValidateFileUseCase(filesystem.NewFileProcessor(dir), &mocks.MockJiraRepository{}),
+	}
+	assert.ErrorContains(t, app.setOutputFormat("yaml"), "invalid --output")
+	assert.NoError(t, app.setOutputFormat("JSON"))
+
+	stdout := captureStdout(t, func() {
+		assert.NoError(t, app.runValidate(context.Background(), "", csvPath, 5))
+	})
+
+	var output map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &output), "stdout should be a single JSON document: %s", stdout)
+	assert.Equal(t, csvPath, output["file"])
+	assert.Equal(t, true, output["valid"])
+	assert.Equal(t, float64(1), output["result"].(map[string]interface{})["total_stories"])
+	assert.NotContains(t, stdout, "=== VALIDACION")
+}
+
+func TestRunPrintConfig(t *testing.T) {
+	cfg := &config.Config{
+		JiraURL:      "https://test.atlassian.net",
+		JiraEmail:    "test@example.com",
+		JiraAPIToken: "secret-token",
+		ProjectKey:   "PROJ",
+		BatchSize:    10,
+	}
+
+	var out strings.Builder
+	assert.NoError(t, runPrintConfig(cfg, &out, formatters.NewOutputFormatter()))
+
+	assert.NotContains(t, out.String(), "secret-token")
+	assert.Contains(t, out.String(), "\n  \"JiraURL\"", "config should be printed indented")
+
+	var printed map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(out.String()), &printed))
+	assert.Equal(t, config.MaskedSecret, printed["JiraAPIToken"])
+	assert.Equal(t, "PROJ", printed["ProjectKey"])
+
+	fields := reflect.TypeOf(config.Config{})
+	for i := 0; i < fields.NumField(); i++ {
+		assert.Contains(t, printed, fields.Field(i).Name)
+	}
+}
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	w.Close()
+
+	out, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	return string(out)
+}
+
 func TestSetupCommands(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -193,6 +760,11 @@ func TestSetupCommands(t *testing.T) {
 				"validate",
 				"test-connection",
 				"diagnose",
+				"logs",
+				"history",
+				"preflight",
+				"delete",
+				"watch",
 			},
 		},
 	}
@@ -242,7 +814,7 @@ func TestNewApp_Integration(t *testing.T) {
 
 			// Verify all expected commands are present
 			commands := app.Commands()
-			expectedCommands := []string{"process", "validate", "test-connection", "diagnose"}
+			expectedCommands := []string{"process", "validate", "test-connection", "diagnose", "logs", "history", "preflight", "delete", "watch"}
 
 			assert.Len(t, commands, len(expectedCommands))
 
@@ -271,12 +843,17 @@ func TestCommandFlags_Integration(t *testing.T) {
 		{
 			name:          "process_command_flags",
 			commandName:   "process",
-			expectedFlags: []string{"project", "file", "dry-run", "batch-size"},
+			expectedFlags: []string{"project", "file", "dry-run", "batch-size", "output", "pretty", "resume"},
 		},
 		{
 			name:          "validate_command_flags",
 			commandName:   "validate",
-			expectedFlags: []string{"project", "file"},
+			expectedFlags: []string{"project", "file", "output", "pretty"},
+		},
+		{
+			name:          "watch_command_flags",
+			commandName:   "watch",
+			expectedFlags: []string{"project", "dry-run", "interval"},
 		},
 		{
 			name:          "diagnose_command_flags",
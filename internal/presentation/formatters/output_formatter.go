@@ -1,26 +1,161 @@
 package formatters
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"historiadorgo/internal/application/usecases"
 	"historiadorgo/internal/domain/entities"
+	"historiadorgo/internal/infrastructure/logger"
 )
 
-type OutputFormatter struct{}
+type OutputFormatter struct {
+	onlyFailures bool
+	prettyJSON   bool
+}
 
 func NewOutputFormatter() *OutputFormatter {
 	return &OutputFormatter{}
 }
 
+// SetReportOnlyFailures limita el detalle a las filas con error, manteniendo los totales
+func (of *OutputFormatter) SetReportOnlyFailures(onlyFailures bool) {
+	of.onlyFailures = onlyFailures
+}
+
+// SetPrettyJSON indenta la salida JSON; por defecto es compacta para consumo por scripts
+func (of *OutputFormatter) SetPrettyJSON(pretty bool) {
+	of.prettyJSON = pretty
+}
+
+// FormatJSON serializa value compacto o indentado segun SetPrettyJSON
+func (of *OutputFormatter) FormatJSON(value interface{}) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if of.prettyJSON {
+		data, err = json.MarshalIndent(value, "", "  ")
+	} else {
+		data, err = json.Marshal(value)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
+
+// batchResultsJSON es la salida de process --output json cuando se procesan varios archivos
+type batchResultsJSON struct {
+	Files          int                     `json:"files"`
+	ProcessedRows  int                     `json:"processed_rows"`
+	SuccessfulRows int                     `json:"successful_rows"`
+	ErrorRows      int                     `json:"error_rows"`
+	Results        []*entities.BatchResult `json:"results"`
+}
+
+// validationJSON es la salida de validate --output json para un archivo
+type validationJSON struct {
+	File   string                     `json:"file"`
+	Valid  bool                       `json:"valid"`
+	Error  string                     `json:"error,omitempty"`
+	Result *usecases.ValidationResult `json:"result,omitempty"`
+}
+
+// directoryValidationJSON es la salida de validate -d --output json
+type directoryValidationJSON struct {
+	Dir          string                     `json:"dir"`
+	Error        string                     `json:"error,omitempty"`
+	ValidFiles   int                        `json:"valid_files"`
+	InvalidFiles int                        `json:"invalid_files"`
+	Totals       *usecases.ValidationResult `json:"totals,omitempty"`
+	Files        []*validationJSON          `json:"files"`
+}
+
+// FormatBatchResultJSON serializa el lote con el resultado de cada fila (keys, URLs, subtareas
+// y errores); dry_run distingue las keys simuladas de las creadas
+func (of *OutputFormatter) FormatBatchResultJSON(result *entities.BatchResult) (string, error) {
+	return of.FormatJSON(result)
+}
+
+// FormatMultipleBatchResultsJSON serializa los lotes de varios archivos con sus totales
+func (of *OutputFormatter) FormatMultipleBatchResultsJSON(results []*entities.BatchResult) (string, error) {
+	summary := batchResultsJSON{Files: len(results), Results: results}
+	if summary.Results == nil {
+		summary.Results = []*entities.BatchResult{}
+	}
+	for _, result := range results {
+		summary.ProcessedRows += result.ProcessedRows
+		summary.SuccessfulRows += result.SuccessfulRows
+		summary.ErrorRows += result.ErrorRows
+	}
+
+	return of.FormatJSON(summary)
+}
+
+// FormatValidationJSON serializa la validacion de un archivo; error indica que no se pudo validar
+func (of *OutputFormatter) FormatValidationJSON(filePath string, validationResult *usecases.ValidationResult, err error) (string, error) {
+	return of.FormatJSON(newValidationJSON(filePath, validationResult, err))
+}
+
+// FormatDirectoryValidationJSON serializa la validacion de un directorio con el detalle por archivo
+func (of *OutputFormatter) FormatDirectoryValidationJSON(inputDir string, dirResult *usecases.DirectoryValidationResult, err error) (string, error) {
+	output := directoryValidationJSON{Dir: inputDir, Files: []*validationJSON{}}
+	if err != nil {
+		output.Error = err.Error()
+	}
+	if dirResult != nil {
+		output.ValidFiles = dirResult.ValidFiles
+		output.InvalidFiles = dirResult.InvalidFiles
+		output.Totals = stableValidationResult(dirResult.Totals)
+		for _, file := range dirResult.Files {
+			output.Files = append(output.Files, newValidationJSON(file.FilePath, file.Result, file.Err))
+		}
+	}
+
+	return of.FormatJSON(output)
+}
+
+func newValidationJSON(filePath string, validationResult *usecases.ValidationResult, err error) *validationJSON {
+	output := &validationJSON{File: filePath, Valid: err == nil}
+	if err != nil {
+		output.Error = err.Error()
+		return output
+	}
+	output.Result = stableValidationResult(validationResult)
+	return output
+}
+
+// stableValidationResult devuelve una copia con listas vacias en lugar de null, para que los
+// scripts encuentren siempre las mismas claves
+func stableValidationResult(result *usecases.ValidationResult) *usecases.ValidationResult {
+	if result == nil {
+		return nil
+	}
+
+	stable := *result
+	if stable.Warnings == nil {
+		stable.Warnings = []string{}
+	}
+	if stable.Errors == nil {
+		stable.Errors = []string{}
+	}
+	return &stable
+}
+
 func (of *OutputFormatter) FormatBatchResult(result *entities.BatchResult) string {
 	var output strings.Builder
 
 	output.WriteString(of.formatHeader(result))
 	output.WriteString(of.formatSummary(result))
 
+	if result.APIEstimate != nil {
+		output.WriteString(of.formatAPIEstimate(result.APIEstimate))
+	}
+
 	if result.HasValidationErrors() {
 		output.WriteString(of.formatValidationErrors(result))
 	}
@@ -47,17 +182,38 @@ func (of *OutputFormatter) FormatMultipleBatchResults(results []*entities.BatchR
 	totalProcessed := 0
 	totalSuccessful := 0
 	totalErrors := 0
+	featuresCreated := 0
+	featuresReused := 0
+	subtasksCreated := 0
+	subtasksFailed := 0
 
+	var allResults []*entities.ProcessResult
 	for _, result := range results {
+		allResults = append(allResults, result.Results...)
 		totalProcessed += result.ProcessedRows
 		totalSuccessful += result.SuccessfulRows
 		totalErrors += result.ErrorRows
+		featuresCreated += result.FeaturesCreated
+		featuresReused += result.FeaturesReused
+		subtasksCreated += result.TotalSubtasksCreated
+		subtasksFailed += result.TotalSubtasksFailed
 	}
 
 	output.WriteString(fmt.Sprintf("Archivos procesados: %d\n", totalFiles))
 	output.WriteString(fmt.Sprintf("Historias procesadas: %d\n", totalProcessed))
 	output.WriteString(fmt.Sprintf("[OK] Historias exitosas: %d\n", totalSuccessful))
 	output.WriteString(fmt.Sprintf("[ERROR] Historias con errores: %d\n", totalErrors))
+	output.WriteString(formatFailuresByCode(allResults))
+
+	if featuresCreated > 0 || featuresReused > 0 {
+		output.WriteString(fmt.Sprintf("Features creadas: %d\n", featuresCreated))
+		output.WriteString(fmt.Sprintf("Features reutilizadas: %d\n", featuresReused))
+	}
+
+	if subtasksCreated > 0 || subtasksFailed > 0 {
+		output.WriteString(fmt.Sprintf("Subtareas creadas: %d\n", subtasksCreated))
+		output.WriteString(fmt.Sprintf("Subtareas con errores: %d\n", subtasksFailed))
+	}
 
 	if totalProcessed > 0 {
 		successRate := float64(totalSuccessful) / float64(totalProcessed) * 100
@@ -75,6 +231,93 @@ func (of *OutputFormatter) FormatMultipleBatchResults(results []*entities.BatchR
 	return output.String()
 }
 
+// FormatBatchResultMarkdown lista las historias del lote como checklist Markdown con links
+// y subtareas anidadas; las filas creadas quedan marcadas y las fallidas pendientes
+func (of *OutputFormatter) FormatBatchResultMarkdown(result *entities.BatchResult) string {
+	var output strings.Builder
+
+	title := result.FileName
+	if result.DryRun {
+		title += " (dry-run)"
+	}
+	output.WriteString(fmt.Sprintf("## Historias: %s\n\n", title))
+
+	if len(result.Results) == 0 {
+		output.WriteString("_Sin historias procesadas_\n")
+		return output.String()
+	}
+
+	for _, processResult := range result.Results {
+		if !processResult.Success {
+			output.WriteString(fmt.Sprintf("- [ ] Fila %d: %s (error: %s)\n", processResult.RowNumber, processResult.Summary, processResult.ErrorMessage))
+			continue
+		}
+
+		output.WriteString(fmt.Sprintf("- [x] %s %s\n", markdownLink(processResult.IssueKey, processResult.IssueURL), processResult.Summary))
+
+		for _, subtask := range processResult.Subtareas {
+			if subtask.Success {
+				output.WriteString(fmt.Sprintf("  - [x] %s %s\n", markdownLink(subtask.IssueKey, subtask.IssueURL), subtask.Description))
+			} else {
+				output.WriteString(fmt.Sprintf("  - [ ] %s (error: %s)\n", subtask.Description, subtask.Error))
+			}
+		}
+	}
+
+	return output.String()
+}
+
+func markdownLink(key, url string) string {
+	if url == "" {
+		return key
+	}
+	return fmt.Sprintf("[%s](%s)", key, url)
+}
+
+// FormatRateLimitSummary resume el throttling recibido de Jira; vacio si no hubo eventos
+func (of *OutputFormatter) FormatRateLimitSummary(stats entities.RateLimitStats) string {
+	if !stats.HasEvents() {
+		return ""
+	}
+
+	var output strings.Builder
+
+	output.WriteString("=== LIMITE DE TASA (RATE LIMIT) ===\n")
+	output.WriteString(fmt.Sprintf("Respuestas 429 recibidas: %d\n", stats.ThrottledResponses))
+	if stats.RetryAfterWaits > 0 {
+		output.WriteString(fmt.Sprintf("Esperas Retry-After: %d (total %v)\n", stats.RetryAfterWaits, stats.RetryAfterTotal.Round(time.Second)))
+	}
+	output.WriteString("Sugerencia: reducir la tasa de requests a Jira para evitar el throttling\n\n")
+
+	return output.String()
+}
+
+// FormatDeleteResults muestra el resultado de cada key del comando delete y un resumen
+func (of *OutputFormatter) FormatDeleteResults(results []*entities.DeleteResult) string {
+	var output strings.Builder
+
+	output.WriteString("=== ELIMINACION DE ISSUES ===\n")
+
+	deleted, alreadyDeleted, failed := 0, 0, 0
+	for _, result := range results {
+		switch {
+		case result.Deleted:
+			deleted++
+			output.WriteString(fmt.Sprintf("[OK] %s eliminado\n", result.IssueKey))
+		case result.AlreadyDeleted:
+			alreadyDeleted++
+			output.WriteString(fmt.Sprintf("[WARNING] %s no existe o ya fue eliminado\n", result.IssueKey))
+		default:
+			failed++
+			output.WriteString(fmt.Sprintf("[ERROR] %s: %s\n", result.IssueKey, result.Error))
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("\nEliminados: %d, ya eliminados: %d, con errores: %d\n", deleted, alreadyDeleted, failed))
+
+	return output.String()
+}
+
 func (of *OutputFormatter) FormatConnectionTest(err error) string {
 	if err != nil {
 		return fmt.Sprintf("[ERROR] Prueba de conexion fallida: %v\n", err)
@@ -101,10 +344,20 @@ func (of *OutputFormatter) FormatValidation(filePath string, validationResult *u
 		output.WriteString(fmt.Sprintf("Con subtareas: %d\n", validationResult.WithSubtasks))
 		output.WriteString(fmt.Sprintf("Total subtareas: %d\n", validationResult.TotalSubtasks))
 		output.WriteString(fmt.Sprintf("Con parent: %d\n", validationResult.WithParent))
+		if validationResult.WithLabels > 0 {
+			output.WriteString(fmt.Sprintf("Con etiquetas: %d (%s)\n", validationResult.WithLabels, strings.Join(validationResult.Labels, ", ")))
+		}
+		output.WriteString(formatSubtaskHistogram(validationResult.SubtaskHistogram))
 
 		if validationResult.InvalidSubtasks > 0 {
 			output.WriteString(fmt.Sprintf("[WARNING] Subtareas invalidas: %d\n", validationResult.InvalidSubtasks))
 		}
+		for _, warning := range validationResult.Warnings {
+			output.WriteString(fmt.Sprintf("[WARNING] %s\n", warning))
+		}
+		for _, rowError := range validationResult.Errors {
+			output.WriteString(fmt.Sprintf("[ERROR] %s\n", rowError))
+		}
 
 		output.WriteString("\n")
 
@@ -128,6 +381,98 @@ func (of *OutputFormatter) FormatValidation(filePath string, validationResult *u
 	return output.String()
 }
 
+// formatSubtaskHistogram muestra en una linea cuantas historias caen en cada rango de subtareas
+func formatSubtaskHistogram(histogram []usecases.SubtaskBucket) string {
+	if len(histogram) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(histogram))
+	for i, bucket := range histogram {
+		parts[i] = fmt.Sprintf("%s: %d", bucket.Label, bucket.Stories)
+	}
+
+	return fmt.Sprintf("Historias por cantidad de subtareas: %s\n", strings.Join(parts, ", "))
+}
+
+func (of *OutputFormatter) FormatDirectoryValidation(inputDir string, dirResult *usecases.DirectoryValidationResult, err error) string {
+	var output strings.Builder
+
+	output.WriteString("=== VALIDACION DE DIRECTORIO ===\n\n")
+	output.WriteString(fmt.Sprintf("Directorio: %s\n", inputDir))
+
+	if dirResult == nil {
+		output.WriteString(fmt.Sprintf("[ERROR] Validacion fallida: %v\n", err))
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("Archivos: %d\n", len(dirResult.Files)))
+	output.WriteString(fmt.Sprintf("[OK] Archivos validos: %d\n", dirResult.ValidFiles))
+	output.WriteString(fmt.Sprintf("[ERROR] Archivos con errores: %d\n\n", dirResult.InvalidFiles))
+
+	output.WriteString("=== ESTADISTICAS TOTALES ===\n")
+	output.WriteString(fmt.Sprintf("Total de historias: %d\n", dirResult.Totals.TotalStories))
+	output.WriteString(fmt.Sprintf("Con subtareas: %d\n", dirResult.Totals.WithSubtasks))
+	output.WriteString(fmt.Sprintf("Total subtareas: %d\n", dirResult.Totals.TotalSubtasks))
+	output.WriteString(fmt.Sprintf("Con parent: %d\n", dirResult.Totals.WithParent))
+	if dirResult.Totals.WithLabels > 0 {
+		output.WriteString(fmt.Sprintf("Con etiquetas: %d (%s)\n", dirResult.Totals.WithLabels, strings.Join(dirResult.Totals.Labels, ", ")))
+	}
+	output.WriteString(formatSubtaskHistogram(dirResult.Totals.SubtaskHistogram))
+	if dirResult.Totals.InvalidSubtasks > 0 {
+		output.WriteString(fmt.Sprintf("[WARNING] Subtareas invalidas: %d\n", dirResult.Totals.InvalidSubtasks))
+	}
+	for _, warning := range dirResult.Totals.Warnings {
+		output.WriteString(fmt.Sprintf("[WARNING] %s\n", warning))
+	}
+	for _, rowError := range dirResult.Totals.Errors {
+		output.WriteString(fmt.Sprintf("[ERROR] %s\n", rowError))
+	}
+	output.WriteString("\n")
+
+	output.WriteString("=== DETALLE POR ARCHIVO ===\n")
+	for _, file := range dirResult.Files {
+		if file.Err != nil {
+			output.WriteString(fmt.Sprintf("[ERROR] %s: %v\n", file.FilePath, file.Err))
+			continue
+		}
+		output.WriteString(fmt.Sprintf("[OK] %s: %d historias, %d subtareas, %d con parent\n",
+			file.FilePath, file.Result.TotalStories, file.Result.TotalSubtasks, file.Result.WithParent))
+	}
+
+	if err != nil {
+		output.WriteString(fmt.Sprintf("\n[ERROR] Validacion de proyecto fallida: %v\n", err))
+	}
+
+	return output.String()
+}
+
+// FormatPreflight muestra cada seccion del preflight y el resultado consolidado
+func (of *OutputFormatter) FormatPreflight(report *usecases.PreflightReport) string {
+	var output strings.Builder
+
+	output.WriteString("=== PREFLIGHT ===\n\n")
+
+	for _, section := range report.Sections {
+		status := "[OK]"
+		if !section.Passed {
+			status = "[ERROR]"
+		}
+		output.WriteString(fmt.Sprintf("%s %s\n", status, section.Name))
+		for _, detail := range section.Details {
+			output.WriteString(fmt.Sprintf("  - %s\n", detail))
+		}
+	}
+
+	if report.Passed() {
+		output.WriteString("\nRESULTADO: APTO para importar\n")
+	} else {
+		output.WriteString("\nRESULTADO: NO APTO para importar\n")
+	}
+
+	return output.String()
+}
+
 func (of *OutputFormatter) FormatDiagnosis(requiredFields []string) string {
 	var output strings.Builder
 
@@ -160,6 +505,84 @@ func (of *OutputFormatter) FormatDiagnosisNoProject() string {
 	return output.String()
 }
 
+// FormatHistory muestra el historial de ejecuciones como tabla
+func (of *OutputFormatter) FormatHistory(summaries []*entities.RunSummary) string {
+	var output strings.Builder
+
+	output.WriteString("=== HISTORIAL DE EJECUCIONES ===\n\n")
+
+	if len(summaries) == 0 {
+		output.WriteString("Sin ejecuciones registradas\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("%-19s  %-35s  %8s  %7s  %10s\n", "FECHA", "ARCHIVO", "FILAS", "EXITO", "DURACION"))
+	output.WriteString(strings.Repeat("-", 87) + "\n")
+
+	for _, summary := range summaries {
+		fileName := summary.FileName
+		if summary.DryRun {
+			fileName += " (dry-run)"
+		}
+		if len(fileName) > 35 {
+			fileName = fileName[:32] + "..."
+		}
+
+		output.WriteString(fmt.Sprintf("%-19s  %-35s  %8s  %6.1f%%  %10v\n",
+			summary.Date.Format("2006-01-02 15:04:05"),
+			fileName,
+			fmt.Sprintf("%d/%d", summary.SuccessfulRows, summary.TotalRows),
+			summary.SuccessRate,
+			summary.Duration.Round(time.Millisecond)))
+	}
+
+	return output.String()
+}
+
+// FormatLogFiles lista los logs del directorio, del mas reciente al mas antiguo
+func (of *OutputFormatter) FormatLogFiles(logsDir string, files []logger.LogFile) string {
+	var output strings.Builder
+
+	output.WriteString("=== ARCHIVOS DE LOG ===\n\n")
+	output.WriteString(fmt.Sprintf("Directorio: %s\n", logsDir))
+
+	if len(files) == 0 {
+		output.WriteString("Sin archivos de log\n")
+		return output.String()
+	}
+
+	output.WriteString("\n")
+	for _, file := range files {
+		output.WriteString(fmt.Sprintf("%-40s %10s  %s\n", file.Name, formatSize(file.Size), file.ModTime.Format("2006-01-02 15:04:05")))
+	}
+	output.WriteString(fmt.Sprintf("\nTotal: %d archivos\n", len(files)))
+
+	return output.String()
+}
+
+// FormatLogTail muestra las ultimas lineas de un archivo de log
+func (of *OutputFormatter) FormatLogTail(file logger.LogFile, lines []string) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("=== ULTIMAS %d LINEAS: %s ===\n", len(lines), file.Name))
+	for _, line := range lines {
+		output.WriteString(line + "\n")
+	}
+
+	return output.String()
+}
+
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
+	case bytes >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
+
 func (of *OutputFormatter) formatHeader(result *entities.BatchResult) string {
 	var output strings.Builder
 
@@ -185,11 +608,26 @@ func (of *OutputFormatter) formatSummary(result *entities.BatchResult) string {
 	output.WriteString(fmt.Sprintf("Filas procesadas: %d\n", result.ProcessedRows))
 	output.WriteString(fmt.Sprintf("[OK] Exitosas: %d\n", result.SuccessfulRows))
 	output.WriteString(fmt.Sprintf("[ERROR] Con errores: %d\n", result.ErrorRows))
+	output.WriteString(formatFailuresByCode(result.Results))
 
 	if result.SkippedRows > 0 {
 		output.WriteString(fmt.Sprintf("Saltadas: %d\n", result.SkippedRows))
 	}
 
+	if result.FeaturesCreated > 0 || result.FeaturesReused > 0 {
+		output.WriteString(fmt.Sprintf("Features creadas: %d\n", result.FeaturesCreated))
+		output.WriteString(fmt.Sprintf("Features reutilizadas: %d\n", result.FeaturesReused))
+	}
+
+	if result.TotalSubtasksCreated > 0 || result.TotalSubtasksFailed > 0 {
+		output.WriteString(fmt.Sprintf("Subtareas creadas: %d\n", result.TotalSubtasksCreated))
+		output.WriteString(fmt.Sprintf("Subtareas con errores: %d\n", result.TotalSubtasksFailed))
+	}
+
+	if len(result.RolledBack) > 0 {
+		output.WriteString(fmt.Sprintf("Rollback: %d issues eliminados (%s)\n", len(result.RolledBack), strings.Join(result.RolledBack, ", ")))
+	}
+
 	if result.ProcessedRows > 0 {
 		successRate := result.GetSuccessRate()
 		output.WriteString(fmt.Sprintf("Tasa de exito: %.1f%%\n", successRate))
@@ -200,18 +638,70 @@ func (of *OutputFormatter) formatSummary(result *entities.BatchResult) string {
 	return output.String()
 }
 
+// formatFailuresByCode resume las filas fallidas por tipo de error (ej: "VALIDATION: 5, AUTH: 2")
+func formatFailuresByCode(results []*entities.ProcessResult) string {
+	grouped := entities.GroupFailuresByCode(results)
+	if len(grouped) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(grouped))
+	for i, group := range grouped {
+		parts[i] = fmt.Sprintf("%s: %d", group.Code, group.Count)
+	}
+
+	return fmt.Sprintf("Errores por tipo: %s\n", strings.Join(parts, ", "))
+}
+
+func (of *OutputFormatter) formatAPIEstimate(estimate *entities.APIEstimate) string {
+	var output strings.Builder
+
+	output.WriteString("=== ESTIMACION DE LLAMADAS A JIRA ===\n")
+	output.WriteString(fmt.Sprintf("Historias: %d\n", estimate.StoryCalls))
+	output.WriteString(fmt.Sprintf("Subtareas: %d\n", estimate.SubtaskCalls))
+	output.WriteString(fmt.Sprintf("Validaciones de parent: %d\n", estimate.ParentValidations))
+	output.WriteString(fmt.Sprintf("Features (busquedas/creaciones): %d/%d\n", estimate.FeatureSearches, estimate.FeatureCreates))
+	output.WriteString(fmt.Sprintf("Total de llamadas: %d\n", estimate.TotalCalls()))
+
+	if duration := estimate.EstimatedDuration(); duration > 0 {
+		output.WriteString(fmt.Sprintf("Tiempo estimado: %v (a %d requests/s)\n", duration.Round(time.Millisecond), estimate.RequestsPerSecond))
+	}
+
+	output.WriteString("\n")
+
+	return output.String()
+}
+
 func (of *OutputFormatter) formatProcessResults(result *entities.BatchResult) string {
 	var output strings.Builder
 
-	output.WriteString("=== DETALLE DE PROCESAMIENTO ===\n")
+	if of.onlyFailures {
+		output.WriteString("=== DETALLE DE PROCESAMIENTO (SOLO ERRORES) ===\n")
+		if result.ErrorRows == 0 {
+			output.WriteString("Sin filas con errores\n")
+		}
+	} else {
+		output.WriteString("=== DETALLE DE PROCESAMIENTO ===\n")
+	}
 
 	for _, processResult := range result.Results {
+		if processResult.Success && of.onlyFailures {
+			continue
+		}
+
 		if processResult.Success {
 			output.WriteString(fmt.Sprintf("[OK] Fila %d: %s\n", processResult.RowNumber, processResult.IssueKey))
 
 			if len(processResult.Subtareas) > 0 {
 				output.WriteString(of.formatSubtasks(processResult.Subtareas))
 			}
+
+			for _, warning := range processResult.Warnings {
+				output.WriteString(fmt.Sprintf("   [WARNING] %s\n", warning))
+			}
+		} else if processResult.IssueKey != "" {
+			// La historia se creo y fallo un paso posterior: la key sigue siendo util para revisarla en Jira
+			output.WriteString(fmt.Sprintf("[ERROR] Fila %d: %s (creada como %s)\n", processResult.RowNumber, processResult.ErrorMessage, processResult.IssueKey))
 		} else {
 			output.WriteString(fmt.Sprintf("[ERROR] Fila %d: %s\n", processResult.RowNumber, processResult.ErrorMessage))
 		}
@@ -273,7 +763,7 @@ func (of *OutputFormatter) formatFooter(result *entities.BatchResult) string {
 		output.WriteString("[OK] Procesamiento completado exitosamente\n")
 
 		issues := result.GetProcessedIssues()
-		if len(issues) > 0 {
+		if len(issues) > 0 && !of.onlyFailures {
 			output.WriteString(fmt.Sprintf("Issues creados: %s\n", strings.Join(issues, ", ")))
 		}
 	} else if result.HasErrors() {
@@ -282,5 +772,9 @@ func (of *OutputFormatter) formatFooter(result *entities.BatchResult) string {
 		output.WriteString("[WARNING] No se procesaron historias\n")
 	}
 
+	if result.ResultsFile != "" {
+		output.WriteString(fmt.Sprintf("Resultados: %s\n", result.ResultsFile))
+	}
+
 	return output.String()
 }
@@ -1,7 +1,9 @@
 package formatters
 
 import (
+	"encoding/json"
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -52,6 +54,270 @@ func TestOutputFormatter_FormatBatchResult(t *testing.T) {
 	}
 }
 
+func TestOutputFormatter_FormatBatchResult_ReportOnlyFailures(t *testing.T) {
+	formatter := NewOutputFormatter()
+	formatter.SetReportOnlyFailures(true)
+
+	batchResult := entities.NewBatchResult("test.csv", 3, false)
+
+	for _, key := range []string{"PROJ-1", "PROJ-2"} {
+		successResult := entities.NewProcessResult(len(batchResult.Results) + 2)
+		successResult.Success = true
+		successResult.IssueKey = key
+		batchResult.AddResult(successResult)
+	}
+
+	errorResult := entities.NewProcessResult(4)
+	errorResult.Success = false
+	errorResult.ErrorMessage = "Test error"
+	batchResult.AddResult(errorResult)
+	batchResult.Finish()
+
+	output := formatter.FormatBatchResult(batchResult)
+
+	for _, expected := range []string{
+		"Filas procesadas: 3",
+		"[OK] Exitosas: 2",
+		"[ERROR] Con errores: 1",
+		"(SOLO ERRORES)",
+		"[ERROR] Fila 4: Test error",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+
+	for _, unexpected := range []string{"PROJ-1", "PROJ-2"} {
+		if strings.Contains(output, unexpected) {
+			t.Errorf("Output should not contain successful row %q, got: %s", unexpected, output)
+		}
+	}
+}
+
+func TestOutputFormatter_FormatBatchResult_ReportOnlyFailures_NoFailures(t *testing.T) {
+	formatter := NewOutputFormatter()
+	formatter.SetReportOnlyFailures(true)
+
+	batchResult := entities.NewBatchResult("test.csv", 1, false)
+	successResult := entities.NewProcessResult(2)
+	successResult.Success = true
+	successResult.IssueKey = "PROJ-1"
+	batchResult.AddResult(successResult)
+	batchResult.Finish()
+
+	output := formatter.FormatBatchResult(batchResult)
+
+	if !strings.Contains(output, "Sin filas con errores") {
+		t.Errorf("Output should state there are no failures, got: %s", output)
+	}
+	if strings.Contains(output, "PROJ-1") {
+		t.Errorf("Output should not list successful issues, got: %s", output)
+	}
+	if !strings.Contains(output, "[OK] Exitosas: 1") {
+		t.Errorf("Output should keep aggregate counts, got: %s", output)
+	}
+}
+
+func TestOutputFormatter_FormatBatchResult_FailedRowKeepsCreatedKey(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	batchResult := entities.NewBatchResult("test.csv", 2, false)
+
+	partial := entities.NewProcessResult(2)
+	partial.Success = false
+	partial.IssueKey = "PROJ-7"
+	partial.ErrorMessage = "could not link PROJ-7 to feature PROJ-1: status 404"
+	batchResult.AddResult(partial)
+
+	failed := entities.NewProcessResult(3)
+	failed.Success = false
+	failed.ErrorMessage = "status 400"
+	batchResult.AddResult(failed)
+	batchResult.Finish()
+
+	output := formatter.FormatBatchResult(batchResult)
+
+	if !strings.Contains(output, "[ERROR] Fila 2: could not link PROJ-7 to feature PROJ-1: status 404 (creada como PROJ-7)") {
+		t.Errorf("Output should show the created key of a partially created row, got: %s", output)
+	}
+	if !strings.Contains(output, "[ERROR] Fila 3: status 400\n") {
+		t.Errorf("Output should keep the plain error line for rows without issue, got: %s", output)
+	}
+}
+
+func TestOutputFormatter_FormatBatchResult_FailuresByCode(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	batchResult := entities.NewBatchResult("test.csv", 5, false)
+	for i, message := range []string{
+		"jira error: ; summary: Summary is required",
+		"error creating issue: status 401, body: ",
+		"jira error: ; priority: invalid",
+		"error creating issue: status 429, body: ",
+	} {
+		result := entities.NewProcessResult(i + 2)
+		result.ErrorMessage = message
+		batchResult.AddResult(result)
+	}
+	success := entities.NewProcessResult(6)
+	success.Success = true
+	batchResult.AddResult(success)
+	batchResult.Finish()
+
+	expected := "Errores por tipo: VALIDATION: 2, AUTH: 1, RATE_LIMITED: 1"
+	if output := formatter.FormatBatchResult(batchResult); !strings.Contains(output, expected) {
+		t.Errorf("Output should contain %q, got: %s", expected, output)
+	}
+
+	other := entities.NewBatchResult("other.csv", 1, false)
+	authError := entities.NewProcessResult(2)
+	authError.ErrorMessage = "error creating issue: status 403, body: "
+	other.AddResult(authError)
+
+	expected = "Errores por tipo: AUTH: 2, VALIDATION: 2, RATE_LIMITED: 1"
+	if output := formatter.FormatMultipleBatchResults([]*entities.BatchResult{batchResult, other}); !strings.Contains(output, expected) {
+		t.Errorf("Output should contain %q, got: %s", expected, output)
+	}
+
+	clean := entities.NewBatchResult("clean.csv", 1, false)
+	clean.AddResult(success)
+	if output := formatter.FormatBatchResult(clean); strings.Contains(output, "Errores por tipo") {
+		t.Errorf("Output without failures should not group errors, got: %s", output)
+	}
+}
+
+func TestOutputFormatter_FormatBatchResult_FeatureCounters(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	withoutFeatures := entities.NewBatchResult("plain.csv", 1, false)
+	withoutFeatures.Finish()
+	if output := formatter.FormatBatchResult(withoutFeatures); strings.Contains(output, "Features creadas") {
+		t.Errorf("Output should omit feature counters when no features were used, got: %s", output)
+	}
+
+	first := entities.NewBatchResult("a.csv", 2, false)
+	first.FeaturesCreated = 2
+	first.FeaturesReused = 1
+	first.Finish()
+
+	second := entities.NewBatchResult("b.csv", 1, false)
+	second.FeaturesReused = 3
+	second.Finish()
+
+	output := formatter.FormatBatchResult(first)
+	for _, expected := range []string{"Features creadas: 2", "Features reutilizadas: 1"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+
+	output = formatter.FormatMultipleBatchResults([]*entities.BatchResult{first, second})
+	for _, expected := range []string{"Features creadas: 2", "Features reutilizadas: 4"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestOutputFormatter_FormatBatchResult_RolledBack(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	result := entities.NewBatchResult("a.csv", 2, false)
+	result.RolledBack = []string{"PROJ-2", "PROJ-1"}
+	result.Finish()
+
+	if output := formatter.FormatBatchResult(result); !strings.Contains(output, "Rollback: 2 issues eliminados (PROJ-2, PROJ-1)") {
+		t.Errorf("Output should list the rolled back issues, got: %s", output)
+	}
+}
+
+func TestOutputFormatter_FormatBatchResult_ResultsFile(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	result := entities.NewBatchResult("a.csv", 0, false)
+	result.ResultsFile = "logs/results/a_20240305-143000.results.json"
+	result.Finish()
+
+	if output := formatter.FormatBatchResult(result); !strings.Contains(output, "Resultados: logs/results/a_20240305-143000.results.json") {
+		t.Errorf("Output should point to the results file, got: %s", output)
+	}
+}
+
+func TestOutputFormatter_FormatDeleteResults(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	output := formatter.FormatDeleteResults([]*entities.DeleteResult{
+		{IssueKey: "PROJ-1", Deleted: true},
+		{IssueKey: "PROJ-2", AlreadyDeleted: true},
+		{IssueKey: "PROJ-3", Error: "permission denied: cannot delete PROJ-3"},
+	})
+
+	for _, expected := range []string{
+		"[OK] PROJ-1 eliminado",
+		"[WARNING] PROJ-2 no existe o ya fue eliminado",
+		"[ERROR] PROJ-3: permission denied: cannot delete PROJ-3",
+		"Eliminados: 1, ya eliminados: 1, con errores: 1",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestOutputFormatter_FormatBatchResult_SubtaskTotals(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	first := entities.NewBatchResult("a.csv", 1, false)
+	first.TotalSubtasksCreated = 3
+	first.TotalSubtasksFailed = 1
+	first.Finish()
+
+	second := entities.NewBatchResult("b.csv", 1, false)
+	second.TotalSubtasksCreated = 2
+	second.Finish()
+
+	output := formatter.FormatBatchResult(first)
+	for _, expected := range []string{"Subtareas creadas: 3", "Subtareas con errores: 1"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+
+	output = formatter.FormatMultipleBatchResults([]*entities.BatchResult{first, second})
+	for _, expected := range []string{"Subtareas creadas: 5", "Subtareas con errores: 1"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestOutputFormatter_FormatRateLimitSummary(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	if output := formatter.FormatRateLimitSummary(entities.RateLimitStats{}); output != "" {
+		t.Errorf("Expected empty summary without rate limit events, got: %s", output)
+	}
+
+	stats := entities.RateLimitStats{}
+	for i := 0; i < 4; i++ {
+		stats.RecordThrottle()
+	}
+	stats.RecordRetryAfter(2 * time.Second)
+	stats.RecordRetryAfter(3 * time.Second)
+
+	output := formatter.FormatRateLimitSummary(stats)
+
+	for _, expected := range []string{
+		"=== LIMITE DE TASA (RATE LIMIT) ===",
+		"Respuestas 429 recibidas: 4",
+		"Esperas Retry-After: 2 (total 5s)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+}
+
 func TestOutputFormatter_FormatBatchResult_DryRun(t *testing.T) {
 	formatter := NewOutputFormatter()
 
@@ -65,6 +331,22 @@ func TestOutputFormatter_FormatBatchResult_DryRun(t *testing.T) {
 	}
 }
 
+func TestOutputFormatter_FormatBatchResult_APIEstimate(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	batchResult := entities.NewBatchResult("test.csv", 2, true)
+	batchResult.APIEstimate = &entities.APIEstimate{StoryCalls: 2, SubtaskCalls: 3, FeatureSearches: 1, FeatureCreates: 1, RequestsPerSecond: 5}
+	batchResult.Finish()
+
+	output := formatter.FormatBatchResult(batchResult)
+
+	for _, want := range []string{"=== ESTIMACION DE LLAMADAS A JIRA ===", "Total de llamadas: 7", "Tiempo estimado: 1.4s (a 5 requests/s)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got: %s", want, output)
+		}
+	}
+}
+
 func TestOutputFormatter_FormatMultipleBatchResults(t *testing.T) {
 	formatter := NewOutputFormatter()
 
@@ -107,6 +389,13 @@ func TestOutputFormatter_FormatValidation(t *testing.T) {
 		WithParent:      1,
 		InvalidSubtasks: 1,
 		Preview:         "Sample preview",
+		Warnings:        []string{"las columnas podrian estar invertidas"},
+		Errors:          []string{"fila 3: sin criterios de aceptacion"},
+		SubtaskHistogram: []usecases.SubtaskBucket{
+			{Label: "0", Stories: 1},
+			{Label: "1-3", Stories: 1},
+			{Label: "4+", Stories: 1},
+		},
 	}
 
 	output := formatter.FormatValidation("test.csv", validationResult, nil)
@@ -119,7 +408,10 @@ func TestOutputFormatter_FormatValidation(t *testing.T) {
 		"Con subtareas: 2",
 		"Total subtareas: 5",
 		"Con parent: 1",
+		"Historias por cantidad de subtareas: 0: 1, 1-3: 1, 4+: 1",
 		"Subtareas invalidas: 1",
+		"[WARNING] las columnas podrian estar invertidas",
+		"[ERROR] fila 3: sin criterios de aceptacion",
 		"Sample preview",
 	}
 
@@ -130,6 +422,41 @@ func TestOutputFormatter_FormatValidation(t *testing.T) {
 	}
 }
 
+func TestOutputFormatter_FormatDirectoryValidation(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	dirResult := &usecases.DirectoryValidationResult{
+		Files: []*usecases.FileValidation{
+			{FilePath: "entrada/a.csv", Result: &usecases.ValidationResult{TotalStories: 2, TotalSubtasks: 3, WithParent: 1}},
+			{FilePath: "entrada/b.csv", Err: errors.New("file contains no valid stories")},
+		},
+		Totals:       &usecases.ValidationResult{TotalStories: 2, WithSubtasks: 1, TotalSubtasks: 3, WithParent: 1},
+		ValidFiles:   1,
+		InvalidFiles: 1,
+	}
+
+	output := formatter.FormatDirectoryValidation("entrada", dirResult, nil)
+
+	for _, expected := range []string{
+		"=== VALIDACION DE DIRECTORIO ===",
+		"Archivos: 2",
+		"[OK] Archivos validos: 1",
+		"[ERROR] Archivos con errores: 1",
+		"Total de historias: 2",
+		"[OK] entrada/a.csv: 2 historias, 3 subtareas, 1 con parent",
+		"[ERROR] entrada/b.csv: file contains no valid stories",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+
+	output = formatter.FormatDirectoryValidation("entrada", nil, errors.New("no files found in entrada"))
+	if !strings.Contains(output, "[ERROR] Validacion fallida: no files found in entrada") {
+		t.Errorf("Output should contain the error, got: %s", output)
+	}
+}
+
 func TestOutputFormatter_FormatValidation_WithError(t *testing.T) {
 	formatter := NewOutputFormatter()
 
@@ -180,6 +507,35 @@ func TestOutputFormatter_FormatConnectionTest(t *testing.T) {
 	}
 }
 
+func TestOutputFormatter_FormatPreflight(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	report := &usecases.PreflightReport{
+		Sections: []*usecases.PreflightSection{
+			{Name: "Configuracion", Passed: true},
+			{Name: "Conexion con Jira", Passed: false, Details: []string{"autenticacion: status 401"}},
+		},
+	}
+	output := formatter.FormatPreflight(report)
+
+	for _, expected := range []string{
+		"=== PREFLIGHT ===",
+		"[OK] Configuracion",
+		"[ERROR] Conexion con Jira",
+		"  - autenticacion: status 401",
+		"RESULTADO: NO APTO para importar",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+
+	report.Sections[1].Passed = true
+	if output := formatter.FormatPreflight(report); !strings.Contains(output, "RESULTADO: APTO para importar") {
+		t.Errorf("Expected passing result, got: %s", output)
+	}
+}
+
 func TestOutputFormatter_FormatDiagnosis(t *testing.T) {
 	formatter := NewOutputFormatter()
 
@@ -280,3 +636,250 @@ func TestOutputFormatter_ProcessResults_WithSubtasks(t *testing.T) {
 		}
 	}
 }
+
+func TestOutputFormatter_FormatHistory(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	output := formatter.FormatHistory(nil)
+	if !strings.Contains(output, "Sin ejecuciones registradas") {
+		t.Errorf("Expected empty history message, got: %s", output)
+	}
+
+	summaries := []*entities.RunSummary{
+		{
+			Date:           time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC),
+			FileName:       "sprint2.xlsx",
+			TotalRows:      4,
+			SuccessfulRows: 3,
+			SuccessRate:    75,
+			Duration:       1500 * time.Millisecond,
+			DryRun:         true,
+		},
+		{
+			Date:           time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			FileName:       "sprint1.csv",
+			TotalRows:      10,
+			SuccessfulRows: 10,
+			SuccessRate:    100,
+			Duration:       3 * time.Second,
+		},
+	}
+
+	output = formatter.FormatHistory(summaries)
+
+	for _, expected := range []string{
+		"=== HISTORIAL DE EJECUCIONES ===",
+		"2024-01-02 10:30:00",
+		"sprint2.xlsx (dry-run)",
+		"3/4",
+		"75.0%",
+		"1.5s",
+		"sprint1.csv",
+		"100.0%",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestOutputFormatter_FormatBatchResultMarkdown(t *testing.T) {
+	formatter := NewOutputFormatter()
+
+	result := entities.NewBatchResult("sprint.csv", 2, false)
+
+	created := entities.NewProcessResult(2)
+	created.Success = true
+	created.IssueKey = "PROJ-1"
+	created.IssueURL = "https://jira.example.com/browse/PROJ-1"
+	created.Summary = "Login de usuario"
+	created.AddSubtaskResult("Crear formulario", true, "PROJ-2", "https://jira.example.com/browse/PROJ-2", "")
+	created.AddSubtaskResult("Validar campos", false, "", "", "field required")
+	result.AddResult(created)
+
+	failed := entities.NewProcessResult(3)
+	failed.Success = false
+	failed.Summary = "Logout"
+	failed.ErrorMessage = "jira error"
+	result.AddResult(failed)
+
+	output := formatter.FormatBatchResultMarkdown(result)
+
+	for _, expected := range []string{
+		"## Historias: sprint.csv",
+		"- [x] [PROJ-1](https://jira.example.com/browse/PROJ-1) Login de usuario\n",
+		"  - [x] [PROJ-2](https://jira.example.com/browse/PROJ-2) Crear formulario\n",
+		"  - [ ] Validar campos (error: field required)\n",
+		"- [ ] Fila 3: Logout (error: jira error)\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Markdown should contain %q, got: %s", expected, output)
+		}
+	}
+
+	empty := formatter.FormatBatchResultMarkdown(entities.NewBatchResult("vacio.csv", 0, true))
+	if !strings.Contains(empty, "## Historias: vacio.csv (dry-run)") || !strings.Contains(empty, "Sin historias procesadas") {
+		t.Errorf("Unexpected markdown for empty batch: %s", empty)
+	}
+}
+
+func TestOutputFormatter_FormatJSON(t *testing.T) {
+	result := entities.NewBatchResult("test.csv", 1, true)
+	result.AddError("Warning: example")
+
+	formatter := NewOutputFormatter()
+	compact, err := formatter.FormatJSON(result)
+	if err != nil {
+		t.Fatalf("FormatJSON() error = %v", err)
+	}
+	if strings.Count(compact, "\n") != 1 || strings.Contains(compact, "  ") {
+		t.Errorf("Expected compact single-line JSON by default, got %q", compact)
+	}
+
+	formatter.SetPrettyJSON(true)
+	pretty, err := formatter.FormatJSON(result)
+	if err != nil {
+		t.Fatalf("FormatJSON() error = %v", err)
+	}
+	if !strings.Contains(pretty, "\n  \"") {
+		t.Errorf("Expected indented JSON with --pretty, got %q", pretty)
+	}
+
+	var a, b interface{}
+	if err := json.Unmarshal([]byte(compact), &a); err != nil {
+		t.Fatalf("compact output is not valid JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(pretty), &b); err != nil {
+		t.Fatalf("pretty output is not valid JSON: %v", err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Error("Expected compact and pretty output to encode the same result")
+	}
+}
+
+func TestOutputFormatter_FormatBatchResultJSON(t *testing.T) {
+	result := entities.NewBatchResult("test.csv", 2, false)
+	ok := entities.NewProcessResult(2)
+	ok.Success = true
+	ok.IssueKey = "PROJ-1"
+	ok.IssueURL = "https://jira.test/browse/PROJ-1"
+	ok.AddSubtaskResult("Subtarea", true, "PROJ-2", "https://jira.test/browse/PROJ-2", "")
+	result.AddResult(ok)
+	failed := entities.NewProcessResult(3)
+	failed.ErrorMessage = "field required"
+	result.AddResult(failed)
+	result.Finish()
+
+	formatter := NewOutputFormatter()
+	output, err := formatter.FormatBatchResultJSON(result)
+	if err != nil {
+		t.Fatalf("FormatBatchResultJSON() error = %v", err)
+	}
+
+	var decoded entities.BatchResult
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if decoded.SuccessfulRows != 1 || decoded.ErrorRows != 1 || len(decoded.Results) != 2 {
+		t.Errorf("Unexpected totals after round-trip: %+v", decoded)
+	}
+	if decoded.Results[0].IssueKey != "PROJ-1" || decoded.Results[0].Subtareas[0].IssueURL != "https://jira.test/browse/PROJ-2" {
+		t.Errorf("Expected issue keys and subtask URLs, got %+v", decoded.Results[0])
+	}
+	if decoded.Results[1].ErrorMessage != "field required" {
+		t.Errorf("Expected the row error message, got %q", decoded.Results[1].ErrorMessage)
+	}
+
+	for _, key := range []string{`"dry_run":false`, `"issue_url":"https://jira.test/browse/PROJ-1"`, `"subtareas":[`, `"error_message":"field required"`} {
+		if !strings.Contains(output, key) {
+			t.Errorf("JSON should contain %s, got: %s", key, output)
+		}
+	}
+
+	dryRun, err := formatter.FormatBatchResultJSON(entities.NewBatchResult("test.csv", 0, true))
+	if err != nil {
+		t.Fatalf("FormatBatchResultJSON() error = %v", err)
+	}
+	if !strings.Contains(dryRun, `"dry_run":true`) {
+		t.Errorf("Dry-run JSON should be flagged, got: %s", dryRun)
+	}
+}
+
+func TestOutputFormatter_FormatMultipleBatchResultsJSON(t *testing.T) {
+	first := entities.NewBatchResult("a.csv", 1, true)
+	row := entities.NewProcessResult(2)
+	row.Success = true
+	first.AddResult(row)
+	second := entities.NewBatchResult("b.csv", 0, true)
+
+	output, err := NewOutputFormatter().FormatMultipleBatchResultsJSON([]*entities.BatchResult{first, second})
+	if err != nil {
+		t.Fatalf("FormatMultipleBatchResultsJSON() error = %v", err)
+	}
+
+	var decoded struct {
+		Files          int                     `json:"files"`
+		SuccessfulRows int                     `json:"successful_rows"`
+		Results        []*entities.BatchResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if decoded.Files != 2 || decoded.SuccessfulRows != 1 || len(decoded.Results) != 2 || decoded.Results[1].FileName != "b.csv" {
+		t.Errorf("Unexpected multiple batch JSON: %s", output)
+	}
+}
+
+func TestOutputFormatter_FormatValidationJSON(t *testing.T) {
+	formatter := NewOutputFormatter()
+	result := &usecases.ValidationResult{TotalStories: 3, WithSubtasks: 1, Preview: "tabla", Warnings: []string{"fila 2: aviso"}}
+
+	output, err := formatter.FormatValidationJSON("test.csv", result, nil)
+	if err != nil {
+		t.Fatalf("FormatValidationJSON() error = %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if decoded["file"] != "test.csv" || decoded["valid"] != true {
+		t.Errorf("Unexpected validation JSON: %s", output)
+	}
+	body := decoded["result"].(map[string]interface{})
+	for _, key := range []string{"total_stories", "with_subtasks", "warnings", "errors"} {
+		if _, ok := body[key]; !ok {
+			t.Errorf("result should contain %q, got: %s", key, output)
+		}
+	}
+	if _, ok := body["Preview"]; ok || strings.Contains(output, "tabla") {
+		t.Errorf("Preview table should not be part of the JSON, got: %s", output)
+	}
+
+	failed, err := formatter.FormatValidationJSON("roto.csv", nil, errors.New("missing required columns"))
+	if err != nil {
+		t.Fatalf("FormatValidationJSON() error = %v", err)
+	}
+	if !strings.Contains(failed, `"valid":false`) || !strings.Contains(failed, `"error":"missing required columns"`) {
+		t.Errorf("Expected a failed validation, got: %s", failed)
+	}
+
+	dirResult := &usecases.DirectoryValidationResult{
+		Files: []*usecases.FileValidation{
+			{FilePath: "ok.csv", Result: result},
+			{FilePath: "roto.csv", Err: errors.New("missing required columns")},
+		},
+		Totals:       result,
+		ValidFiles:   1,
+		InvalidFiles: 1,
+	}
+	dir, err := formatter.FormatDirectoryValidationJSON("entrada", dirResult, nil)
+	if err != nil {
+		t.Fatalf("FormatDirectoryValidationJSON() error = %v", err)
+	}
+	for _, key := range []string{`"dir":"entrada"`, `"valid_files":1`, `"invalid_files":1`, `"file":"roto.csv","valid":false`} {
+		if !strings.Contains(dir, key) {
+			t.Errorf("Directory JSON should contain %s, got: %s", key, dir)
+		}
+	}
+}
@@ -167,6 +167,53 @@ Historia válida,Descripción de prueba,Criterio de aceptación,Subtarea1;Subtar
 	}
 }
 
+func TestValidateSubtaskDelimiter_Integration(t *testing.T) {
+	tempDir := t.TempDir()
+	originalEnv := setupTestEnvironment(t, tempDir)
+	defer restoreEnvironment(originalEnv)
+	t.Setenv("SUBTASK_DELIMITER", "|")
+
+	csvFile := filepath.Join(tempDir, "delimitador.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte(`titulo,descripcion,criterio_aceptacion,subtareas
+Historia 1,Descripcion,Criterio,"Configurar DNS; TTL bajo; puerto 53|Deploy"
+Historia 2,Descripcion,Criterio,"Smoke test
+Rollback"
+`), 0644))
+
+	app, err := createTestApp(tempDir)
+	require.NoError(t, err)
+	defer app.logger.Close()
+
+	// Con "|" el punto y coma queda dentro de la subtarea; los saltos de linea siguen separando
+	result, err := app.validateUseCase.Execute(context.Background(), csvFile, "", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.TotalStories)
+	assert.Equal(t, 4, result.TotalSubtasks)
+}
+
+func TestValidateColumnAliases_Integration(t *testing.T) {
+	tempDir := t.TempDir()
+	originalEnv := setupTestEnvironment(t, tempDir)
+	defer restoreEnvironment(originalEnv)
+	t.Setenv("COLUMN_ALIASES", `{"titulo": ["Title"], "descripcion": ["Description"], "criterio_aceptacion": ["Acceptance Criteria"], "subtareas": ["Subtasks"]}`)
+
+	csvFile := filepath.Join(tempDir, "english.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte(`Title,Description,Acceptance Criteria,Subtasks
+Login,Como usuario quiero ingresar,Ingresa con email,Formulario;API
+Logout,Como usuario quiero salir,Sale de la sesion,
+`), 0644))
+
+	app, err := createTestApp(tempDir)
+	require.NoError(t, err)
+	defer app.logger.Close()
+
+	result, err := app.validateUseCase.Execute(context.Background(), csvFile, "", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.TotalStories)
+	assert.Equal(t, 2, result.TotalSubtasks)
+	assert.Contains(t, result.Preview, "Login")
+}
+
 func TestProcessMultipleFiles_Integration(t *testing.T) {
 	tempDir := t.TempDir()
 	inputDir := filepath.Join(tempDir, "entrada")
@@ -226,6 +273,43 @@ Historia 2,Descripción 2,Criterio 2,Sub3,PROJ-456`,
 	assert.Contains(t, logStr, "dry_run:true")
 }
 
+func TestWatchDirectory_Integration(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "entrada")
+	processedDir := filepath.Join(tempDir, "procesados")
+	require.NoError(t, os.MkdirAll(inputDir, 0755))
+
+	originalEnv := setupTestEnvironment(t, tempDir)
+	defer restoreEnvironment(originalEnv)
+
+	app, err := createTestApp(tempDir)
+	require.NoError(t, err)
+	defer app.logger.Close()
+
+	watch := usecases.NewWatchDirectoryUseCase(app.processUseCase, filesystem.NewFileProcessor(processedDir))
+	watch.SetInterval(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- watch.Run(ctx, inputDir, "TEST-PROJ", false)
+	}()
+
+	// El archivo aparece mientras watch ya esta corriendo
+	csvContent := `titulo,descripcion,criterio_aceptacion,subtareas,parent
+Historia 1,Descripción 1,Criterio 1,Sub1;Sub2,`
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, "nuevas.csv"), []byte(csvContent), 0644))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(processedDir, "nuevas.csv"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond, "watch should process the new file and move it to processed")
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.NoFileExists(t, filepath.Join(inputDir, "nuevas.csv"))
+}
+
 // Helper functions
 
 func setupTestEnvironment(t *testing.T, tempDir string) map[string]string {
@@ -275,6 +359,14 @@ func createTestApp(tempDir string) (*App, error) {
 	}
 
 	fileRepo := filesystem.NewFileProcessor(cfg.ProcessedDirectory)
+	fileRepo.SetSubtaskDelimiter(cfg.GetSubtaskDelimiter())
+	if err := fileRepo.SetColumnAliases(cfg.GetColumnAliases()); err != nil {
+		return nil, err
+	}
+	fileRepo.SetSheet(cfg.ExcelSheet)
+	if err := fileRepo.SetInputEncoding(cfg.InputEncoding); err != nil {
+		return nil, err
+	}
 
 	// Use mock Jira repository for safety - even in dry-run we don't want real API calls
 	jiraRepo := &mocks.MockJiraRepository{
@@ -290,7 +382,7 @@ func createTestApp(tempDir string) (*App, error) {
 		TestConnectionFunc: func(ctx context.Context) error {
 			return nil // Always connected for integration tests
 		},
-		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+		CreateUserStoryFunc: func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 			// Mock successful creation for integration tests
 			result := entities.NewProcessResult(rowNumber)
 			result.Success = true
@@ -319,6 +411,9 @@ func createTestApp(tempDir string) (*App, error) {
 
 	// Create use cases
 	processUseCase := usecases.NewProcessFilesUseCase(fileRepo, jiraRepo, featureManager)
+	if cfg.StreamCSV {
+		processUseCase.SetStoryStreamer(fileRepo)
+	}
 	validateUseCase := usecases.NewValidateFileUseCase(fileRepo, jiraRepo)
 	testConnectionUseCase := usecases.NewTestConnectionUseCase(jiraRepo)
 	diagnoseUseCase := usecases.NewDiagnoseFeaturesUseCase(featureManager)
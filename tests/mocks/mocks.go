@@ -12,6 +12,9 @@ type MockFileRepository struct {
 	ValidateFileFunc    func(ctx context.Context, filePath string) error
 	MoveToProcessedFunc func(ctx context.Context, filePath string) error
 	GetPendingFilesFunc func(ctx context.Context, inputDir string) ([]string, error)
+	ComputeHashFunc     func(ctx context.Context, filePath string) (string, error)
+	DetectEncodingFunc  func(ctx context.Context, filePath string) (string, error)
+	ExistsFunc          func(ctx context.Context, filePath string) (bool, error)
 }
 
 func (m *MockFileRepository) ReadFile(ctx context.Context, filePath string) ([]*entities.UserStory, error) {
@@ -42,15 +45,100 @@ func (m *MockFileRepository) GetPendingFiles(ctx context.Context, inputDir strin
 	return nil, nil
 }
 
+func (m *MockFileRepository) ComputeHash(ctx context.Context, filePath string) (string, error) {
+	if m.ComputeHashFunc != nil {
+		return m.ComputeHashFunc(ctx, filePath)
+	}
+	return "", nil
+}
+
+func (m *MockFileRepository) DetectEncoding(ctx context.Context, filePath string) (string, error) {
+	if m.DetectEncodingFunc != nil {
+		return m.DetectEncodingFunc(ctx, filePath)
+	}
+	return "", nil
+}
+
+func (m *MockFileRepository) Exists(ctx context.Context, filePath string) (bool, error) {
+	if m.ExistsFunc != nil {
+		return m.ExistsFunc(ctx, filePath)
+	}
+	return true, nil
+}
+
+// MockFileLedger is a mock implementation of repositories.FileLedger
+type MockFileLedger struct {
+	IsProcessedFunc   func(ctx context.Context, hash string) (bool, error)
+	MarkProcessedFunc func(ctx context.Context, hash, filePath string) error
+}
+
+func (m *MockFileLedger) IsProcessed(ctx context.Context, hash string) (bool, error) {
+	if m.IsProcessedFunc != nil {
+		return m.IsProcessedFunc(ctx, hash)
+	}
+	return false, nil
+}
+
+func (m *MockFileLedger) MarkProcessed(ctx context.Context, hash, filePath string) error {
+	if m.MarkProcessedFunc != nil {
+		return m.MarkProcessedFunc(ctx, hash, filePath)
+	}
+	return nil
+}
+
+// MockRunHistory is a mock implementation of repositories.RunHistory
+type MockRunHistory struct {
+	AppendFunc func(ctx context.Context, summary *entities.RunSummary) error
+	ListFunc   func(ctx context.Context) ([]*entities.RunSummary, error)
+}
+
+func (m *MockRunHistory) Append(ctx context.Context, summary *entities.RunSummary) error {
+	if m.AppendFunc != nil {
+		return m.AppendFunc(ctx, summary)
+	}
+	return nil
+}
+
+func (m *MockRunHistory) List(ctx context.Context) ([]*entities.RunSummary, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// MockResultsStore is a mock implementation of repositories.ResultsStore
+type MockResultsStore struct {
+	SaveFunc func(ctx context.Context, result *entities.BatchResult) (string, error)
+	LoadFunc func(ctx context.Context, path string) (*entities.BatchResult, error)
+}
+
+func (m *MockResultsStore) Save(ctx context.Context, result *entities.BatchResult) (string, error) {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, result)
+	}
+	return "", nil
+}
+
+func (m *MockResultsStore) Load(ctx context.Context, path string) (*entities.BatchResult, error) {
+	if m.LoadFunc != nil {
+		return m.LoadFunc(ctx, path)
+	}
+	return nil, nil
+}
+
 // MockJiraRepository is a mock implementation of repositories.JiraRepository
 type MockJiraRepository struct {
 	TestConnectionFunc           func(ctx context.Context) error
 	ValidateProjectFunc          func(ctx context.Context, projectKey string) error
 	ValidateSubtaskIssueTypeFunc func(ctx context.Context, projectKey string) error
+	ValidateStoryIssueTypeFunc   func(ctx context.Context, projectKey string) error
 	ValidateFeatureIssueTypeFunc func(ctx context.Context) error
 	ValidateParentIssueFunc      func(ctx context.Context, issueKey string) error
-	CreateUserStoryFunc          func(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error)
+	CreateUserStoryFunc          func(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error)
 	GetIssueTypesFunc            func(ctx context.Context) ([]map[string]interface{}, error)
+	GetPrioritiesFunc            func(ctx context.Context) ([]string, error)
+	CreateIssueLinkFunc          func(ctx context.Context, inwardKey, outwardKey, linkType string) error
+	DeleteIssueFunc              func(ctx context.Context, issueKey string) error
 }
 
 func (m *MockJiraRepository) TestConnection(ctx context.Context) error {
@@ -74,6 +162,13 @@ func (m *MockJiraRepository) ValidateSubtaskIssueType(ctx context.Context, proje
 	return nil
 }
 
+func (m *MockJiraRepository) ValidateStoryIssueType(ctx context.Context, projectKey string) error {
+	if m.ValidateStoryIssueTypeFunc != nil {
+		return m.ValidateStoryIssueTypeFunc(ctx, projectKey)
+	}
+	return nil
+}
+
 func (m *MockJiraRepository) ValidateFeatureIssueType(ctx context.Context) error {
 	if m.ValidateFeatureIssueTypeFunc != nil {
 		return m.ValidateFeatureIssueTypeFunc(ctx)
@@ -88,9 +183,9 @@ func (m *MockJiraRepository) ValidateParentIssue(ctx context.Context, issueKey s
 	return nil
 }
 
-func (m *MockJiraRepository) CreateUserStory(ctx context.Context, story *entities.UserStory, rowNumber int) (*entities.ProcessResult, error) {
+func (m *MockJiraRepository) CreateUserStory(ctx context.Context, story *entities.UserStory, projectKey string, rowNumber int) (*entities.ProcessResult, error) {
 	if m.CreateUserStoryFunc != nil {
-		return m.CreateUserStoryFunc(ctx, story, rowNumber)
+		return m.CreateUserStoryFunc(ctx, story, projectKey, rowNumber)
 	}
 	return nil, nil
 }
@@ -102,11 +197,57 @@ func (m *MockJiraRepository) GetIssueTypes(ctx context.Context) ([]map[string]in
 	return nil, nil
 }
 
+func (m *MockJiraRepository) GetPriorities(ctx context.Context) ([]string, error) {
+	if m.GetPrioritiesFunc != nil {
+		return m.GetPrioritiesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockJiraRepository) CreateIssueLink(ctx context.Context, inwardKey, outwardKey, linkType string) error {
+	if m.CreateIssueLinkFunc != nil {
+		return m.CreateIssueLinkFunc(ctx, inwardKey, outwardKey, linkType)
+	}
+	return nil
+}
+
+func (m *MockJiraRepository) DeleteIssue(ctx context.Context, issueKey string) error {
+	if m.DeleteIssueFunc != nil {
+		return m.DeleteIssueFunc(ctx, issueKey)
+	}
+	return nil
+}
+
+// MockBulkStoryCreator is a mock implementation of repositories.BulkStoryCreator
+type MockBulkStoryCreator struct {
+	CreateUserStoriesFunc func(ctx context.Context, stories []*entities.UserStory, projectKey string, rowNumbers []int) ([]*entities.ProcessResult, error)
+}
+
+func (m *MockBulkStoryCreator) CreateUserStories(ctx context.Context, stories []*entities.UserStory, projectKey string, rowNumbers []int) ([]*entities.ProcessResult, error) {
+	if m.CreateUserStoriesFunc != nil {
+		return m.CreateUserStoriesFunc(ctx, stories, projectKey, rowNumbers)
+	}
+	return nil, nil
+}
+
+// MockStoryStreamer is a mock implementation of repositories.StoryStreamer
+type MockStoryStreamer struct {
+	ReadFileStreamFunc func(ctx context.Context, filePath string, fn func(story *entities.UserStory) error) error
+}
+
+func (m *MockStoryStreamer) ReadFileStream(ctx context.Context, filePath string, fn func(story *entities.UserStory) error) error {
+	if m.ReadFileStreamFunc != nil {
+		return m.ReadFileStreamFunc(ctx, filePath, fn)
+	}
+	return nil
+}
+
 // MockFeatureManager is a mock implementation of repositories.FeatureManager
 type MockFeatureManager struct {
 	CreateOrGetFeatureFunc            func(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error)
 	SearchExistingFeatureFunc         func(ctx context.Context, description, projectKey string) (string, error)
 	ValidateFeatureRequiredFieldsFunc func(ctx context.Context, projectKey string) ([]string, error)
+	ForgetFeatureFunc                 func(featureKey string)
 }
 
 func (m *MockFeatureManager) CreateOrGetFeature(ctx context.Context, description, projectKey string) (*entities.FeatureResult, error) {
@@ -130,6 +271,12 @@ func (m *MockFeatureManager) ValidateFeatureRequiredFields(ctx context.Context,
 	return nil, nil
 }
 
+func (m *MockFeatureManager) ForgetFeature(featureKey string) {
+	if m.ForgetFeatureFunc != nil {
+		m.ForgetFeatureFunc(featureKey)
+	}
+}
+
 // MockProcessFilesUseCase is a mock implementation of ProcessFilesUseCase
 type MockProcessFilesUseCase struct {
 	ExecuteFunc         func(ctx context.Context, filePath, projectKey string, dryRun bool) (*entities.BatchResult, error)